@@ -45,6 +45,7 @@ const (
 	varPostgresConnectionMaxIdle    = "postgres.connection.maxidle"
 	varPostgresConnectionMaxOpen    = "postgres.connection.maxopen"
 	varFeatureWorkitemRemote        = "feature.workitem.remote"
+	varWorkItemLinkGraphMaxDepth    = "workitemlink.graph.maxdepth"
 	varPopulateCommonTypes          = "populate.commontypes"
 	varHTTPAddress                  = "http.address"
 	varMetricsHTTPAddress           = "metrics.http.address"
@@ -73,6 +74,7 @@ const (
 	varCacheControlWorkItemTypes     = "cachecontrol.workitemtypes"
 	varCacheControlWorkItemLinks     = "cachecontrol.workitemLinks"
 	varCacheControlWorkItemLinkTypes = "cachecontrol.workitemlinktypes"
+	varCacheControlLinkTopologies    = "cachecontrol.linktopologies"
 	varCacheControlSpaces            = "cachecontrol.spaces"
 	varCacheControlIterations        = "cachecontrol.iterations"
 	varCacheControlAreas             = "cachecontrol.areas"
@@ -106,6 +108,8 @@ const (
 	varNotificationServiceURL   = "notification.serviceurl"
 	varTogglesServiceURL        = "toggles.serviceurl"
 	varDeploymentsHTTPTimeout   = "deployments.http.timeout"
+	varDeploymentsCacheTTL      = "deployments.http.cachettl"
+	varDeploymentsMaxRetries    = "deployments.http.maxretries"
 )
 
 // Registry encapsulates the Viper configuration registry which stores the
@@ -219,6 +223,7 @@ func (c *Registry) setConfigDefaults() {
 	c.v.SetDefault(varCacheControlAreas, "max-age=2")
 	c.v.SetDefault(varCacheControlComments, "max-age=2")
 	c.v.SetDefault(varCacheControlFilters, "max-age=86400")
+	c.v.SetDefault(varCacheControlLinkTopologies, "max-age=86400")
 	c.v.SetDefault(varCacheControlUsers, "max-age=2")
 	c.v.SetDefault(varCacheControlCollaborators, "max-age=2")
 
@@ -237,12 +242,15 @@ func (c *Registry) setConfigDefaults() {
 
 	// Features
 	c.v.SetDefault(varFeatureWorkitemRemote, true)
+	c.v.SetDefault(varWorkItemLinkGraphMaxDepth, defaultWorkItemLinkGraphMaxDepth)
 
 	c.v.SetDefault(varKeycloakTesUser2Name, defaultKeycloakTesUser2Name)
 	c.v.SetDefault(varOpenshiftTenantMasterURL, defaultOpenshiftTenantMasterURL)
 	c.v.SetDefault(varCheStarterURL, defaultCheStarterURL)
 	c.v.SetDefault(varTogglesServiceURL, defaultTogglesServiceURL)
 	c.v.SetDefault(varDeploymentsHTTPTimeout, defaultDeploymentsHTTPTimeout)
+	c.v.SetDefault(varDeploymentsCacheTTL, defaultDeploymentsCacheTTL)
+	c.v.SetDefault(varDeploymentsMaxRetries, defaultDeploymentsMaxRetries)
 }
 
 // GetPostgresHost returns the postgres host as set via default, config file, or environment variable
@@ -255,6 +263,12 @@ func (c *Registry) GetPostgresPort() int64 {
 	return c.v.GetInt64(varPostgresPort)
 }
 
+// GetWorkItemLinkGraphMaxDepth returns the maximum number of links that may
+// be followed from a work item when traversing its link graph
+func (c *Registry) GetWorkItemLinkGraphMaxDepth() int {
+	return c.v.GetInt(varWorkItemLinkGraphMaxDepth)
+}
+
 // GetFeatureWorkitemRemote returns true if remote Work Item feaute is enabled
 func (c *Registry) GetFeatureWorkitemRemote() bool {
 	return c.v.GetBool(varFeatureWorkitemRemote)
@@ -514,6 +528,12 @@ func (c *Registry) GetCacheControlFilters() string {
 	return c.v.GetString(varCacheControlFilters)
 }
 
+// GetCacheControlLinkTopologies returns the value to set in the "Cache-Control" HTTP response header
+// when returning the work item link type topologies.
+func (c *Registry) GetCacheControlLinkTopologies() string {
+	return c.v.GetString(varCacheControlLinkTopologies)
+}
+
 // GetCacheControlUsers returns the value to set in the "Cache-Control" HTTP response header
 // when returning users.
 func (c *Registry) GetCacheControlUsers() string {
@@ -843,6 +863,27 @@ func (c *Registry) GetDeploymentsHTTPTimeoutSeconds() time.Duration {
 	return time.Duration(timeout) * time.Second
 }
 
+// GetDeploymentsHTTPCacheTTL returns for how long connections to the Kubernetes
+// and OpenShift API servers are reused for the same cluster and user. Zero means
+// that connections are not reused between requests.
+func (c *Registry) GetDeploymentsHTTPCacheTTL() time.Duration {
+	ttl := c.v.GetInt(varDeploymentsCacheTTL)
+	if ttl < 0 {
+		ttl = 0
+	}
+	return time.Duration(ttl) * time.Second
+}
+
+// GetDeploymentsHTTPMaxRetries returns how often failed requests to the Kubernetes
+// and OpenShift API servers are retried. Zero means that requests are not retried.
+func (c *Registry) GetDeploymentsHTTPMaxRetries() int {
+	retries := c.v.GetInt(varDeploymentsMaxRetries)
+	if retries < 0 {
+		retries = 0
+	}
+	return retries
+}
+
 const (
 	defaultHeaderMaxLength = 5000 // bytes
 
@@ -869,11 +910,14 @@ const (
 	devModeKeycloakURL   = "https://sso.prod-preview.openshift.io"
 	devModeKeycloakRealm = "fabric8-test"
 
-	defaultOpenshiftTenantMasterURL = "https://tsrv.devshift.net:8443"
-	defaultTogglesServiceURL        = "http://f8toggles-service"
-	defaultCheStarterURL            = "che-server"
-	minimumDeploymentsHTTPTimeout   = 1
-	defaultDeploymentsHTTPTimeout   = 30
+	defaultOpenshiftTenantMasterURL  = "https://tsrv.devshift.net:8443"
+	defaultTogglesServiceURL         = "http://f8toggles-service"
+	defaultCheStarterURL             = "che-server"
+	minimumDeploymentsHTTPTimeout    = 1
+	defaultDeploymentsHTTPTimeout    = 30
+	defaultDeploymentsCacheTTL       = 300
+	defaultDeploymentsMaxRetries     = 2
+	defaultWorkItemLinkGraphMaxDepth = 10
 
 	// DefaultValidRedirectURLs is a regex to be used to whitelist redirect URL for auth
 	// If the F8_REDIRECT_VALID env var is not set then in Dev Mode all redirects allowed - *
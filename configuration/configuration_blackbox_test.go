@@ -215,3 +215,20 @@ func TestGetDeploymentsTimeoutDefault(t *testing.T) {
 	expectedTimeSeconds := time.Duration(30) * time.Second
 	assert.Equal(t, expectedTimeSeconds, viperValue)
 }
+
+func TestGetWorkItemLinkGraphMaxDepth(t *testing.T) {
+	resource.Require(t, resource.UnitTest)
+	t.Run("default", func(t *testing.T) {
+		require.Equal(t, 10, config.GetWorkItemLinkGraphMaxDepth())
+	})
+	t.Run("set by env variable", func(t *testing.T) {
+		env := os.Getenv("F8_WORKITEMLINK_GRAPH_MAXDEPTH")
+		defer func() {
+			os.Setenv("F8_WORKITEMLINK_GRAPH_MAXDEPTH", env)
+			resetConfiguration(defaultValuesConfigFilePath)
+		}()
+		os.Setenv("F8_WORKITEMLINK_GRAPH_MAXDEPTH", "3")
+		resetConfiguration(defaultValuesConfigFilePath)
+		require.Equal(t, 3, config.GetWorkItemLinkGraphMaxDepth())
+	})
+}
@@ -198,3 +198,29 @@ func TestDeploymentsTimeoutIsAlwaysPositive(t *testing.T) {
 
 	resetConfiguration()
 }
+
+func TestDeploymentsCacheTTLIsNeverNegative(t *testing.T) {
+	resource.Require(t, resource.UnitTest)
+
+	config.v.Set(varDeploymentsCacheTTL, 60)
+	assert.Equal(t, 60*time.Second, config.GetDeploymentsHTTPCacheTTL())
+
+	// Negative values disable the cache just like zero
+	config.v.Set(varDeploymentsCacheTTL, -5)
+	assert.Equal(t, time.Duration(0), config.GetDeploymentsHTTPCacheTTL())
+
+	resetConfiguration()
+}
+
+func TestDeploymentsMaxRetriesIsNeverNegative(t *testing.T) {
+	resource.Require(t, resource.UnitTest)
+
+	config.v.Set(varDeploymentsMaxRetries, 3)
+	assert.Equal(t, 3, config.GetDeploymentsHTTPMaxRetries())
+
+	// Negative values disable retries just like zero
+	config.v.Set(varDeploymentsMaxRetries, -1)
+	assert.Equal(t, 0, config.GetDeploymentsHTTPMaxRetries())
+
+	resetConfiguration()
+}
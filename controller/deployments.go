@@ -38,16 +38,22 @@ type ClientGetter interface {
 // Default implementation of KubeClientGetter and OSIOClientGetter used by NewDeploymentsController
 type defaultClientGetter struct {
 	config *configuration.Registry
+	// Shares connections between kube clients, nil if disabled
+	restAPIGetter kubernetes.RESTAPIGetter
 }
 
 // NewDeploymentsController creates a deployments controller.
 func NewDeploymentsController(service *goa.Service, config *configuration.Registry) *DeploymentsController {
+	clientGetter := &defaultClientGetter{
+		config: config,
+	}
+	if ttl := config.GetDeploymentsHTTPCacheTTL(); ttl > 0 {
+		clientGetter.restAPIGetter = kubernetes.NewCachingRESTAPIGetter(ttl)
+	}
 	return &DeploymentsController{
-		Controller: service.NewController("DeploymentsController"),
-		Config:     config,
-		ClientGetter: &defaultClientGetter{
-			config: config,
-		},
+		Controller:   service.NewController("DeploymentsController"),
+		Config:       config,
+		ClientGetter: clientGetter,
 	}
 }
 
@@ -267,8 +273,14 @@ func (g *defaultClientGetter) GetKubeClient(ctx context.Context) (kubernetes.Kub
 		BearerToken:   kubeToken,
 		UserNamespace: *kubeNamespaceName,
 		Timeout:       g.config.GetDeploymentsHTTPTimeoutSeconds(),
+		RequestID:     log.ExtractRequestID(ctx),
+		MaxRetries:    g.config.GetDeploymentsHTTPMaxRetries(),
 	}
-	kc, err := kubernetes.NewKubeClient(kubeConfig)
+	if g.restAPIGetter != nil {
+		kubeConfig.KubeRESTAPIGetter = g.restAPIGetter
+		kubeConfig.OpenShiftRESTAPIGetter = g.restAPIGetter
+	}
+	kc, err := kubernetes.NewKubeClient(ctx, kubeConfig)
 	if err != nil {
 		log.Error(ctx, map[string]interface{}{
 			"err":     err,
@@ -302,6 +314,10 @@ func (c *DeploymentsController) SetDeployment(ctx *app.SetDeploymentDeploymentsC
 
 	_ /*oldCount*/, err = kc.ScaleDeployment(*kubeSpaceName, ctx.AppName, ctx.DeployName, *ctx.PodCount)
 	if err != nil {
+		switch cause := errs.Cause(err).(type) {
+		case errors.NotFoundError, errors.BadParameterError:
+			return cause
+		}
 		return errors.NewInternalError(ctx, errs.Wrapf(err, "error scaling deployment %s", ctx.DeployName))
 	}
 
@@ -410,6 +426,10 @@ func (c *DeploymentsController) ShowDeploymentStats(ctx *app.ShowDeploymentStats
 
 	deploymentStats, err := kc.GetDeploymentStats(*kubeSpaceName, ctx.AppName, ctx.DeployName, startTime)
 	if err != nil {
+		switch cause := errs.Cause(err).(type) {
+		case errors.NotFoundError:
+			return cause
+		}
 		return errors.NewInternalError(ctx, errs.Wrapf(err, "could not retrieve deployment statistics for %s", ctx.DeployName))
 	}
 	if deploymentStats == nil {
@@ -442,6 +462,10 @@ func (c *DeploymentsController) ShowSpace(ctx *app.ShowSpaceDeploymentsContext)
 	// get OpenShift space
 	space, err := kc.GetSpace(*kubeSpaceName)
 	if err != nil {
+		switch cause := errs.Cause(err).(type) {
+		case errors.NotFoundError:
+			return cause
+		}
 		return errors.NewInternalError(ctx, errs.Wrapf(err, "could not retrieve space %s", *kubeSpaceName))
 	}
 	if space == nil {
@@ -469,6 +493,10 @@ func (c *DeploymentsController) ShowSpaceEnvironments(ctx *app.ShowSpaceEnvironm
 
 	envs, err := kc.GetEnvironments()
 	if err != nil {
+		switch cause := errs.Cause(err).(type) {
+		case errors.NotFoundError:
+			return cause
+		}
 		return errors.NewInternalError(ctx, errs.Wrap(err, "error retrieving environments"))
 	}
 	if envs == nil {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/goadesign/goa"
 	"github.com/goadesign/goa/goatest"
+	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/require"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/app/test"
 	"github.com/fabric8-services/fabric8-wit/configuration"
 	"github.com/fabric8-services/fabric8-wit/controller"
+	witerrors "github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/kubernetes"
 )
 
@@ -45,6 +47,7 @@ type deploymentsTestFixture struct {
 type deploymentsTestErrors struct {
 	getKubeClientError    error
 	deleteDeploymentError error
+	getEnvironmentsError  error
 }
 
 func (fixture *deploymentsTestFixture) GetKubeClient(ctx context.Context) (kubernetes.KubeClientInterface, error) {
@@ -70,6 +73,10 @@ func (c *testKubeClient) DeleteDeployment(spaceName string, appName string, envN
 	return c.fixture.deleteDeploymentError
 }
 
+func (c *testKubeClient) GetEnvironments() ([]*app.SimpleEnvironment, error) {
+	return []*app.SimpleEnvironment{}, c.fixture.getEnvironmentsError
+}
+
 func (fixture *deploymentsTestFixture) GetAndCheckOSIOClient(ctx context.Context) (controller.OpenshiftIOClient, error) {
 	return &testOSIOClient{
 		fixture: fixture,
@@ -224,6 +231,23 @@ func TestDeleteDeployment(t *testing.T) {
 	}
 }
 
+func TestShowSpaceEnvironmentsNotFound(t *testing.T) {
+	// given a missing environment namespace
+	fixture := &deploymentsTestFixture{
+		deploymentsTestErrors: deploymentsTestErrors{
+			getEnvironmentsError: errs.Wrap(witerrors.NewNotFoundError("namespace", "myspace-run"), "failed to get environment"),
+		},
+	}
+	controller := &controller.DeploymentsController{
+		ClientGetter: fixture,
+	}
+	// when
+	err := controller.ShowSpaceEnvironments(&app.ShowSpaceEnvironmentsDeploymentsContext{})
+	// then
+	require.IsType(t, witerrors.NotFoundError{}, err)
+	require.True(t, fixture.kube.closed, "KubeClient is still open")
+}
+
 func createDeploymentsController() (*goa.Service, *controller.DeploymentsController, error) {
 	svc := goa.New("deployment-service-test")
 	config, err := configuration.New("../config.yaml")
@@ -74,6 +74,9 @@ func (c *SpaceController) Create(ctx *app.CreateSpaceContext) error {
 		if reqSpace.Attributes.Description != nil {
 			newSpace.Description = *reqSpace.Attributes.Description
 		}
+		if reqSpace.Attributes.AllowCrossSpaceLinks != nil {
+			newSpace.AllowCrossSpaceLinks = *reqSpace.Attributes.AllowCrossSpaceLinks
+		}
 
 		rSpace, err = appl.Spaces().Create(ctx, &newSpace)
 		if err != nil {
@@ -281,6 +284,9 @@ func (c *SpaceController) Update(ctx *app.UpdateSpaceContext) error {
 		if ctx.Payload.Data.Attributes.Description != nil {
 			s.Description = *ctx.Payload.Data.Attributes.Description
 		}
+		if ctx.Payload.Data.Attributes.AllowCrossSpaceLinks != nil {
+			s.AllowCrossSpaceLinks = *ctx.Payload.Data.Attributes.AllowCrossSpaceLinks
+		}
 
 		s, err = appl.Spaces().Save(ctx.Context, s)
 		return err
@@ -351,6 +357,9 @@ func ConvertSpaceToModel(appSpace app.Space) space.Space {
 		if appSpace.Attributes.Description != nil {
 			modelSpace.Description = *appSpace.Attributes.Description
 		}
+		if appSpace.Attributes.AllowCrossSpaceLinks != nil {
+			modelSpace.AllowCrossSpaceLinks = *appSpace.Attributes.AllowCrossSpaceLinks
+		}
 	}
 	if appSpace.Relationships != nil && appSpace.Relationships.OwnedBy != nil &&
 		appSpace.Relationships.OwnedBy.Data != nil && appSpace.Relationships.OwnedBy.Data.ID != nil {
@@ -411,11 +420,12 @@ func ConvertSpaceFromModel(request *http.Request, sp space.Space, options ...Spa
 		ID:   &sp.ID,
 		Type: APIStringTypeSpace,
 		Attributes: &app.SpaceAttributes{
-			Name:        &sp.Name,
-			Description: &sp.Description,
-			CreatedAt:   &sp.CreatedAt,
-			UpdatedAt:   &sp.UpdatedAt,
-			Version:     &sp.Version,
+			Name:                 &sp.Name,
+			Description:          &sp.Description,
+			CreatedAt:            &sp.CreatedAt,
+			UpdatedAt:            &sp.UpdatedAt,
+			Version:              &sp.Version,
+			AllowCrossSpaceLinks: &sp.AllowCrossSpaceLinks,
 		},
 		Links: &app.GenericLinksForSpace{
 			Self:    &selfURL,
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SpaceWorkItemLinksController implements the space-work-item-links resource.
+type SpaceWorkItemLinksController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewSpaceWorkItemLinksController creates a space-work-item-links controller.
+func NewSpaceWorkItemLinksController(service *goa.Service, db application.DB) *SpaceWorkItemLinksController {
+	return &SpaceWorkItemLinksController{
+		Controller: service.NewController("SpaceWorkItemLinksController"),
+		db:         db,
+	}
+}
+
+// Batch runs the batch action. It returns the links of all the given work
+// items grouped by work item. Link types and work items referenced by the
+// links are only included once in the response.
+func (c *SpaceWorkItemLinksController) Batch(ctx *app.BatchSpaceWorkItemLinksContext) error {
+	// Build our "set" of distinct work item IDs while keeping the order
+	idMap := map[uuid.UUID]struct{}{}
+	ids := []uuid.UUID{}
+	for _, data := range ctx.Payload.Data {
+		if _, ok := idMap[data.ID]; !ok {
+			idMap[data.ID] = struct{}{}
+			ids = append(ids, data.ID)
+		}
+	}
+	res := &app.WorkItemLinkGroupList{}
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID); err != nil {
+			return err
+		}
+		workItems, err := appl.WorkItems().LoadBatchByID(ctx, ids)
+		if err != nil {
+			return err
+		}
+		found := map[uuid.UUID]struct{}{}
+		for _, wi := range workItems {
+			if wi.SpaceID != ctx.SpaceID {
+				return errors.NewBadParameterError("data.id", wi.ID).Expected("work item in space " + ctx.SpaceID.String())
+			}
+			found[wi.ID] = struct{}{}
+		}
+		for _, id := range ids {
+			if _, ok := found[id]; !ok {
+				return errors.NewNotFoundError("work item", id.String())
+			}
+		}
+		modelLinks, err := appl.WorkItemLinks().ListByWorkItems(ctx, ids...)
+		if err != nil {
+			return err
+		}
+		appLinks := ConvertLinksFromModels(ctx.Request, modelLinks)
+		res.Data = convertLinkGroups(ids, appLinks.Data)
+		res.Meta = &app.WorkItemLinkListMeta{
+			TotalCount: len(appLinks.Data),
+		}
+		// include the link types and source and target work items of all
+		// links, each of them only once
+		if err := enrichLinkList(ctx, appl, ctx.Request, appLinks); err != nil {
+			return err
+		}
+		res.Included = appLinks.Included
+		return nil
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(res)
+}
+
+// convertLinkGroups groups the given links by the work items with the given
+// IDs. A link shows up in the groups of both its source and target if both
+// are among the given work items.
+func convertLinkGroups(wiIDs []uuid.UUID, linksData []*app.WorkItemLinkData) []*app.WorkItemLinkGroup {
+	groups := make([]*app.WorkItemLinkGroup, len(wiIDs))
+	groupsByID := make(map[uuid.UUID]*app.WorkItemLinkGroup, len(wiIDs))
+	for i, id := range wiIDs {
+		groups[i] = &app.WorkItemLinkGroup{
+			Type:  "workitemlinkgroups",
+			ID:    id,
+			Links: []*app.WorkItemLinkData{},
+		}
+		groupsByID[id] = groups[i]
+	}
+	for _, linkData := range linksData {
+		src := linkData.Relationships.Source.Data.ID
+		tgt := linkData.Relationships.Target.Data.ID
+		if g, ok := groupsByID[src]; ok {
+			g.Links = append(g.Links, linkData)
+		}
+		if g, ok := groupsByID[tgt]; ok && tgt != src {
+			g.Links = append(g.Links, linkData)
+		}
+	}
+	return groups
+}
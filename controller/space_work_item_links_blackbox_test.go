@@ -0,0 +1,97 @@
+package controller_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormapplication"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type spaceWorkItemLinksSuite struct {
+	gormtestsupport.DBTestSuite
+	svc  *goa.Service
+	ctrl *SpaceWorkItemLinksController
+}
+
+func TestSpaceWorkItemLinksController(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &spaceWorkItemLinksSuite{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
+}
+
+func (s *spaceWorkItemLinksSuite) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.svc = goa.New("space-work-item-links-test")
+	s.ctrl = NewSpaceWorkItemLinksController(s.svc, gormapplication.NewGormDB(s.DB))
+}
+
+func batchPayload(ids ...uuid.UUID) *app.WorkItemLinksBatchPayload {
+	payload := &app.WorkItemLinksBatchPayload{
+		Data: make([]*app.RelationWorkItemData, len(ids)),
+	}
+	for i, id := range ids {
+		payload.Data[i] = &app.RelationWorkItemData{
+			Type: "workitems",
+			ID:   id,
+		}
+	}
+	return payload
+}
+
+func (s *spaceWorkItemLinksSuite) TestBatch() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(4, tf.SetWorkItemTitles("A", "B", "C", "D")),
+		tf.WorkItemLinksCustom(3, tf.BuildLinks(tf.L("A", "B"), tf.L("A", "C"), tf.L("C", "D"))),
+	)
+	A := fxt.WorkItemByTitle("A").ID
+	B := fxt.WorkItemByTitle("B").ID
+	D := fxt.WorkItemByTitle("D").ID
+
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		_, res := test.BatchSpaceWorkItemLinksOK(t, s.svc.Context, s.svc, s.ctrl, fxt.Spaces[0].ID, batchPayload(A, B, D))
+		// then
+		require.Len(t, res.Data, 3)
+		require.Equal(t, A, res.Data[0].ID)
+		require.Len(t, res.Data[0].Links, 2)
+		require.Equal(t, B, res.Data[1].ID)
+		require.Len(t, res.Data[1].Links, 1)
+		require.Equal(t, D, res.Data[2].ID)
+		require.Len(t, res.Data[2].Links, 1)
+		require.Equal(t, 3, res.Meta.TotalCount)
+		// one link type and four work items are included only once
+		linkTypes, workItems := 0, 0
+		for _, included := range res.Included {
+			switch included.(type) {
+			case *app.WorkItemLinkTypeData:
+				linkTypes++
+			case *app.WorkItem:
+				workItems++
+			}
+		}
+		require.Equal(t, 1, linkTypes)
+		require.Equal(t, 4, workItems)
+	})
+
+	s.T().Run("unknown work item", func(t *testing.T) {
+		test.BatchSpaceWorkItemLinksNotFound(t, s.svc.Context, s.svc, s.ctrl, fxt.Spaces[0].ID, batchPayload(A, uuid.NewV4()))
+	})
+
+	s.T().Run("work item from another space", func(t *testing.T) {
+		otherFxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1))
+		test.BatchSpaceWorkItemLinksBadRequest(t, s.svc.Context, s.svc, s.ctrl, otherFxt.Spaces[0].ID, batchPayload(A))
+	})
+
+	s.T().Run("unknown space", func(t *testing.T) {
+		test.BatchSpaceWorkItemLinksNotFound(t, s.svc.Context, s.svc, s.ctrl, uuid.NewV4(), batchPayload(A))
+	})
+}
@@ -210,9 +210,21 @@ func (c *WorkItemLinkController) Create(ctx *app.CreateWorkItemLinkContext) erro
 	if err != nil {
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
+	idempotent := ctx.Payload.Data.Attributes != nil && ctx.Payload.Data.Attributes.Idempotent != nil && *ctx.Payload.Data.Attributes.Idempotent
 	var createdModelLink *link.WorkItemLink
+	existing := false
 	err = application.Transactional(c.db, func(appl application.Application) error {
 		var err error
+		if idempotent {
+			createdModelLink, err = appl.WorkItemLinks().LoadByEndpoints(ctx.Context, modelLink.SourceID, modelLink.TargetID, modelLink.LinkTypeID)
+			if err == nil {
+				existing = true
+				return nil
+			}
+			if ok, _ := errors.IsNotFoundError(err); !ok {
+				return err
+			}
+		}
 		createdModelLink, err = appl.WorkItemLinks().Create(ctx.Context, modelLink.SourceID, modelLink.TargetID, modelLink.LinkTypeID, *currentUserIdentityID)
 		return err
 	})
@@ -225,9 +237,99 @@ func (c *WorkItemLinkController) Create(ctx *app.CreateWorkItemLinkContext) erro
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
 	ctx.ResponseWriter.Header().Set("Location", app.WorkItemLinkHref(createdAppLink.Data.ID))
+	if existing {
+		return ctx.OK(&createdAppLink)
+	}
 	return ctx.Created(&createdAppLink)
 }
 
+// errBulkLinksFailed rolls back the transaction of a bulk request once any of
+// its links couldn't be created.
+var errBulkLinksFailed = errs.New("not all work item links could be created")
+
+// Bulk runs the bulk action. All links are created in a single transaction,
+// so either all of them are created or none. The result of each link holds the
+// created link or the problem that prevented its creation.
+func (c *WorkItemLinkController) Bulk(ctx *app.BulkWorkItemLinkContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	if len(ctx.Payload.Data) == 0 {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data", ctx.Payload.Data).Expected("at least one work item link"))
+	}
+	res := &app.WorkItemLinkBulkResultList{
+		Data: make([]*app.WorkItemLinkBulkResult, len(ctx.Payload.Data)),
+	}
+	failed := false
+	setError := func(result *app.WorkItemLinkBulkResult, err error) error {
+		jerr, status := jsonapi.ErrorToJSONAPIError(ctx, err)
+		// only problems with the link itself are reported per link
+		if status >= http.StatusInternalServerError {
+			return err
+		}
+		result.Error = &jerr
+		failed = true
+		return nil
+	}
+	// links that can't even be converted are never passed to the repository
+	modelLinks := []link.WorkItemLink{}
+	indexes := []int{}
+	for i, data := range ctx.Payload.Data {
+		res.Data[i] = &app.WorkItemLinkBulkResult{}
+		modelLink, err := ConvertLinkToModel(app.WorkItemLinkSingle{Data: data})
+		if err != nil {
+			if err := setError(res.Data[i], err); err != nil {
+				return jsonapi.JSONErrorResponse(ctx, err)
+			}
+			continue
+		}
+		modelLinks = append(modelLinks, *modelLink)
+		indexes = append(indexes, i)
+	}
+	createdLinks := &app.WorkItemLinkList{
+		Data: []*app.WorkItemLinkData{},
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		created, linkErrs, err := appl.WorkItemLinks().CreateBatch(ctx, modelLinks, *currentUserIdentityID)
+		if err != nil {
+			return err
+		}
+		for j, i := range indexes {
+			if linkErrs[j] != nil {
+				if err := setError(res.Data[i], linkErrs[j]); err != nil {
+					return err
+				}
+				continue
+			}
+			appLink := ConvertLinkFromModel(ctx.Request, *created[j])
+			res.Data[i].Created = true
+			res.Data[i].Data = appLink.Data
+			createdLinks.Data = append(createdLinks.Data, appLink.Data)
+		}
+		if failed {
+			return errBulkLinksFailed
+		}
+		return nil
+	})
+	if err != nil && errs.Cause(err) != errBulkLinksFailed {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if failed {
+		// nothing was created after all
+		for _, result := range res.Data {
+			result.Created = false
+			result.Data = nil
+		}
+		return ctx.UnprocessableEntity(res)
+	}
+	if err := enrichLinkList(ctx.Context, c.db, ctx.Request, createdLinks); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res.Included = createdLinks.Included
+	return ctx.Created(res)
+}
+
 func (c *WorkItemLinkController) checkIfUserIsSpaceCollaboratorOrWorkItemCreator(ctx context.Context, linkID uuid.UUID, currentIdentityID uuid.UUID) (bool, error) {
 	var authorized bool
 	var sourceSpaceID *uuid.UUID
@@ -305,6 +407,37 @@ func (c *WorkItemLinkController) Delete(ctx *app.DeleteWorkItemLinkContext) erro
 	return ctx.OK([]byte{})
 }
 
+// DeleteByEndpoints runs the delete_by_endpoints action. It deletes the link
+// of the given type between the given source and target work items.
+func (c *WorkItemLinkController) DeleteByEndpoints(ctx *app.DeleteByEndpointsWorkItemLinkContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	var modelLink *link.WorkItemLink
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		modelLink, err = appl.WorkItemLinks().LoadByEndpoints(ctx.Context, ctx.SourceID, ctx.TargetID, ctx.LinkTypeID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	authorized, err := c.checkIfUserIsSpaceCollaboratorOrWorkItemCreator(ctx, modelLink.ID, *currentUserIdentityID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if !authorized {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewForbiddenError("user is not authorized to delete the link"))
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		return appl.WorkItemLinks().Delete(ctx.Context, modelLink.ID, *currentUserIdentityID)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK([]byte{})
+}
+
 // Show runs the show action.
 func (c *WorkItemLinkController) Show(ctx *app.ShowWorkItemLinkContext) error {
 	var modelLink *link.WorkItemLink
@@ -380,6 +513,25 @@ func ConvertLinkFromModel(request *http.Request, t link.WorkItemLink) app.WorkIt
 	return converted
 }
 
+// ConvertLinksFromModels converts a list of work item links from model to REST
+// representation. The returned list always has a non-nil "data" array and a
+// "meta" object so that an empty result is serialized as `"data":[]` and
+// `"totalCount":0` rather than `null`.
+func ConvertLinksFromModels(request *http.Request, modelLinks []link.WorkItemLink) *app.WorkItemLinkList {
+	appLinks := app.WorkItemLinkList{}
+	appLinks.Data = make([]*app.WorkItemLinkData, len(modelLinks))
+	for index, modelLink := range modelLinks {
+		appLink := ConvertLinkFromModel(request, modelLink)
+		appLinks.Data[index] = appLink.Data
+	}
+	// TODO: When adding pagination, this must not be len(rows) but
+	// the overall total number of elements from all pages.
+	appLinks.Meta = &app.WorkItemLinkListMeta{
+		TotalCount: len(modelLinks),
+	}
+	return &appLinks
+}
+
 // ConvertLinkToModel converts the incoming app representation of a work item link to the model layout.
 // Values are only overwrriten if they are set in "in", otherwise the values in "out" remain.
 // NOTE: Only the LinkTypeID, SourceID, and TargetID fields will be set.
@@ -419,5 +571,11 @@ func ConvertLinkToModel(appLink app.WorkItemLinkSingle) (*link.WorkItemLink, err
 		modelLink.TargetID = d.ID
 	}
 
+	// A reversed link is stored in its canonical direction so that all
+	// topology checks operate on the swapped source and target.
+	if attrs != nil && attrs.Reverse != nil && *attrs.Reverse {
+		modelLink.SourceID, modelLink.TargetID = modelLink.TargetID, modelLink.SourceID
+	}
+
 	return &modelLink, nil
 }
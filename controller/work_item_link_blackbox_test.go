@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -205,6 +206,55 @@ func (s *workItemLinkSuite) TestCreate() {
 			ctrl := NewWorkItemLinkController(svc, gormapplication.NewGormDB(s.DB), s.Configuration)
 			createOK(t, fxt, svc, ctrl)
 		})
+		t.Run("reversed", func(t *testing.T) {
+			// given
+			fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItems(2), tf.WorkItemLinkTypes(1))
+			svc, ctrl := s.SecuredController(*fxt.Identities[0])
+			createPayload := newCreateWorkItemLinkPayload(fxt.WorkItems[1].ID, fxt.WorkItems[0].ID, fxt.WorkItemLinkTypes[0].ID)
+			reverse := true
+			createPayload.Data.Attributes.Reverse = &reverse
+			// when
+			_, workItemLink := test.CreateWorkItemLinkCreated(t, svc.Context, svc, ctrl, createPayload)
+			// then the link is stored with source and target swapped
+			require.NotNil(t, workItemLink)
+			require.Equal(t, fxt.WorkItems[0].ID, workItemLink.Data.Relationships.Source.Data.ID)
+			require.Equal(t, fxt.WorkItems[1].ID, workItemLink.Data.Relationships.Target.Data.ID)
+		})
+		t.Run("idempotent without existing link", func(t *testing.T) {
+			// given
+			fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItems(2), tf.WorkItemLinkTypes(1))
+			svc, ctrl := s.SecuredController(*fxt.Identities[0])
+			createPayload := newCreateWorkItemLinkPayload(fxt.WorkItems[0].ID, fxt.WorkItems[1].ID, fxt.WorkItemLinkTypes[0].ID)
+			idempotent := true
+			createPayload.Data.Attributes.Idempotent = &idempotent
+			// when
+			_, workItemLink := test.CreateWorkItemLinkCreated(t, svc.Context, svc, ctrl, createPayload)
+			// then
+			require.NotNil(t, workItemLink)
+			require.Equal(t, fxt.WorkItems[0].ID, workItemLink.Data.Relationships.Source.Data.ID)
+		})
+	})
+	s.T().Run(http.StatusText(http.StatusOK), func(t *testing.T) {
+		t.Run("idempotent with existing link", func(t *testing.T) {
+			// given
+			fxt := tf.NewTestFixture(t, s.DB,
+				tf.CreateWorkItemEnvironment(),
+				tf.WorkItemLinks(1),
+				tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyNetwork)))
+			svc, ctrl := s.SecuredController(*fxt.Identities[0])
+			existing := fxt.WorkItemLinks[0]
+			createPayload := newCreateWorkItemLinkPayload(existing.SourceID, existing.TargetID, existing.LinkTypeID)
+			idempotent := true
+			createPayload.Data.Attributes.Idempotent = &idempotent
+			// when
+			_, workItemLink := test.CreateWorkItemLinkOK(t, svc.Context, svc, ctrl, createPayload)
+			// then the existing link is returned
+			require.NotNil(t, workItemLink)
+			require.Equal(t, existing.ID, *workItemLink.Data.ID)
+			count, err := link.NewWorkItemLinkRepository(s.DB).CountByTypeID(s.Ctx, existing.LinkTypeID)
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+		})
 	})
 	s.T().Run(http.StatusText(http.StatusUnauthorized), func(t *testing.T) {
 		t.Run("as not logged in user", func(t *testing.T) {
@@ -238,6 +288,21 @@ func (s *workItemLinkSuite) TestCreate() {
 			createPayload := newCreateWorkItemLinkPayload(fxt.WorkItems[0].ID, fxt.WorkItems[1].ID, uuid.Nil)
 			_, _ = test.CreateWorkItemLinkBadRequest(t, svc.Context, svc, ctrl, createPayload)
 		})
+		t.Run("reversed link violates tree topology", func(t *testing.T) {
+			// given A is the parent of C in a tree
+			fxt := tf.NewTestFixture(t, s.DB,
+				tf.CreateWorkItemEnvironment(),
+				tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+				tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyTree)),
+				tf.WorkItemLinksCustom(1, tf.BuildLinks(tf.L("A", "C"))),
+			)
+			svc, ctrl := s.SecuredController(*fxt.Identities[0])
+			// when "C is child of B" is created, then C would have a second parent
+			createPayload := newCreateWorkItemLinkPayload(fxt.WorkItemByTitle("C").ID, fxt.WorkItemByTitle("B").ID, fxt.WorkItemLinkTypes[0].ID)
+			reverse := true
+			createPayload.Data.Attributes.Reverse = &reverse
+			_, _ = test.CreateWorkItemLinkBadRequest(t, svc.Context, svc, ctrl, createPayload)
+		})
 	})
 
 	s.T().Run(http.StatusText(http.StatusNotFound), func(t *testing.T) {
@@ -263,6 +328,91 @@ func (s *workItemLinkSuite) TestCreate() {
 
 }
 
+// newBulkWorkItemLinksPayload returns the payload to create the given work
+// item links in one request
+func newBulkWorkItemLinksPayload(links ...link.WorkItemLink) *app.CreateWorkItemLinksBulkPayload {
+	payload := &app.CreateWorkItemLinksBulkPayload{
+		Data: make([]*app.WorkItemLinkData, len(links)),
+	}
+	for i, l := range links {
+		payload.Data[i] = newCreateWorkItemLinkPayload(l.SourceID, l.TargetID, l.LinkTypeID).Data
+	}
+	return payload
+}
+
+func (s *workItemLinkSuite) TestBulk() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.CreateWorkItemEnvironment(),
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyNetwork)),
+		tf.WorkItemLinksCustom(1, tf.BuildLinks(tf.L("A", "B"))),
+	)
+	A := fxt.WorkItemByTitle("A").ID
+	B := fxt.WorkItemByTitle("B").ID
+	C := fxt.WorkItemByTitle("C").ID
+	linkTypeID := fxt.WorkItemLinkTypes[0].ID
+	newLink := func(sourceID, targetID uuid.UUID) link.WorkItemLink {
+		return link.WorkItemLink{SourceID: sourceID, TargetID: targetID, LinkTypeID: linkTypeID}
+	}
+	svc, ctrl := s.SecuredController(*fxt.Identities[0])
+	countLinks := func(t *testing.T) int {
+		count, err := link.NewWorkItemLinkRepository(s.DB).CountByTypeID(s.Ctx, linkTypeID)
+		require.NoError(t, err)
+		return count
+	}
+
+	s.T().Run(http.StatusText(http.StatusCreated), func(t *testing.T) {
+		// when
+		_, res := test.BulkWorkItemLinkCreated(t, svc.Context, svc, ctrl, newBulkWorkItemLinksPayload(newLink(A, C), newLink(C, B)))
+		// then
+		require.Len(t, res.Data, 2)
+		for i, expected := range []link.WorkItemLink{newLink(A, C), newLink(C, B)} {
+			require.True(t, res.Data[i].Created)
+			require.Nil(t, res.Data[i].Error)
+			require.NotNil(t, res.Data[i].Data)
+			require.NotNil(t, res.Data[i].Data.ID)
+			require.Equal(t, expected.SourceID, res.Data[i].Data.Relationships.Source.Data.ID)
+			require.Equal(t, expected.TargetID, res.Data[i].Data.Relationships.Target.Data.ID)
+		}
+		// the link type and all three work items are included
+		require.Len(t, res.Included, 4)
+		require.Equal(t, 3, countLinks(t))
+	})
+
+	s.T().Run(http.StatusText(http.StatusUnprocessableEntity), func(t *testing.T) {
+		// given
+		before := countLinks(t)
+		// when the second link already exists and the third has an unknown
+		// source
+		_, res := test.BulkWorkItemLinkUnprocessableEntity(t, svc.Context, svc, ctrl, newBulkWorkItemLinksPayload(newLink(B, C), newLink(A, B), newLink(uuid.NewV4(), C)))
+		// then no link is created and the problems are reported per link
+		require.Len(t, res.Data, 3)
+		for _, result := range res.Data {
+			require.False(t, result.Created)
+			require.Nil(t, result.Data)
+		}
+		require.Nil(t, res.Data[0].Error)
+		require.NotNil(t, res.Data[1].Error)
+		require.Equal(t, strconv.Itoa(http.StatusConflict), *res.Data[1].Error.Status)
+		require.NotNil(t, res.Data[2].Error)
+		require.Equal(t, strconv.Itoa(http.StatusNotFound), *res.Data[2].Error.Status)
+		require.Equal(t, before, countLinks(t))
+	})
+
+	s.T().Run(http.StatusText(http.StatusBadRequest), func(t *testing.T) {
+		t.Run("empty array", func(t *testing.T) {
+			test.BulkWorkItemLinkBadRequest(t, svc.Context, svc, ctrl, newBulkWorkItemLinksPayload())
+		})
+	})
+
+	s.T().Run(http.StatusText(http.StatusUnauthorized), func(t *testing.T) {
+		svc := goa.New("TestUnauthorizedBulkWorkItemLinks-Service")
+		ctrl := NewWorkItemLinkController(svc, gormapplication.NewGormDB(s.DB), s.Configuration)
+		test.BulkWorkItemLinkUnauthorized(t, svc.Context, svc, ctrl, newBulkWorkItemLinksPayload(newLink(B, A)))
+	})
+}
+
 func (s *workItemLinkSuite) TestDelete() {
 	s.T().Run(http.StatusText(http.StatusOK), func(t *testing.T) {
 		t.Run("as space owner", func(t *testing.T) {
@@ -315,6 +465,51 @@ func (s *workItemLinkSuite) TestDelete() {
 	})
 }
 
+func (s *workItemLinkSuite) TestDeleteByEndpoints() {
+	s.T().Run(http.StatusText(http.StatusOK), func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItemLinks(1))
+		svc, ctrl := s.SecuredController(*fxt.Identities[0])
+		l := fxt.WorkItemLinks[0]
+		// when
+		_ = test.DeleteByEndpointsWorkItemLinkOK(t, svc.Context, svc, ctrl, l.LinkTypeID, l.SourceID, l.TargetID)
+		// then verify that the link really was deleted
+		_, _ = test.ShowWorkItemLinkNotFound(t, svc.Context, svc, ctrl, l.ID, nil, nil)
+	})
+	s.T().Run(http.StatusText(http.StatusForbidden), func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(1), tf.Identities(2, tf.SetIdentityUsernames("owner", "collaborator")))
+		svc := testsupport.ServiceAsSpaceUser("svc", *fxt.IdentityByUsername("collaborator"), &TestSpaceAuthzService{*fxt.IdentityByUsername("owner"), ""})
+		ctrl := NewWorkItemLinkController(svc, gormapplication.NewGormDB(s.DB), s.Configuration)
+		l := fxt.WorkItemLinks[0]
+		// when
+		test.DeleteByEndpointsWorkItemLinkForbidden(t, svc.Context, svc, ctrl, l.LinkTypeID, l.SourceID, l.TargetID)
+		// then verify the link still exists
+		_, _ = test.ShowWorkItemLinkOK(t, svc.Context, svc, ctrl, l.ID, nil, nil)
+	})
+	s.T().Run(http.StatusText(http.StatusUnauthorized), func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(1))
+		svc := goa.New("TestUnauthorizedDeleteByEndpointsWorkItemLink-Service")
+		ctrl := NewWorkItemLinkController(svc, gormapplication.NewGormDB(s.DB), s.Configuration)
+		l := fxt.WorkItemLinks[0]
+		// when/then
+		_, _ = test.DeleteByEndpointsWorkItemLinkUnauthorized(t, svc.Context, svc, ctrl, l.LinkTypeID, l.SourceID, l.TargetID)
+	})
+	s.T().Run(http.StatusText(http.StatusNotFound), func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItemLinks(1))
+		svc, ctrl := s.SecuredController(*fxt.Identities[0])
+		l := fxt.WorkItemLinks[0]
+		t.Run("reverse direction", func(t *testing.T) {
+			_, _ = test.DeleteByEndpointsWorkItemLinkNotFound(t, svc.Context, svc, ctrl, l.LinkTypeID, l.TargetID, l.SourceID)
+		})
+		t.Run("other link type", func(t *testing.T) {
+			_, _ = test.DeleteByEndpointsWorkItemLinkNotFound(t, svc.Context, svc, ctrl, uuid.NewV4(), l.SourceID, l.TargetID)
+		})
+	})
+}
+
 func (s *workItemLinkSuite) TestShow() {
 	s.T().Run(http.StatusText(http.StatusOK), func(t *testing.T) {
 		t.Run("normal", func(t *testing.T) {
@@ -412,7 +607,7 @@ func (s *workItemLinkSuite) TestList() {
 	s.T().Run(http.StatusText(http.StatusOK), func(t *testing.T) {
 		t.Run("for /api/workitems/:id/relationships/links", func(t *testing.T) {
 			// when
-			_, links := test.ListWorkItemRelationshipsLinksOK(t, svc.Context, svc, relCtrl, fxt.WorkItemLinks[0].SourceID, nil, nil)
+			_, links := test.ListWorkItemRelationshipsLinksOK(t, svc.Context, svc, relCtrl, fxt.WorkItemLinks[0].SourceID, nil, nil, nil)
 			for i, obj := range links.Included {
 				switch t := obj.(type) {
 				case *app.WorkItem:
@@ -427,11 +622,61 @@ func (s *workItemLinkSuite) TestList() {
 	s.T().Run(http.StatusText(http.StatusNotFound), func(t *testing.T) {
 		t.Run("for /api/workitems/:id/relationships/links", func(t *testing.T) {
 			// when
-			_, _ = test.ListWorkItemRelationshipsLinksNotFound(t, svc.Context, svc, relCtrl, uuid.NewV4(), nil, nil)
+			_, _ = test.ListWorkItemRelationshipsLinksNotFound(t, svc.Context, svc, relCtrl, uuid.NewV4(), nil, nil, nil)
 		})
 	})
 }
 
+func (s *workItemLinkSuite) TestListByDirection() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.CreateWorkItemEnvironment(),
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyNetwork), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].SelfReferenceAllowed = true
+			return nil
+		}),
+		tf.WorkItemLinksCustom(3, tf.BuildLinks(tf.L("A", "B"), tf.L("C", "A"), tf.L("A", "A"))),
+	)
+	A := fxt.WorkItemByTitle("A").ID
+	linkType := fxt.WorkItemLinkTypes[0]
+	svc, _ := s.SecuredController(*fxt.Identities[0])
+	relCtrl := NewWorkItemRelationshipsLinksController(svc, gormapplication.NewGormDB(s.DB), s.Configuration)
+
+	s.T().Run(http.StatusText(http.StatusOK), func(t *testing.T) {
+		// when
+		_, res := test.ByDirectionWorkItemRelationshipsLinksOK(t, svc.Context, svc, relCtrl, A, nil, nil)
+		// then
+		require.Equal(t, A, res.Data.ID)
+		require.Len(t, res.Data.Outgoing, 2)
+		directions := map[uuid.UUID]string{}
+		for _, l := range res.Data.Outgoing {
+			require.Equal(t, linkType.ForwardName, l.Name)
+			directions[*l.Link.ID] = l.Direction
+		}
+		require.Equal(t, map[uuid.UUID]string{
+			fxt.WorkItemLinks[0].ID: "outgoing",
+			fxt.WorkItemLinks[2].ID: "self",
+		}, directions)
+		require.Len(t, res.Data.Incoming, 1)
+		require.Equal(t, "incoming", res.Data.Incoming[0].Direction)
+		require.Equal(t, linkType.ReverseName, res.Data.Incoming[0].Name)
+		require.Equal(t, fxt.WorkItemLinks[1].ID, *res.Data.Incoming[0].Link.ID)
+		// the link type and the three work items are included
+		require.Len(t, res.Included, 4)
+	})
+	s.T().Run(http.StatusText(http.StatusNotModified), func(t *testing.T) {
+		// given
+		res, _ := test.ByDirectionWorkItemRelationshipsLinksOK(t, svc.Context, svc, relCtrl, A, nil, nil)
+		ifNoneMatch := res.Header()[app.ETag][0]
+		// when/then
+		test.ByDirectionWorkItemRelationshipsLinksNotModified(t, svc.Context, svc, relCtrl, A, nil, &ifNoneMatch)
+	})
+	s.T().Run(http.StatusText(http.StatusNotFound), func(t *testing.T) {
+		test.ByDirectionWorkItemRelationshipsLinksNotFound(t, svc.Context, svc, relCtrl, uuid.NewV4(), nil, nil)
+	})
+}
+
 func (s *workItemLinkSuite) getWorkItemLinkTestDataFunc() func(t *testing.T) []testSecureAPI {
 	return func(t *testing.T) []testSecureAPI {
 		privatekey := testtoken.PrivateKey()
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"strings"
+
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/application"
 	"github.com/fabric8-services/fabric8-wit/errors"
@@ -9,7 +11,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/rest"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 	"github.com/goadesign/goa"
-	//uuid "github.com/satori/go.uuid"
+	uuid "github.com/satori/go.uuid"
 )
 
 // WorkItemLinkCategoryController implements the work-item-link-category resource.
@@ -104,12 +106,32 @@ func (c *WorkItemLinkCategoryController) Show(ctx *app.ShowWorkItemLinkCategoryC
 
 // List runs the list action.
 func (c *WorkItemLinkCategoryController) List(ctx *app.ListWorkItemLinkCategoryContext) error {
+	var ids map[uuid.UUID]bool
+	if ctx.Ids != nil {
+		ids = map[uuid.UUID]bool{}
+		for _, s := range strings.Split(*ctx.Ids, ",") {
+			id, err := uuid.FromString(strings.TrimSpace(s))
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("ids", *ctx.Ids).Expected("comma-separated list of UUIDs"))
+			}
+			ids[id] = true
+		}
+	}
 	err := application.Transactional(c.db, func(appl application.Application) error {
 		modelCategories, err := appl.WorkItemLinkCategories().List(ctx.Context)
 		if err != nil {
 			jerrors, httpStatusCode := jsonapi.ErrorToJSONAPIErrors(ctx, err)
 			return ctx.ResponseData.Service.Send(ctx.Context, httpStatusCode, jerrors)
 		}
+		if ids != nil {
+			filtered := []link.WorkItemLinkCategory{}
+			for _, cat := range modelCategories {
+				if ids[cat.ID] {
+					filtered = append(filtered, cat)
+				}
+			}
+			modelCategories = filtered
+		}
 		// convert
 		appCategories := app.WorkItemLinkCategoryList{}
 		appCategories.Data = make([]*app.WorkItemLinkCategoryData, len(modelCategories))
@@ -136,6 +158,45 @@ func (c *WorkItemLinkCategoryController) List(ctx *app.ListWorkItemLinkCategoryC
 	return nil
 }
 
+// Impact runs the impact action. It lists the work item link types that would
+// be orphaned by deleting the category, together with their number of links,
+// so that they can be reassigned before the category is deleted.
+func (c *WorkItemLinkCategoryController) Impact(ctx *app.ImpactWorkItemLinkCategoryContext) error {
+	var modelLinkTypes []link.WorkItemLinkType
+	var linkCounts map[uuid.UUID]int
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.WorkItemLinkCategories().CheckExists(ctx, ctx.ID); err != nil {
+			return err
+		}
+		var err error
+		modelLinkTypes, err = appl.WorkItemLinkTypes().ListByCategory(ctx, ctx.ID)
+		if err != nil {
+			return err
+		}
+		typeIDs := make([]uuid.UUID, len(modelLinkTypes))
+		for i, t := range modelLinkTypes {
+			typeIDs[i] = t.ID
+		}
+		linkCounts, err = appl.WorkItemLinks().CountByTypeIDs(ctx, typeIDs...)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res := &app.WorkItemLinkCategoryImpactList{
+		Data: make([]*app.WorkItemLinkTypeData, len(modelLinkTypes)),
+		Meta: &app.WorkItemLinkCategoryImpactMeta{
+			TotalCount: len(modelLinkTypes),
+			LinkCounts: make(map[string]int, len(modelLinkTypes)),
+		},
+	}
+	for i, t := range modelLinkTypes {
+		res.Data[i] = ConvertWorkItemLinkTypeFromModel(ctx.Request, t).Data
+		res.Meta.LinkCounts[t.ID.String()] = linkCounts[t.ID]
+	}
+	return ctx.OK(res)
+}
+
 // Delete runs the delete action.
 func (c *WorkItemLinkCategoryController) Delete(ctx *app.DeleteWorkItemLinkCategoryContext) error {
 	// Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
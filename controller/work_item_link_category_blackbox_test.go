@@ -443,7 +443,7 @@ func (s *workItemLinkCategorySuite) TestListWorkItemLinkCategoryOK() {
 	createWorkItemLinkCategoryUserInRepo(s.T(), s.appDB, s.linkCatCtrl.Context)
 
 	// Fetch a single work item link category
-	_, linkCatCollection := test.ListWorkItemLinkCategoryOK(s.T(), nil, nil, s.linkCatCtrl)
+	_, linkCatCollection := test.ListWorkItemLinkCategoryOK(s.T(), nil, nil, s.linkCatCtrl, nil)
 
 	require.NotNil(s.T(), linkCatCollection)
 	require.Nil(s.T(), linkCatCollection.Validate())
@@ -465,6 +465,27 @@ func (s *workItemLinkCategorySuite) TestListWorkItemLinkCategoryOK() {
 	require.Exactly(s.T(), 0, toBeFound, "Not all required work item link categories (system and user) where found.")
 }
 
+// TestListWorkItemLinkCategoryByIDs tests if we can restrict the list of work
+// item link categories to the given IDs
+func (s *workItemLinkCategorySuite) TestListWorkItemLinkCategoryByIDs() {
+	systemID := createWorkItemLinkCategorySystemInRepo(s.T(), s.appDB, s.linkCatCtrl.Context)
+	createWorkItemLinkCategoryUserInRepo(s.T(), s.appDB, s.linkCatCtrl.Context)
+
+	s.T().Run("ok", func(t *testing.T) {
+		ids := systemID.String() + "," + uuid.NewV4().String()
+		_, linkCatCollection := test.ListWorkItemLinkCategoryOK(t, nil, nil, s.linkCatCtrl, &ids)
+		require.NotNil(t, linkCatCollection)
+		require.Len(t, linkCatCollection.Data, 1)
+		require.Equal(t, systemID, *linkCatCollection.Data[0].ID)
+		require.Equal(t, 1, linkCatCollection.Meta.TotalCount)
+	})
+
+	s.T().Run("bad id", func(t *testing.T) {
+		ids := systemID.String() + ",foo"
+		test.ListWorkItemLinkCategoryBadRequest(t, nil, nil, s.linkCatCtrl, &ids)
+	})
+}
+
 func getWorkItemLinkCategoryTestData(t *testing.T) []testSecureAPI {
 	privatekey := testtoken.PrivateKey()
 	differentPrivatekey, err := jwt.ParseRSAPrivateKeyFromPEM(([]byte(RSADifferentPrivateKeyTest)))
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// WorkItemLinkGraphController implements the work-item-link-graph resource.
+type WorkItemLinkGraphController struct {
+	*goa.Controller
+	db     application.DB
+	config WorkItemLinkGraphControllerConfig
+}
+
+// WorkItemLinkGraphControllerConfig the config interface for the WorkItemLinkGraphController
+type WorkItemLinkGraphControllerConfig interface {
+	GetWorkItemLinkGraphMaxDepth() int
+}
+
+// NewWorkItemLinkGraphController creates a work-item-link-graph controller.
+func NewWorkItemLinkGraphController(service *goa.Service, db application.DB, config WorkItemLinkGraphControllerConfig) *WorkItemLinkGraphController {
+	return &WorkItemLinkGraphController{
+		Controller: service.NewController("WorkItemLinkGraphController"),
+		db:         db,
+		config:     config,
+	}
+}
+
+// Show runs the show action. It traverses the links of the given work item
+// breadth-first, so every work item is reported with its shortest distance to
+// the given work item.
+func (c *WorkItemLinkGraphController) Show(ctx *app.ShowWorkItemLinkGraphContext) error {
+	maxDepth := c.config.GetWorkItemLinkGraphMaxDepth()
+	depth := maxDepth
+	if ctx.Depth != nil {
+		if *ctx.Depth > maxDepth {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("depth", *ctx.Depth).Expected("at most the maximum depth of the link graph"))
+		}
+		depth = *ctx.Depth
+	}
+	res := &app.WorkItemLinkGraphSingle{}
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		if ctx.Type != nil {
+			if err := appl.WorkItemLinkTypes().CheckExists(ctx, *ctx.Type); err != nil {
+				if ok, _ := errors.IsNotFoundError(err); ok {
+					return errors.NewBadParameterError("type", *ctx.Type).Expected("an existing work item link type")
+				}
+				return err
+			}
+		}
+		root, err := appl.WorkItems().LoadByID(ctx, ctx.WiID)
+		if err != nil {
+			return err
+		}
+		depths, modelLinks, err := traverseLinkGraph(ctx, appl, root.ID, depth, ctx.Type)
+		if err != nil {
+			return err
+		}
+		nodes, err := convertLinkGraphNodes(ctx, appl, depths)
+		if err != nil {
+			return err
+		}
+		appLinks := ConvertLinksFromModels(ctx.Request, modelLinks)
+		res.Data = &app.WorkItemLinkGraphData{
+			Type:  "workitemlinkgraphs",
+			ID:    root.ID,
+			Nodes: nodes,
+			Edges: appLinks.Data,
+		}
+		typeDataArr, err := getTypesOfLinks(ctx, appl, ctx.Request, appLinks.Data)
+		if err != nil {
+			return err
+		}
+		for _, typeData := range typeDataArr {
+			res.Included = append(res.Included, typeData)
+		}
+		return nil
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(res)
+}
+
+// traverseLinkGraph follows the links of the given work item breadth-first up
+// to the given depth, optionally only following links of the given link type.
+// It returns the distance of every reached work item to the given one and the
+// followed links in the order they were found. Work items are only visited
+// once, so cycles end the traversal.
+func traverseLinkGraph(ctx *app.ShowWorkItemLinkGraphContext, appl application.Application, rootID uuid.UUID, depth int, linkTypeID *uuid.UUID) (map[uuid.UUID]int, []link.WorkItemLink, error) {
+	depths := map[uuid.UUID]int{rootID: 0}
+	seenLinks := map[uuid.UUID]struct{}{}
+	modelLinks := []link.WorkItemLink{}
+	frontier := []uuid.UUID{rootID}
+	for level := 1; level <= depth && len(frontier) > 0; level++ {
+		links, err := appl.WorkItemLinks().ListByWorkItems(ctx, frontier...)
+		if err != nil {
+			return nil, nil, errs.WithStack(err)
+		}
+		next := []uuid.UUID{}
+		for _, l := range links {
+			if linkTypeID != nil && l.LinkTypeID != *linkTypeID {
+				continue
+			}
+			if _, ok := seenLinks[l.ID]; ok {
+				continue
+			}
+			seenLinks[l.ID] = struct{}{}
+			modelLinks = append(modelLinks, l)
+			for _, id := range []uuid.UUID{l.SourceID, l.TargetID} {
+				if _, ok := depths[id]; !ok {
+					depths[id] = level
+					next = append(next, id)
+				}
+			}
+		}
+		frontier = next
+	}
+	return depths, modelLinks, nil
+}
+
+// convertLinkGraphNodes loads the work items with the given distances to the
+// root work item and converts them to graph nodes, root first and the others
+// ordered by their distance.
+func convertLinkGraphNodes(ctx *app.ShowWorkItemLinkGraphContext, appl application.Application, depths map[uuid.UUID]int) ([]*app.WorkItemLinkGraphNode, error) {
+	ids := make([]uuid.UUID, 0, len(depths))
+	for id := range depths {
+		ids = append(ids, id)
+	}
+	workItems, err := appl.WorkItems().LoadBatchByID(ctx, ids)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	// group by depth so that nodes closer to the root come first
+	byDepth := map[int][]*app.WorkItemLinkGraphNode{}
+	maxDepth := 0
+	for _, wi := range workItems {
+		d := depths[wi.ID]
+		number := wi.Number
+		node := &app.WorkItemLinkGraphNode{
+			ID:     wi.ID,
+			Number: &number,
+			Depth:  d,
+		}
+		if title, ok := wi.Fields[workitem.SystemTitle].(string); ok {
+			node.Title = &title
+		}
+		if state, ok := wi.Fields[workitem.SystemState].(string); ok {
+			node.State = &state
+		}
+		byDepth[d] = append(byDepth[d], node)
+		if d > maxDepth {
+			maxDepth = d
+		}
+	}
+	nodes := []*app.WorkItemLinkGraphNode{}
+	for d := 0; d <= maxDepth; d++ {
+		nodes = append(nodes, byDepth[d]...)
+	}
+	return nodes, nil
+}
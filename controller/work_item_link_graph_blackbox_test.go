@@ -0,0 +1,130 @@
+package controller_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormapplication"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type workItemLinkGraphSuite struct {
+	gormtestsupport.DBTestSuite
+	svc  *goa.Service
+	ctrl *WorkItemLinkGraphController
+}
+
+func TestWorkItemLinkGraphController(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &workItemLinkGraphSuite{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
+}
+
+func (s *workItemLinkGraphSuite) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.svc = goa.New("work-item-link-graph-test")
+	s.ctrl = NewWorkItemLinkGraphController(s.svc, gormapplication.NewGormDB(s.DB), s.Configuration)
+}
+
+// nodeDepths returns the depth of every node in the graph by work item ID
+func nodeDepths(t *testing.T, res *app.WorkItemLinkGraphSingle) map[uuid.UUID]int {
+	depths := map[uuid.UUID]int{}
+	for _, node := range res.Data.Nodes {
+		_, duplicate := depths[node.ID]
+		require.False(t, duplicate, "node %s is returned more than once", node.ID)
+		depths[node.ID] = node.Depth
+	}
+	return depths
+}
+
+func (s *workItemLinkGraphSuite) TestShow() {
+	// given a cycle A->B->C->A of one link type and a link C->D of another
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(5, tf.SetWorkItemTitles("A", "B", "C", "D", "E")),
+		tf.WorkItemLinkTypes(2,
+			tf.SetTopologies(link.TopologyNetwork, link.TopologyNetwork),
+			tf.SetWorkItemLinkTypeNames("cycle", "other"),
+		),
+		tf.WorkItemLinksCustom(4, tf.BuildLinks(
+			tf.L("A", "B", "cycle"),
+			tf.L("B", "C", "cycle"),
+			tf.L("C", "A", "cycle"),
+			tf.L("C", "D", "other"),
+		)),
+	)
+	A := fxt.WorkItemByTitle("A")
+	B := fxt.WorkItemByTitle("B").ID
+	C := fxt.WorkItemByTitle("C").ID
+	D := fxt.WorkItemByTitle("D").ID
+	cycleTypeID := fxt.WorkItemLinkTypeByName("cycle").ID
+	maxDepth := s.Configuration.GetWorkItemLinkGraphMaxDepth()
+
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		_, res := test.ShowWorkItemLinkGraphOK(t, s.svc.Context, s.svc, s.ctrl, A.ID, nil, nil)
+		// then the cycle is traversed only once
+		require.Equal(t, A.ID, res.Data.ID)
+		require.Equal(t, map[uuid.UUID]int{A.ID: 0, B: 1, C: 1, D: 2}, nodeDepths(t, res))
+		require.Len(t, res.Data.Edges, 4)
+		require.Len(t, res.Included, 2)
+		// the root comes first with its summary
+		root := res.Data.Nodes[0]
+		require.Equal(t, A.ID, root.ID)
+		require.NotNil(t, root.Title)
+		require.Equal(t, "A", *root.Title)
+		require.NotNil(t, root.Number)
+		require.Equal(t, A.Number, *root.Number)
+		require.NotNil(t, root.State)
+	})
+
+	s.T().Run("limited depth", func(t *testing.T) {
+		// when
+		depth := 1
+		_, res := test.ShowWorkItemLinkGraphOK(t, s.svc.Context, s.svc, s.ctrl, A.ID, &depth, nil)
+		// then
+		require.Equal(t, map[uuid.UUID]int{A.ID: 0, B: 1, C: 1}, nodeDepths(t, res))
+		require.Len(t, res.Data.Edges, 2)
+	})
+
+	s.T().Run("filtered by link type", func(t *testing.T) {
+		// when
+		_, res := test.ShowWorkItemLinkGraphOK(t, s.svc.Context, s.svc, s.ctrl, A.ID, nil, &cycleTypeID)
+		// then
+		require.Equal(t, map[uuid.UUID]int{A.ID: 0, B: 1, C: 1}, nodeDepths(t, res))
+		require.Len(t, res.Data.Edges, 3)
+		require.Len(t, res.Included, 1)
+	})
+
+	s.T().Run("no links", func(t *testing.T) {
+		// when
+		E := fxt.WorkItemByTitle("E").ID
+		_, res := test.ShowWorkItemLinkGraphOK(t, s.svc.Context, s.svc, s.ctrl, E, nil, nil)
+		// then
+		require.Equal(t, map[uuid.UUID]int{E: 0}, nodeDepths(t, res))
+		require.Empty(t, res.Data.Edges)
+	})
+
+	s.T().Run(http.StatusText(http.StatusBadRequest), func(t *testing.T) {
+		t.Run("depth above maximum", func(t *testing.T) {
+			depth := maxDepth + 1
+			test.ShowWorkItemLinkGraphBadRequest(t, s.svc.Context, s.svc, s.ctrl, A.ID, &depth, nil)
+		})
+		t.Run("unknown link type", func(t *testing.T) {
+			linkTypeID := uuid.NewV4()
+			test.ShowWorkItemLinkGraphBadRequest(t, s.svc.Context, s.svc, s.ctrl, A.ID, nil, &linkTypeID)
+		})
+	})
+
+	s.T().Run(http.StatusText(http.StatusNotFound), func(t *testing.T) {
+		test.ShowWorkItemLinkGraphNotFound(t, s.svc.Context, s.svc, s.ctrl, uuid.NewV4(), nil, nil)
+	})
+}
@@ -1,15 +1,24 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/fabric8-services/fabric8-wit/account"
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/application"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/metric"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/rendering"
 	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 	errs "github.com/pkg/errors"
 
@@ -39,8 +48,9 @@ func NewWorkItemLinkTypeController(service *goa.Service, db application.DB, conf
 	}
 }
 
-// enrichLinkTypeSingle includes related resources in the single's "included" array
-func enrichLinkTypeSingle(ctx *workItemLinkContext, single *app.WorkItemLinkTypeSingle) error {
+// enrichLinkTypeSingle includes the requested related resources in the
+// single's "included" array
+func enrichLinkTypeSingle(ctx *workItemLinkContext, single *app.WorkItemLinkTypeSingle, includes linkTypeIncludes) error {
 	// Add "links" element
 	relatedURL := rest.AbsoluteURL(ctx.Request, ctx.LinkFunc(*single.Data.ID))
 	single.Data.Links = &app.GenericLinks{
@@ -48,34 +58,75 @@ func enrichLinkTypeSingle(ctx *workItemLinkContext, single *app.WorkItemLinkType
 		Related: &relatedURL,
 	}
 
-	// Now include the optional link category data in the work item link type "included" array
-	modelCategory, err := ctx.Application.WorkItemLinkCategories().Load(ctx.Context, single.Data.Relationships.LinkCategory.Data.ID)
-	if err != nil {
+	// Relationships that were left out by a sparse fieldset are not included
+	rel := single.Data.Relationships
+	if rel == nil {
+		return nil
+	}
+	if err := checkLinkTypeRelationships(ctx.Context, single.Data, includes); err != nil {
 		return err
 	}
-	appCategory := ConvertLinkCategoryFromModel(*modelCategory)
-	single.Included = append(single.Included, appCategory.Data)
+
+	// Now include the optional link category data in the work item link type "included" array
+	if rel.LinkCategory != nil && includes.has(linkTypeIncludeLinkCategory) {
+		modelCategory, err := ctx.Application.WorkItemLinkCategories().Load(ctx.Context, rel.LinkCategory.Data.ID)
+		if err != nil {
+			return err
+		}
+		appCategory := ConvertLinkCategoryFromModel(*modelCategory)
+		single.Included = append(single.Included, appCategory.Data)
+		resolveLinkCategoryRelation(rel.LinkCategory, *modelCategory)
+	}
 
 	// Now include the optional link space data in the work item link type "included" array
-	space, err := ctx.Application.Spaces().Load(ctx.Context, *single.Data.Relationships.Space.Data.ID)
-	if err != nil {
-		return err
+	if rel.Space != nil && includes.has(linkTypeIncludeSpace) {
+		space, err := ctx.Application.Spaces().Load(ctx.Context, *rel.Space.Data.ID)
+		if err != nil {
+			return err
+		}
+
+		spaceData, err := ConvertSpaceFromModel(ctx.Request, *space, IncludeBacklogTotalCount(ctx.Context, ctx.DB))
+		if err != nil {
+			return err
+		}
+		spaceSingle := &app.SpaceSingle{
+			Data: spaceData,
+		}
+		single.Included = append(single.Included, spaceSingle.Data)
 	}
 
-	spaceData, err := ConvertSpaceFromModel(ctx.Request, *space, IncludeBacklogTotalCount(ctx.Context, ctx.DB))
-	if err != nil {
-		return err
+	return nil
+}
+
+// checkLinkTypeRelationships returns an internal error if a relationship
+// that is about to be included lacks the ID of the related resource. This
+// only happens when a malformed link type reaches the enrichment.
+func checkLinkTypeRelationships(ctx context.Context, data *app.WorkItemLinkTypeData, includes linkTypeIncludes) error {
+	rel := data.Relationships
+	if rel.LinkCategory != nil && includes.has(linkTypeIncludeLinkCategory) && rel.LinkCategory.Data == nil {
+		return errors.NewInternalError(ctx, errs.Errorf("link category relationship of work item link type %s has no data", data.ID))
 	}
-	spaceSingle := &app.SpaceSingle{
-		Data: spaceData,
+	if rel.Space != nil && includes.has(linkTypeIncludeSpace) && (rel.Space.Data == nil || rel.Space.Data.ID == nil) {
+		return errors.NewInternalError(ctx, errs.Errorf("space relationship of work item link type %s has no ID", data.ID))
 	}
-	single.Included = append(single.Included, spaceSingle.Data)
-
 	return nil
 }
 
-// enrichLinkTypeList includes related resources in the list's "included" array
-func enrichLinkTypeList(ctx *workItemLinkContext, list *app.WorkItemLinkTypeList) error {
+// resolveLinkCategoryRelation marks the given link category relationship as
+// resolved and adds the category's name to its "meta" object. The "links"
+// element is left untouched for full navigation.
+func resolveLinkCategoryRelation(rel *app.RelationWorkItemLinkCategory, category link.WorkItemLinkCategory) {
+	if rel.Meta == nil {
+		rel.Meta = map[string]interface{}{}
+	}
+	rel.Meta["resolved"] = true
+	rel.Meta["name"] = category.Name
+}
+
+// enrichLinkTypeList includes the requested related resources in the list's
+// "included" array. The relationships of resources that are not included just
+// carry their IDs.
+func enrichLinkTypeList(ctx *workItemLinkContext, list *app.WorkItemLinkTypeList, includes linkTypeIncludes) error {
 	// Add "links" element
 	for _, data := range list.Data {
 		relatedURL := rest.AbsoluteURL(ctx.Request, ctx.LinkFunc(*data.ID))
@@ -84,199 +135,1670 @@ func enrichLinkTypeList(ctx *workItemLinkContext, list *app.WorkItemLinkTypeList
 			Related: &relatedURL,
 		}
 	}
-	// Build our "set" of distinct category IDs already converted as strings
-	categoryIDMap := map[uuid.UUID]bool{}
-	for _, typeData := range list.Data {
-		categoryIDMap[typeData.Relationships.LinkCategory.Data.ID] = true
+	// Collect the distinct category and space IDs in the order in which they
+	// first appear so that the "included" array is stable. Relationships that
+	// were left out by a sparse fieldset are not included.
+	categoryIDs := []uuid.UUID{}
+	spaceIDs := []uuid.UUID{}
+	categoryIDMap := map[uuid.UUID]bool{}
+	spaceIDMap := map[uuid.UUID]bool{}
+	for _, typeData := range list.Data {
+		if typeData.Relationships == nil {
+			continue
+		}
+		if err := checkLinkTypeRelationships(ctx.Context, typeData, includes); err != nil {
+			return err
+		}
+		if typeData.Relationships.LinkCategory != nil && includes.has(linkTypeIncludeLinkCategory) {
+			categoryID := typeData.Relationships.LinkCategory.Data.ID
+			if !categoryIDMap[categoryID] {
+				categoryIDMap[categoryID] = true
+				categoryIDs = append(categoryIDs, categoryID)
+			}
+		}
+		if typeData.Relationships.Space != nil && includes.has(linkTypeIncludeSpace) {
+			spaceID := *typeData.Relationships.Space.Data.ID
+			if !spaceIDMap[spaceID] {
+				spaceIDMap[spaceID] = true
+				spaceIDs = append(spaceIDs, spaceID)
+			}
+		}
+	}
+	// Now include the optional link category data in the work item link type "included" array
+	if len(categoryIDs) > 0 {
+		modelCategories, err := ctx.Application.WorkItemLinkCategories().LoadBatch(ctx.Context, categoryIDs)
+		if err != nil {
+			return err
+		}
+		categories := make(map[uuid.UUID]link.WorkItemLinkCategory, len(modelCategories))
+		for _, modelCategory := range modelCategories {
+			categories[modelCategory.ID] = modelCategory
+			appCategory := ConvertLinkCategoryFromModel(modelCategory)
+			list.Included = append(list.Included, appCategory.Data)
+		}
+		for _, typeData := range list.Data {
+			if typeData.Relationships == nil || typeData.Relationships.LinkCategory == nil {
+				continue
+			}
+			resolveLinkCategoryRelation(typeData.Relationships.LinkCategory, categories[typeData.Relationships.LinkCategory.Data.ID])
+		}
+	}
+	if len(spaceIDs) == 0 {
+		return nil
+	}
+
+	// Now include the optional link space data in the work item link type "included" array
+	spaces, err := ctx.Application.Spaces().LoadBatch(ctx.Context, spaceIDs)
+	if err != nil {
+		return err
+	}
+	for _, space := range spaces {
+		spaceData, err := ConvertSpaceFromModel(ctx.Request, space, IncludeBacklogTotalCount(ctx.Context, ctx.DB))
+		if err != nil {
+			return err
+		}
+		spaceSingle := &app.SpaceSingle{
+			Data: spaceData,
+		}
+		list.Included = append(list.Included, spaceSingle.Data)
+	}
+	return nil
+}
+
+// linkTypeFieldset holds the names of the attributes and relationships of
+// work item link types that were requested with a sparse fieldset (see
+// http://jsonapi.org/format/#fetching-sparse-fieldsets). A nil fieldset
+// requests all fields.
+type linkTypeFieldset map[string]struct{}
+
+// newLinkTypeFieldset parses the given comma separated field names.
+func newLinkTypeFieldset(fields *string) linkTypeFieldset {
+	if fields == nil {
+		return nil
+	}
+	res := linkTypeFieldset{}
+	for _, name := range strings.Split(*fields, ",") {
+		res[strings.TrimSpace(name)] = struct{}{}
+	}
+	return res
+}
+
+// has returns true if the field with the given name was requested.
+func (f linkTypeFieldset) has(name string) bool {
+	if f == nil {
+		return true
+	}
+	_, ok := f[name]
+	return ok
+}
+
+// apply removes the attributes and relationships that were not requested
+// from the given link type. Unknown field names are ignored.
+func (f linkTypeFieldset) apply(data *app.WorkItemLinkTypeData) {
+	if f == nil {
+		return
+	}
+	if attrs := data.Attributes; attrs != nil {
+		if !f.has("name") {
+			attrs.Name = nil
+		}
+		if !f.has("description") {
+			attrs.Description = nil
+		}
+		if !f.has("description_markup") {
+			attrs.DescriptionMarkup = nil
+		}
+		if !f.has("version") {
+			attrs.Version = nil
+		}
+		if !f.has("created-at") {
+			attrs.CreatedAt = nil
+		}
+		if !f.has("updated-at") {
+			attrs.UpdatedAt = nil
+		}
+		if !f.has("deleted-at") {
+			attrs.DeletedAt = nil
+		}
+		if !f.has("forward_name") {
+			attrs.ForwardName = nil
+		}
+		if !f.has("reverse_name") {
+			attrs.ReverseName = nil
+		}
+		if !f.has("topology") {
+			attrs.Topology = nil
+		}
+		if !f.has("display_template") {
+			attrs.DisplayTemplate = nil
+		}
+		if !f.has("self_reference_allowed") {
+			attrs.SelfReferenceAllowed = nil
+		}
+		if !f.has("source_work_item_type_ids") {
+			attrs.SourceWorkItemTypeIDs = nil
+		}
+		if !f.has("target_work_item_type_ids") {
+			attrs.TargetWorkItemTypeIDs = nil
+		}
+		if !f.has("usageCount") {
+			attrs.UsageCount = nil
+		}
+	}
+	if rel := data.Relationships; rel != nil {
+		if !f.has("link_category") {
+			rel.LinkCategory = nil
+		}
+		if !f.has("space") {
+			rel.Space = nil
+		}
+		if !f.has("author") {
+			rel.Author = nil
+		}
+		if rel.LinkCategory == nil && rel.Space == nil && rel.Author == nil {
+			data.Relationships = nil
+		}
+	}
+}
+
+// Names of the related resources of work item link types that can be added to
+// the "included" array with the "include" parameter (see
+// http://jsonapi.org/format/#fetching-includes).
+const (
+	linkTypeIncludeLinkCategory = "link_category"
+	linkTypeIncludeSpace        = "space"
+	linkTypeIncludeAuthor       = "author"
+)
+
+// linkTypeIncludes holds the names of the related resources of work item link
+// types that are added to the "included" array.
+type linkTypeIncludes map[string]struct{}
+
+// defaultLinkTypeIncludes are included by the actions that have no "include"
+// parameter.
+var defaultLinkTypeIncludes = linkTypeIncludes{
+	linkTypeIncludeLinkCategory: {},
+	linkTypeIncludeSpace:        {},
+}
+
+// newLinkTypeIncludes parses the given comma separated include paths. Nothing
+// is included if no paths are given and unknown paths are rejected.
+func newLinkTypeIncludes(include *string) (linkTypeIncludes, error) {
+	res := linkTypeIncludes{}
+	if include == nil || *include == "" {
+		return res, nil
+	}
+	for _, path := range strings.Split(*include, ",") {
+		path = strings.TrimSpace(path)
+		switch path {
+		case linkTypeIncludeLinkCategory, linkTypeIncludeSpace, linkTypeIncludeAuthor:
+			res[path] = struct{}{}
+		default:
+			return nil, errors.NewBadParameterError("include", *include).Expected(fmt.Sprintf("comma separated list of %s, %s or %s", linkTypeIncludeLinkCategory, linkTypeIncludeSpace, linkTypeIncludeAuthor))
+		}
+	}
+	return res, nil
+}
+
+// has returns true if the related resource with the given name is included.
+func (i linkTypeIncludes) has(name string) bool {
+	_, ok := i[name]
+	return ok
+}
+
+// includeLinkTypeAuthors returns the distinct identities that created the
+// given link types in the order in which they first appear. Link types
+// without a known author or whose author relationship was left out by a
+// sparse fieldset are skipped.
+func includeLinkTypeAuthors(ctx context.Context, appl application.Application, request *http.Request, data ...*app.WorkItemLinkTypeData) ([]interface{}, error) {
+	res := []interface{}{}
+	seen := map[string]bool{}
+	for _, d := range data {
+		if d.Relationships == nil || d.Relationships.Author == nil || d.Relationships.Author.Data == nil {
+			continue
+		}
+		authorID := *d.Relationships.Author.Data.ID
+		if seen[authorID] {
+			continue
+		}
+		seen[authorID] = true
+		id, err := uuid.FromString(authorID)
+		if err != nil {
+			return nil, errs.Wrapf(err, "invalid author ID %s", authorID)
+		}
+		identity, err := appl.Identities().First(account.IdentityFilterByID(id), account.IdentityWithUser())
+		if err != nil {
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		if identity == nil {
+			return nil, errors.NewNotFoundError("identity", authorID)
+		}
+		res = append(res, &app.UserData{
+			ID:   ptr.String(identity.ID.String()),
+			Type: APIStringTypeUser,
+			Attributes: &app.UserDataAttributes{
+				IdentityID: ptr.String(identity.ID.String()),
+				Username:   &identity.Username,
+				FullName:   &identity.User.FullName,
+				ImageURL:   &identity.User.ImageURL,
+			},
+			Links: createUserLinks(request, identity.ID),
+		})
+	}
+	return res, nil
+}
+
+// addLinkTypeUsageCounts sets the "usageCount" attribute of every link type in
+// the list to the number of work item links of that type. For directed link
+// types the "usage_forward" and "usage_reverse" meta entries are set as well.
+func addLinkTypeUsageCounts(ctx context.Context, appl application.Application, list *app.WorkItemLinkTypeList) error {
+	ids := make([]uuid.UUID, len(list.Data))
+	for i, data := range list.Data {
+		ids[i] = *data.ID
+	}
+	counts, err := appl.WorkItemLinks().CountByTypeIDs(ctx, ids...)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	directions, err := appl.WorkItemLinks().CountDirectionsByTypeIDs(ctx, ids...)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	for _, data := range list.Data {
+		count := counts[*data.ID]
+		data.Attributes.UsageCount = &count
+		// for undirected link types only the total count is meaningful
+		if data.Attributes.Topology == nil || !link.Topology(*data.Attributes.Topology).IsDirected() {
+			continue
+		}
+		if data.Meta == nil {
+			data.Meta = map[string]interface{}{}
+		}
+		data.Meta["usage_forward"] = directions[*data.ID].Forward
+		data.Meta["usage_reverse"] = directions[*data.ID].Reverse
+	}
+	return nil
+}
+
+// authorizeLinkTypeEditor returns nil if the given user is the owner or a
+// collaborator of the space and therefore may create or restore work item
+// link types in it. Otherwise a ForbiddenError or UnauthorizedError is
+// returned.
+func (c *WorkItemLinkTypeController) authorizeLinkTypeEditor(ctx context.Context, spaceID uuid.UUID, currentUser uuid.UUID) error {
+	var sp *space.Space
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		sp, err = appl.Spaces().Load(ctx, spaceID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	authorized, spaceOwner, err := verifyUser(ctx, currentUser, sp)
+	if err != nil {
+		return errors.NewUnauthorizedError(err.Error())
+	}
+	if !authorized && !spaceOwner {
+		log.Error(ctx, map[string]interface{}{
+			"space_id":     sp.ID,
+			"space_owner":  sp.OwnerID,
+			"current_user": currentUser,
+		}, "user is not allowed to edit work item link types in the space")
+		return errors.NewForbiddenError("user is not allowed to edit work item link types in this space")
+	}
+	return nil
+}
+
+// linkTypeErrorResponse logs the error that occurred in the given operation
+// on the link types of the given space and maps it to the JSONAPI error
+// response. The link type ID is only logged if it is not nil.
+func linkTypeErrorResponse(ctx jsonapi.InternalServerErrorContext, operation string, spaceID uuid.UUID, linkTypeID *uuid.UUID, err error) error {
+	fields := map[string]interface{}{
+		"operation": operation,
+		"space_id":  spaceID,
+		"err":       err,
+	}
+	if linkTypeID != nil {
+		fields["wilt_id"] = *linkTypeID
+	}
+	log.Error(ctx, fields, "work item link type operation failed")
+	return jsonapi.JSONErrorResponse(ctx, err)
+}
+
+// logLinkTypeMutation logs that the given operation successfully changed the
+// given link types of the space.
+func logLinkTypeMutation(ctx context.Context, operation string, spaceID uuid.UUID, linkTypeIDs ...uuid.UUID) {
+	log.Info(ctx, map[string]interface{}{
+		"operation": operation,
+		"space_id":  spaceID,
+		"wilt_ids":  linkTypeIDs,
+	}, "work item link types changed")
+}
+
+// linkTypeMetricSpace returns the space label under which operations on link
+// types of the given space are reported.
+func linkTypeMetricSpace(spaceID uuid.UUID) string {
+	if uuid.Equal(spaceID, space.SystemSpace) {
+		return metric.LinkTypeSpaceSystem
+	}
+	return metric.LinkTypeSpaceCustom
+}
+
+// Create runs the create action.
+func (c *WorkItemLinkTypeController) Create(ctx *app.CreateWorkItemLinkTypeContext) error {
+	defer metric.ReportLinkTypeOperation(metric.LinkTypeOperationCreate, linkTypeMetricSpace(ctx.SpaceID), time.Now())
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "create", ctx.SpaceID, nil, errors.NewUnauthorizedError(err.Error()))
+	}
+	if err := c.authorizeLinkTypeEditor(ctx, ctx.SpaceID, *currentUserIdentityID); err != nil {
+		return linkTypeErrorResponse(ctx, "create", ctx.SpaceID, nil, err)
+	}
+	// Convert payload from app to model representation
+	appLinkType := app.WorkItemLinkTypeSingle{
+		Data: ctx.Payload.Data,
+	}
+	// Set the space to the Payload
+	if ctx.Payload.Data != nil && ctx.Payload.Data.Relationships != nil {
+		// We overwrite or use the space ID in the URL to set the space of this WI
+		spaceSelfURL := rest.AbsoluteURL(ctx.Request, app.SpaceHref(ctx.SpaceID.String()))
+		ctx.Payload.Data.Relationships.Space = app.NewSpaceRelation(ctx.SpaceID, spaceSelfURL)
+	}
+	modelLinkType, err := ConvertWorkItemLinkTypeToModel(appLinkType)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "create", ctx.SpaceID, nil, err)
+	}
+	modelLinkType.SpaceID = ctx.SpaceID
+	modelLinkType.CreatedBy = currentUserIdentityID
+	var createdModelLinkType *link.WorkItemLinkType
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		createdModelLinkType, err = appl.WorkItemLinkTypes().Create(ctx.Context, modelLinkType)
+		if err != nil {
+			return err
+		}
+		appLinkType = ConvertWorkItemLinkTypeFromModel(ctx.Request, *createdModelLinkType)
+		// Enrich
+		HrefFunc := func(obj interface{}) string {
+			return fmt.Sprintf(app.WorkItemLinkTypeHref(createdModelLinkType.SpaceID, "%v"), obj)
+		}
+		linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, currentUserIdentityID)
+		if err := enrichLinkTypeSingle(linkCtx, &appLinkType, defaultLinkTypeIncludes); err != nil {
+			return err
+		}
+		// the transaction is rolled back so nothing is persisted
+		if ctx.DryRun {
+			return errLinkTypeCreationDryRun
+		}
+		return nil
+	})
+	if err != nil && errs.Cause(err) != errLinkTypeCreationDryRun {
+		return linkTypeErrorResponse(ctx, "create", ctx.SpaceID, nil, err)
+	}
+	if ctx.DryRun {
+		ctx.ResponseData.Header().Set("X-Dry-Run", "true")
+		return ctx.OK(&appLinkType)
+	}
+	logLinkTypeMutation(ctx, "create", ctx.SpaceID, createdModelLinkType.ID)
+	ctx.ResponseData.Header().Set("Location", app.WorkItemLinkTypeHref(createdModelLinkType.SpaceID, appLinkType.Data.ID))
+	return ctx.Created(&appLinkType)
+}
+
+// CreateWithCategory runs the create_with_category action.
+func (c *WorkItemLinkTypeController) CreateWithCategory(ctx *app.CreateWithCategoryWorkItemLinkTypeContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "create_with_category", ctx.SpaceID, nil, errors.NewUnauthorizedError(err.Error()))
+	}
+	// Convert payload from app to model representation. The space is always
+	// the one from the URL and the category is set once it is created.
+	spaceSelfURL := rest.AbsoluteURL(ctx.Request, app.SpaceHref(ctx.SpaceID.String()))
+	modelLinkTypes := make([]*link.WorkItemLinkType, len(ctx.Payload.Data))
+	for i, data := range ctx.Payload.Data {
+		if data == nil {
+			return linkTypeErrorResponse(ctx, "create_with_category", ctx.SpaceID, nil, errors.NewBadParameterError(fmt.Sprintf("data[%d]", i), nil).Expected("not <nil>"))
+		}
+		if data.Relationships == nil {
+			data.Relationships = &app.WorkItemLinkTypeRelationships{}
+		}
+		data.Relationships.Space = app.NewSpaceRelation(ctx.SpaceID, spaceSelfURL)
+		modelLinkType, err := ConvertWorkItemLinkTypeToModel(app.WorkItemLinkTypeSingle{Data: data})
+		if err != nil {
+			return linkTypeErrorResponse(ctx, "create_with_category", ctx.SpaceID, nil, errs.Wrapf(err, "invalid work item link type at index %d", i))
+		}
+		modelLinkType.SpaceID = ctx.SpaceID
+		modelLinkType.CreatedBy = currentUserIdentityID
+		modelLinkTypes[i] = modelLinkType
+	}
+	modelCategory := ConvertLinkCategoryToModel(app.WorkItemLinkCategorySingle{Data: ctx.Payload.Category})
+	var res *app.WorkItemLinkTypeList
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		// Link categories are shared by all spaces, so only the space owner
+		// may create one and not every collaborator.
+		s, err := appl.Spaces().Load(ctx.Context, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		if !uuid.Equal(*currentUserIdentityID, s.OwnerID) {
+			log.Error(ctx, map[string]interface{}{
+				"space_id":     ctx.SpaceID,
+				"current_user": *currentUserIdentityID,
+				"space_owner":  s.OwnerID,
+			}, "current user is not the space owner")
+			return errors.NewForbiddenError("user is not the space owner")
+		}
+		createdCategory, err := appl.WorkItemLinkCategories().Create(ctx.Context, &modelCategory)
+		if err != nil {
+			return err
+		}
+		created := make([]link.WorkItemLinkType, len(modelLinkTypes))
+		for i, modelLinkType := range modelLinkTypes {
+			modelLinkType.LinkCategoryID = createdCategory.ID
+			createdLinkType, err := appl.WorkItemLinkTypes().Create(ctx.Context, modelLinkType)
+			if err != nil {
+				return errs.Wrapf(err, "failed to create work item link type %s", modelLinkType.Name)
+			}
+			created[i] = *createdLinkType
+		}
+		res, err = ConvertLinkTypesFromModels(ctx.Request, created)
+		if err != nil {
+			return err
+		}
+		// Enrich
+		HrefFunc := func(obj interface{}) string {
+			return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
+		}
+		linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, currentUserIdentityID)
+		return enrichLinkTypeList(linkCtx, res, defaultLinkTypeIncludes)
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "create_with_category", ctx.SpaceID, nil, err)
+	}
+	createdIDs := make([]uuid.UUID, len(res.Data))
+	for i, data := range res.Data {
+		createdIDs[i] = *data.ID
+	}
+	logLinkTypeMutation(ctx, "create_with_category", ctx.SpaceID, createdIDs...)
+	return ctx.Created(res)
+}
+
+// errLinkTypeCreationDryRun is used to roll back the transaction of a dry-run
+// link type creation.
+var errLinkTypeCreationDryRun = errs.New("dry-run of work item link type creation")
+
+// Validate runs the validate action. It validates every given payload as if
+// it was used to create a work item link type in the space and returns one
+// result per payload in the same order. Nothing is written to the database.
+func (c *WorkItemLinkTypeController) Validate(ctx *app.ValidateWorkItemLinkTypeContext) error {
+	results := make([]*app.WorkItemLinkTypeValidationResult, len(ctx.Payload.Data))
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		existingLinkTypes, _, err := appl.WorkItemLinkTypes().List(ctx.Context, ctx.SpaceID, nil, nil, nil, false, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		// names holds the names of all link types that already exist in the
+		// space or appeared earlier in the batch.
+		names := linkTypeNames{
+			names:        map[string]bool{},
+			forwardNames: map[string]bool{},
+			reverseNames: map[string]bool{},
+		}
+		for _, t := range existingLinkTypes {
+			names.add(t)
+		}
+		for i, data := range ctx.Payload.Data {
+			validationErrs, warnings, err := validateLinkType(ctx, appl, ctx.SpaceID, data, names)
+			if err != nil {
+				return err
+			}
+			result := &app.WorkItemLinkTypeValidationResult{
+				Valid:    len(validationErrs) == 0,
+				Errors:   make([]*app.JSONAPIError, len(validationErrs)),
+				Warnings: warnings,
+			}
+			for j, validationErr := range validationErrs {
+				jerr, _ := jsonapi.ErrorToJSONAPIError(ctx, validationErr)
+				result.Errors[j] = &jerr
+			}
+			results[i] = result
+		}
+		return nil
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "validate", ctx.SpaceID, nil, err)
+	}
+	return ctx.OK(&app.WorkItemLinkTypeValidationResults{Data: results})
+}
+
+// SetCategory runs the set_category action.
+func (c *WorkItemLinkTypeController) SetCategory(ctx *app.SetCategoryWorkItemLinkTypeContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "set_category", ctx.SpaceID, nil, errors.NewUnauthorizedError(err.Error()))
+	}
+	if err := c.authorizeLinkTypeEditor(ctx, ctx.SpaceID, *currentUserIdentityID); err != nil {
+		return linkTypeErrorResponse(ctx, "set_category", ctx.SpaceID, nil, err)
+	}
+	categoryID := ctx.Payload.LinkCategory.ID
+	res := &app.WorkItemLinkTypeCategoryChangeResultList{
+		Data: make([]*app.WorkItemLinkTypeCategoryChangeResult, len(ctx.Payload.Data)),
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID); err != nil {
+			return err
+		}
+		// categories are global, so any existing category can be used
+		if err := appl.WorkItemLinkCategories().CheckExists(ctx, categoryID); err != nil {
+			if _, ok := errs.Cause(err).(errors.NotFoundError); ok {
+				return errors.NewBadParameterError("link_category.id", categoryID).Expected("an existing work item link category")
+			}
+			return err
+		}
+		moved := &app.WorkItemLinkTypeList{
+			Data: []*app.WorkItemLinkTypeData{},
+		}
+		for i, data := range ctx.Payload.Data {
+			result := &app.WorkItemLinkTypeCategoryChangeResult{
+				ID: data.ID,
+			}
+			res.Data[i] = result
+			linkType, err := appl.WorkItemLinkTypes().Load(ctx, data.ID)
+			if err != nil {
+				if _, ok := errs.Cause(err).(errors.NotFoundError); !ok {
+					return err
+				}
+				jerr, _ := jsonapi.ErrorToJSONAPIError(ctx, err)
+				result.Error = &jerr
+				continue
+			}
+			// global link types from the system space can't be moved from
+			// within a space
+			if linkType.SpaceID != ctx.SpaceID {
+				jerr, _ := jsonapi.ErrorToJSONAPIError(ctx, errors.NewBadParameterError("data.id", data.ID).Expected("work item link type of space "+ctx.SpaceID.String()))
+				result.Error = &jerr
+				continue
+			}
+			if linkType.LinkCategoryID != categoryID {
+				linkType.LinkCategoryID = categoryID
+				linkType, err = appl.WorkItemLinkTypes().Save(ctx, *linkType)
+				if err != nil {
+					return err
+				}
+			}
+			appLinkType := ConvertWorkItemLinkTypeFromModel(ctx.Request, *linkType)
+			result.Moved = true
+			result.Data = appLinkType.Data
+			moved.Data = append(moved.Data, appLinkType.Data)
+		}
+		// Enrich
+		HrefFunc := func(obj interface{}) string {
+			return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
+		}
+		linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, currentUserIdentityID)
+		if err := enrichLinkTypeList(linkCtx, moved, defaultLinkTypeIncludes); err != nil {
+			return err
+		}
+		res.Included = moved.Included
+		return nil
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "set_category", ctx.SpaceID, nil, err)
+	}
+	movedIDs := []uuid.UUID{}
+	for _, result := range res.Data {
+		if result.Moved {
+			movedIDs = append(movedIDs, result.ID)
+		}
+	}
+	logLinkTypeMutation(ctx, "set_category", ctx.SpaceID, movedIDs...)
+	return ctx.OK(res)
+}
+
+// linkTypeNames holds the names, forward names and reverse names that are
+// taken by work item link types of a space. They must be unique within the
+// space, just like the repository enforces it on creation.
+type linkTypeNames struct {
+	names        map[string]bool
+	forwardNames map[string]bool
+	reverseNames map[string]bool
+}
+
+// add marks the names of the given link type as taken.
+func (n linkTypeNames) add(linkType link.WorkItemLinkType) {
+	n.names[linkType.Name] = true
+	n.forwardNames[linkType.ForwardName] = true
+	n.reverseNames[linkType.ReverseName] = true
+}
+
+// validateLinkType checks if the given payload can be used to create a work
+// item link type in the given space. Problems with the payload are returned as
+// validation errors or warnings; the returned error is only set if the
+// validation itself failed. The names of a valid payload are added to the
+// given names so that duplicates within a batch are detected.
+func validateLinkType(ctx context.Context, appl application.Application, spaceID uuid.UUID, data *app.WorkItemLinkTypeData, names linkTypeNames) ([]error, []string, error) {
+	warnings := []string{}
+	if data != nil && data.Relationships != nil {
+		data.Relationships.Space = app.NewSpaceRelation(spaceID, "")
+	}
+	modelLinkType, err := ConvertWorkItemLinkTypeToModel(app.WorkItemLinkTypeSingle{Data: data})
+	if err != nil {
+		// report every invalid field as a separate validation error
+		if ok, e := errors.IsBadParameterErrorCollection(err); ok {
+			validationErrs := []error{}
+			for _, badParam := range e.(errors.BadParameterErrorCollection).Errors {
+				validationErrs = append(validationErrs, badParam)
+			}
+			return validationErrs, warnings, nil
+		}
+		return []error{err}, warnings, nil
+	}
+	validationErrs := []error{}
+	if err := modelLinkType.CheckValidForCreation(); err != nil {
+		validationErrs = append(validationErrs, err)
+	}
+	if modelLinkType.LinkCategoryID != uuid.Nil {
+		err := appl.WorkItemLinkCategories().CheckExists(ctx, modelLinkType.LinkCategoryID)
+		if err != nil {
+			if _, ok := errs.Cause(err).(errors.NotFoundError); !ok {
+				return nil, nil, err
+			}
+			validationErrs = append(validationErrs, errors.NewBadParameterError("data.relationships.link_category.data.id", modelLinkType.LinkCategoryID).Expected("an existing work item link category"))
+		}
+	}
+	if modelLinkType.Name != "" && names.names[modelLinkType.Name] {
+		validationErrs = append(validationErrs, errors.NewDataConflictError(fmt.Sprintf("work item link type with name %s already exists", modelLinkType.Name)))
+	}
+	if modelLinkType.ForwardName != "" && names.forwardNames[modelLinkType.ForwardName] {
+		validationErrs = append(validationErrs, errors.NewDataConflictError(fmt.Sprintf("work item link type already exists in space %s with the same forward_name %q", spaceID, modelLinkType.ForwardName)))
+	}
+	if modelLinkType.ReverseName != "" && names.reverseNames[modelLinkType.ReverseName] {
+		validationErrs = append(validationErrs, errors.NewDataConflictError(fmt.Sprintf("work item link type already exists in space %s with the same reverse_name %q", spaceID, modelLinkType.ReverseName)))
+	}
+	if modelLinkType.Description == nil || *modelLinkType.Description == "" {
+		warnings = append(warnings, "work item link type has no description")
+	}
+	if modelLinkType.ForwardName != "" && modelLinkType.ForwardName == modelLinkType.ReverseName {
+		warnings = append(warnings, "forward and reverse name of the work item link type are identical")
+	}
+	if len(validationErrs) == 0 {
+		names.add(*modelLinkType)
+	}
+	return validationErrs, warnings, nil
+}
+
+// Delete runs the delete action.
+func (c *WorkItemLinkTypeController) Delete(ctx *app.DeleteWorkItemLinkTypeContext) error {
+	defer metric.ReportLinkTypeOperation(metric.LinkTypeOperationDelete, linkTypeMetricSpace(ctx.SpaceID), time.Now())
+	// Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
+	if true {
+		return ctx.MethodNotAllowed()
+	}
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "delete", ctx.SpaceID, &ctx.WiltID, errors.NewUnauthorizedError(err.Error()))
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		count, err := appl.WorkItemLinks().CountByTypeID(ctx.Context, ctx.WiltID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			if !ctx.Force {
+				return errors.NewDataConflictError(fmt.Sprintf("work item link type %s is still used by %d work item links; set force=true to delete them together with the type", ctx.WiltID, count))
+			}
+			if _, err := appl.WorkItemLinks().DeleteByTypeID(ctx.Context, ctx.WiltID, *currentUserIdentityID); err != nil {
+				return err
+			}
+		}
+		err = appl.WorkItemLinkTypes().Delete(ctx.Context, ctx.SpaceID, ctx.WiltID)
+		if err != nil {
+			return err
+		}
+		if err := clearDefaultLinkType(ctx.Context, appl, ctx.SpaceID, ctx.WiltID); err != nil {
+			return err
+		}
+		logLinkTypeMutation(ctx, "delete", ctx.SpaceID, ctx.WiltID)
+		return ctx.OK([]byte{})
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "delete", ctx.SpaceID, &ctx.WiltID, err)
+	}
+	return nil
+}
+
+// clearDefaultLinkType resets the default link type of the given space to the
+// system default if it is one of the given (deleted) link types.
+func clearDefaultLinkType(ctx context.Context, appl application.Application, spaceID uuid.UUID, deletedIDs ...uuid.UUID) error {
+	s, err := appl.Spaces().Load(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+	if s.DefaultLinkTypeID == nil {
+		return nil
+	}
+	for _, id := range deletedIDs {
+		if uuid.Equal(*s.DefaultLinkTypeID, id) {
+			s.DefaultLinkTypeID = nil
+			_, err := appl.Spaces().Save(ctx, s)
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteAll runs the delete_all action.
+func (c *WorkItemLinkTypeController) DeleteAll(ctx *app.DeleteAllWorkItemLinkTypeContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "delete_all", ctx.SpaceID, nil, errors.NewUnauthorizedError(err.Error()))
+	}
+	var deletedIDs []uuid.UUID
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		s, err := appl.Spaces().Load(ctx.Context, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		if !uuid.Equal(*currentUserIdentityID, s.OwnerID) {
+			log.Error(ctx, map[string]interface{}{
+				"space_id":     ctx.SpaceID,
+				"current_user": *currentUserIdentityID,
+				"space_owner":  s.OwnerID,
+			}, "current user is not the space owner")
+			return errors.NewForbiddenError("user is not the space owner")
+		}
+		// The list of a space also contains the link types of the system
+		// space which are not deleted.
+		modelLinkTypes, _, err := appl.WorkItemLinkTypes().List(ctx.Context, ctx.SpaceID, nil, nil, nil, false, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		for _, t := range modelLinkTypes {
+			if t.SpaceID == ctx.SpaceID && t.SpaceID != space.SystemSpace {
+				deletedIDs = append(deletedIDs, t.ID)
+			}
+		}
+		if len(deletedIDs) == 0 {
+			return nil
+		}
+		counts, err := appl.WorkItemLinks().CountByTypeIDs(ctx.Context, deletedIDs...)
+		if err != nil {
+			return err
+		}
+		total := 0
+		for _, count := range counts {
+			total += count
+		}
+		if total > 0 {
+			if !ctx.Force {
+				return errors.NewDataConflictError(fmt.Sprintf("the work item link types of space %s are still used by %d work item links; set force=true to delete them together with the types", ctx.SpaceID, total))
+			}
+			for id, count := range counts {
+				if count == 0 {
+					continue
+				}
+				if _, err := appl.WorkItemLinks().DeleteByTypeID(ctx.Context, id, *currentUserIdentityID); err != nil {
+					return err
+				}
+			}
+		}
+		if _, err := appl.WorkItemLinkTypes().DeleteAll(ctx.Context, ctx.SpaceID); err != nil {
+			return err
+		}
+		return clearDefaultLinkType(ctx.Context, appl, ctx.SpaceID, deletedIDs...)
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "delete_all", ctx.SpaceID, nil, err)
+	}
+	if len(deletedIDs) > 0 {
+		logLinkTypeMutation(ctx, "delete_all", ctx.SpaceID, deletedIDs...)
+	}
+	return ctx.OK(&app.WorkItemLinkTypeCount{
+		Meta: &app.WorkItemLinkTypeListMeta{
+			TotalCount: len(deletedIDs),
+		},
+	})
+}
+
+// Restore runs the restore action.
+func (c *WorkItemLinkTypeController) Restore(ctx *app.RestoreWorkItemLinkTypeContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "restore", ctx.SpaceID, &ctx.WiltID, errors.NewUnauthorizedError(err.Error()))
+	}
+	if err := c.authorizeLinkTypeEditor(ctx, ctx.SpaceID, *currentUserIdentityID); err != nil {
+		return linkTypeErrorResponse(ctx, "restore", ctx.SpaceID, &ctx.WiltID, err)
+	}
+	var appLinkType app.WorkItemLinkTypeSingle
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		modelLinkType, err := appl.WorkItemLinkTypes().Restore(ctx.Context, ctx.SpaceID, ctx.WiltID)
+		if err != nil {
+			return err
+		}
+		appLinkType = ConvertWorkItemLinkTypeFromModel(ctx.Request, *modelLinkType)
+		// Enrich
+		HrefFunc := func(obj interface{}) string {
+			return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
+		}
+		linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, currentUserIdentityID)
+		return enrichLinkTypeSingle(linkCtx, &appLinkType, defaultLinkTypeIncludes)
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "restore", ctx.SpaceID, &ctx.WiltID, err)
+	}
+	logLinkTypeMutation(ctx, "restore", ctx.SpaceID, ctx.WiltID)
+	return ctx.OK(&appLinkType)
+}
+
+// CopyFrom runs the copy_from action.
+func (c *WorkItemLinkTypeController) CopyFrom(ctx *app.CopyFromWorkItemLinkTypeContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "copy_from", ctx.SpaceID, nil, errors.NewUnauthorizedError(err.Error()))
+	}
+	if err := c.authorizeLinkTypeEditor(ctx, ctx.SpaceID, *currentUserIdentityID); err != nil {
+		return linkTypeErrorResponse(ctx, "copy_from", ctx.SpaceID, nil, err)
+	}
+	res := app.WorkItemLinkTypeCopyList{
+		Meta: &app.WorkItemLinkTypeCopyMeta{
+			Skipped: []string{},
+		},
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		if _, err := appl.Spaces().Load(ctx.Context, ctx.SourceSpaceID); err != nil {
+			return err
+		}
+		// The list of a space also contains the link types of the system
+		// space which must not be copied.
+		sourceLinkTypes, _, err := appl.WorkItemLinkTypes().List(ctx.Context, ctx.SourceSpaceID, nil, nil, nil, false, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		toCopy := []link.WorkItemLinkType{}
+		for _, t := range sourceLinkTypes {
+			if t.SpaceID != ctx.SourceSpaceID || t.SpaceID == space.SystemSpace {
+				continue
+			}
+			t.CreatedBy = currentUserIdentityID
+			toCopy = append(toCopy, t)
+		}
+		// Link types whose name, forward name or reverse name is already
+		// taken in the target space are skipped.
+		copied, err := appl.WorkItemLinkTypes().CreateDefaultsForSpace(ctx.Context, ctx.SpaceID, toCopy)
+		if err != nil {
+			return err
+		}
+		copiedNames := make(map[string]struct{}, len(copied))
+		for _, t := range copied {
+			copiedNames[t.Name] = struct{}{}
+		}
+		for _, t := range toCopy {
+			if _, ok := copiedNames[t.Name]; !ok {
+				res.Meta.Skipped = append(res.Meta.Skipped, t.Name)
+			}
+		}
+		appLinkTypes, err := ConvertLinkTypesFromModels(ctx.Request, copied)
+		if err != nil {
+			return err
+		}
+		// Enrich
+		HrefFunc := func(obj interface{}) string {
+			return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
+		}
+		linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, currentUserIdentityID)
+		if err := enrichLinkTypeList(linkCtx, appLinkTypes, defaultLinkTypeIncludes); err != nil {
+			return err
+		}
+		res.Data = appLinkTypes.Data
+		res.Included = appLinkTypes.Included
+		res.Meta.TotalCount = len(copied)
+		return nil
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "copy_from", ctx.SpaceID, nil, err)
+	}
+	createdIDs := make([]uuid.UUID, len(res.Data))
+	for i, data := range res.Data {
+		createdIDs[i] = *data.ID
+	}
+	logLinkTypeMutation(ctx, "copy_from", ctx.SpaceID, createdIDs...)
+	return ctx.OK(&res)
+}
+
+// linkTypeExportVersion is the version of the export document format
+// produced by the export action and accepted by the import action
+const linkTypeExportVersion = 1
+
+// Export runs the export action.
+func (c *WorkItemLinkTypeController) Export(ctx *app.ExportWorkItemLinkTypeContext) error {
+	res := app.WorkItemLinkTypeExport{
+		Version:   linkTypeExportVersion,
+		LinkTypes: []*app.WorkItemLinkTypeExportEntry{},
+	}
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		if _, err := appl.Spaces().Load(ctx.Context, ctx.SpaceID); err != nil {
+			return err
+		}
+		// The list of a space also contains the link types of the system
+		// space which must not be exported.
+		modelLinkTypes, _, err := appl.WorkItemLinkTypes().List(ctx.Context, ctx.SpaceID, nil, nil, nil, false, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		categories, err := appl.WorkItemLinkCategories().List(ctx.Context)
+		if err != nil {
+			return err
+		}
+		categoryNames := make(map[uuid.UUID]string, len(categories))
+		for _, cat := range categories {
+			categoryNames[cat.ID] = cat.Name
+		}
+		for _, t := range modelLinkTypes {
+			if t.SpaceID != ctx.SpaceID || t.SpaceID == space.SystemSpace {
+				continue
+			}
+			categoryName, ok := categoryNames[t.LinkCategoryID]
+			if !ok {
+				return errors.NewInternalError(ctx, errs.Errorf("link category %s of work item link type %s not found", t.LinkCategoryID, t.ID))
+			}
+			selfReferenceAllowed := t.SelfReferenceAllowed
+			descriptionMarkup := rendering.NilSafeGetMarkup(&t.DescriptionMarkup)
+			res.LinkTypes = append(res.LinkTypes, &app.WorkItemLinkTypeExportEntry{
+				Name:                  t.Name,
+				Description:           t.Description,
+				DescriptionMarkup:     &descriptionMarkup,
+				ForwardName:           t.ForwardName,
+				ReverseName:           t.ReverseName,
+				Topology:              t.Topology.String(),
+				LinkCategory:          categoryName,
+				SelfReferenceAllowed:  &selfReferenceAllowed,
+				SourceWorkItemTypeIDs: t.SourceWorkItemTypeIDs,
+				TargetWorkItemTypeIDs: t.TargetWorkItemTypeIDs,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "export", ctx.SpaceID, nil, err)
+	}
+	return ctx.OK(&res)
+}
+
+// Import runs the import action.
+func (c *WorkItemLinkTypeController) Import(ctx *app.ImportWorkItemLinkTypeContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "import", ctx.SpaceID, nil, errors.NewUnauthorizedError(err.Error()))
+	}
+	if err := c.authorizeLinkTypeEditor(ctx, ctx.SpaceID, *currentUserIdentityID); err != nil {
+		return linkTypeErrorResponse(ctx, "import", ctx.SpaceID, nil, err)
+	}
+	if ctx.Payload.Version != linkTypeExportVersion {
+		return linkTypeErrorResponse(ctx, "import", ctx.SpaceID, nil, errors.NewBadParameterError("version", ctx.Payload.Version).Expected(linkTypeExportVersion))
+	}
+	res := app.WorkItemLinkTypeCopyList{
+		Meta: &app.WorkItemLinkTypeCopyMeta{
+			Skipped: []string{},
+		},
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		s, err := appl.Spaces().Load(ctx.Context, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		categories, err := appl.WorkItemLinkCategories().List(ctx.Context)
+		if err != nil {
+			return err
+		}
+		categoryIDs := make(map[string]uuid.UUID, len(categories))
+		for _, cat := range categories {
+			categoryIDs[cat.Name] = cat.ID
+		}
+		toImport := make([]link.WorkItemLinkType, len(ctx.Payload.LinkTypes))
+		for i, entry := range ctx.Payload.LinkTypes {
+			categoryID, ok := categoryIDs[entry.LinkCategory]
+			if !ok {
+				// Link categories are shared by all spaces, so only the space
+				// owner may create a missing one and not every collaborator.
+				if !uuid.Equal(*currentUserIdentityID, s.OwnerID) {
+					log.Error(ctx, map[string]interface{}{
+						"space_id":      ctx.SpaceID,
+						"current_user":  *currentUserIdentityID,
+						"space_owner":   s.OwnerID,
+						"link_category": entry.LinkCategory,
+					}, "current user is not the space owner")
+					return errors.NewForbiddenError(fmt.Sprintf("only the space owner may create the missing work item link category %s", entry.LinkCategory))
+				}
+				cat := link.WorkItemLinkCategory{Name: entry.LinkCategory}
+				created, err := appl.WorkItemLinkCategories().Create(ctx.Context, &cat)
+				if err != nil {
+					return errs.Wrapf(err, "failed to create work item link category %s", entry.LinkCategory)
+				}
+				categoryID = created.ID
+				categoryIDs[created.Name] = created.ID
+			}
+			toImport[i] = link.WorkItemLinkType{
+				Name:                  entry.Name,
+				Description:           entry.Description,
+				DescriptionMarkup:     rendering.NilSafeGetMarkup(entry.DescriptionMarkup),
+				ForwardName:           entry.ForwardName,
+				ReverseName:           entry.ReverseName,
+				Topology:              link.Topology(entry.Topology),
+				LinkCategoryID:        categoryID,
+				CreatedBy:             currentUserIdentityID,
+				SourceWorkItemTypeIDs: entry.SourceWorkItemTypeIDs,
+				TargetWorkItemTypeIDs: entry.TargetWorkItemTypeIDs,
+			}
+			if entry.SelfReferenceAllowed != nil {
+				toImport[i].SelfReferenceAllowed = *entry.SelfReferenceAllowed
+			}
+		}
+		// Link types whose name, forward name or reverse name is already
+		// taken in the target space are skipped.
+		imported, err := appl.WorkItemLinkTypes().CreateDefaultsForSpace(ctx.Context, ctx.SpaceID, toImport)
+		if err != nil {
+			return err
+		}
+		importedNames := make(map[string]struct{}, len(imported))
+		for _, t := range imported {
+			importedNames[t.Name] = struct{}{}
+		}
+		for _, t := range toImport {
+			if _, ok := importedNames[t.Name]; !ok {
+				res.Meta.Skipped = append(res.Meta.Skipped, t.Name)
+			}
+		}
+		appLinkTypes, err := ConvertLinkTypesFromModels(ctx.Request, imported)
+		if err != nil {
+			return err
+		}
+		// Enrich
+		HrefFunc := func(obj interface{}) string {
+			return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
+		}
+		linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, currentUserIdentityID)
+		if err := enrichLinkTypeList(linkCtx, appLinkTypes, defaultLinkTypeIncludes); err != nil {
+			return err
+		}
+		res.Data = appLinkTypes.Data
+		res.Included = appLinkTypes.Included
+		res.Meta.TotalCount = len(imported)
+		return nil
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "import", ctx.SpaceID, nil, err)
+	}
+	createdIDs := make([]uuid.UUID, len(res.Data))
+	for i, data := range res.Data {
+		createdIDs[i] = *data.ID
+	}
+	logLinkTypeMutation(ctx, "import", ctx.SpaceID, createdIDs...)
+	return ctx.OK(&res)
+}
+
+// List runs the list action.
+func (c *WorkItemLinkTypeController) List(ctx *app.ListWorkItemLinkTypeContext) error {
+	defer metric.ReportLinkTypeOperation(metric.LinkTypeOperationList, linkTypeMetricSpace(ctx.SpaceID), time.Now())
+	offset, limit := computePagingLimits(ctx.PageOffset, ctx.PageLimit)
+	// keep the filters and sorting sticky in the paging links
+	additionalQuery := []string{}
+	if ctx.FieldsWorkitemlinktypes != nil {
+		additionalQuery = append(additionalQuery, "fields[workitemlinktypes]="+*ctx.FieldsWorkitemlinktypes)
+	}
+	if ctx.FilterCreatedBy != nil {
+		additionalQuery = append(additionalQuery, "filter[createdBy]="+ctx.FilterCreatedBy.String())
+	}
+	if ctx.FilterLinkCategoryID != nil {
+		additionalQuery = append(additionalQuery, "filter[linkCategoryID]="+ctx.FilterLinkCategoryID.String())
+	}
+	var topology *link.Topology
+	includes, err := newLinkTypeIncludes(ctx.Include)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "list", ctx.SpaceID, nil, err)
+	}
+	if ctx.OmitIncluded {
+		includes = linkTypeIncludes{}
+	}
+	if ctx.Include != nil {
+		additionalQuery = append(additionalQuery, "include="+*ctx.Include)
+	}
+	if ctx.FilterTopology != nil {
+		t := link.Topology(*ctx.FilterTopology)
+		if err := t.CheckValid(); err != nil {
+			return linkTypeErrorResponse(ctx, "list", ctx.SpaceID, nil, err)
+		}
+		topology = &t
+		additionalQuery = append(additionalQuery, "filter[topology]="+*ctx.FilterTopology)
+	}
+	if ctx.IncludeDeleted {
+		additionalQuery = append(additionalQuery, "includeDeleted=true")
 	}
-	// Now include the optional link category data in the work item link type "included" array
-	for categoryID := range categoryIDMap {
-		modelCategory, err := ctx.Application.WorkItemLinkCategories().Load(ctx.Context, categoryID)
-		if err != nil {
-			return err
-		}
-		appCategory := ConvertLinkCategoryFromModel(*modelCategory)
-		list.Included = append(list.Included, appCategory.Data)
+	if ctx.Sort != nil {
+		additionalQuery = append(additionalQuery, "sort="+*ctx.Sort)
 	}
-
-	// Build our "set" of distinct space IDs already converted as strings
-	spaceIDMap := map[uuid.UUID]bool{}
-	for _, typeData := range list.Data {
-		spaceIDMap[*typeData.Relationships.Space.Data.ID] = true
+	var ids []uuid.UUID
+	if ctx.Ids != nil {
+		if ctx.FilterCreatedBy != nil || ctx.FilterLinkCategoryID != nil || ctx.FilterTopology != nil || ctx.IncludeDeleted || ctx.Sort != nil || ctx.PageOffset != nil || ctx.PageLimit != nil {
+			return linkTypeErrorResponse(ctx, "list", ctx.SpaceID, nil, errors.NewBadParameterError("ids", *ctx.Ids).Expected("no filter, includeDeleted, sort or page parameters"))
+		}
+		seen := map[uuid.UUID]struct{}{}
+		for _, s := range strings.Split(*ctx.Ids, ",") {
+			id, err := uuid.FromString(strings.TrimSpace(s))
+			if err != nil {
+				return linkTypeErrorResponse(ctx, "list", ctx.SpaceID, nil, errors.NewBadParameterError("ids", *ctx.Ids).Expected("comma-separated list of UUIDs"))
+			}
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+		additionalQuery = append(additionalQuery, "ids="+*ctx.Ids)
 	}
-	// Now include the optional link space data in the work item link type "included" array
-	for spaceID := range spaceIDMap {
-		space, err := ctx.Application.Spaces().Load(ctx.Context, spaceID)
+	var modelLinkTypes []link.WorkItemLinkType
+	var count int
+	var revisions []app.ConditionalRequestEntity
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		if ids != nil {
+			// load the requested link types in the given order so that the
+			// combined ETag only changes when one of them changes
+			offset, limit = 0, len(ids)
+			for _, id := range ids {
+				modelLinkType, err := appl.WorkItemLinkTypes().Load(ctx.Context, id)
+				if err != nil {
+					return err
+				}
+				// link types of other spaces are not visible from this one
+				if modelLinkType.SpaceID != ctx.SpaceID && modelLinkType.SpaceID != space.SystemSpace {
+					return errors.NewNotFoundError("work item link type", id.String())
+				}
+				modelLinkTypes = append(modelLinkTypes, *modelLinkType)
+			}
+			count = len(modelLinkTypes)
+			return nil
+		}
+		var err error
+		modelLinkTypes, count, err = appl.WorkItemLinkTypes().List(ctx.Context, ctx.SpaceID, ctx.FilterCreatedBy, topology, ctx.FilterLinkCategoryID, ctx.IncludeDeleted, ctx.Sort, &offset, &limit)
 		if err != nil {
 			return err
 		}
-		spaceData, err := ConvertSpaceFromModel(ctx.Request, *space, IncludeBacklogTotalCount(ctx.Context, ctx.DB))
+		// a change of any link type of the space must change the ETag of the
+		// list, even if the changed link type is not on the requested page
+		modelRevisions, err := appl.WorkItemLinkTypes().Revisions(ctx.Context, ctx.SpaceID)
 		if err != nil {
 			return err
 		}
-		spaceSingle := &app.SpaceSingle{
-			Data: spaceData,
+		for _, r := range modelRevisions {
+			revisions = append(revisions, r)
 		}
-		list.Included = append(list.Included, spaceSingle.Data)
-	}
-	return nil
-}
-
-// Create runs the create action.
-func (c *WorkItemLinkTypeController) Create(ctx *app.CreateWorkItemLinkTypeContext) error {
-	// Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
-	if true {
-		return ctx.MethodNotAllowed()
-	}
-	// Convert payload from app to model representation
-	appLinkType := app.WorkItemLinkTypeSingle{
-		Data: ctx.Payload.Data,
-	}
-	// Set the space to the Payload
-	if ctx.Payload.Data != nil && ctx.Payload.Data.Relationships != nil {
-		// We overwrite or use the space ID in the URL to set the space of this WI
-		spaceSelfURL := rest.AbsoluteURL(ctx.Request, app.SpaceHref(ctx.SpaceID.String()))
-		ctx.Payload.Data.Relationships.Space = app.NewSpaceRelation(ctx.SpaceID, spaceSelfURL)
-	}
-	modelLinkType, err := ConvertWorkItemLinkTypeToModel(appLinkType)
-	if err != nil {
-		return jsonapi.JSONErrorResponse(ctx, goa.ErrBadRequest(err.Error()))
-	}
-	modelLinkType.SpaceID = ctx.SpaceID
-	currentUserIdentityID, err := login.ContextIdentity(ctx)
+		return nil
+	})
 	if err != nil {
-		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+		return linkTypeErrorResponse(ctx, "list", ctx.SpaceID, nil, err)
 	}
-	var createdModelLinkType *link.WorkItemLinkType
-	err = application.Transactional(c.db, func(appl application.Application) error {
-		createdModelLinkType, err = appl.WorkItemLinkTypes().Create(ctx.Context, modelLinkType)
+	render := func() error {
+		// convert to rest representation
+		appLinkTypes, err := ConvertLinkTypesFromModels(ctx.Request, modelLinkTypes)
 		if err != nil {
-			return err
+			return linkTypeErrorResponse(ctx, "list", ctx.SpaceID, nil, err)
 		}
-		appLinkType = ConvertWorkItemLinkTypeFromModel(ctx.Request, *createdModelLinkType)
+		appLinkTypes.Meta.TotalCount = count
+		appLinkTypes.Links = &app.PagingLinks{}
+		setPagingLinks(appLinkTypes.Links, buildAbsoluteURL(ctx.Request), len(modelLinkTypes), offset, limit, count, additionalQuery...)
 		// Enrich
 		HrefFunc := func(obj interface{}) string {
-			return fmt.Sprintf(app.WorkItemLinkTypeHref(createdModelLinkType.SpaceID, "%v"), obj)
+			return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
 		}
-		linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, currentUserIdentityID)
-		return enrichLinkTypeSingle(linkCtx, &appLinkType)
-	})
-	if err != nil {
-		return jsonapi.JSONErrorResponse(ctx, err)
+		err = application.Transactional(c.db, func(appl application.Application) error {
+			if ctx.IncludeUsage {
+				if err := addLinkTypeUsageCounts(ctx.Context, appl, appLinkTypes); err != nil {
+					return err
+				}
+			}
+			fieldset := newLinkTypeFieldset(ctx.FieldsWorkitemlinktypes)
+			for _, data := range appLinkTypes.Data {
+				fieldset.apply(data)
+			}
+			linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, nil)
+			if err := enrichLinkTypeList(linkCtx, appLinkTypes, includes); err != nil {
+				return err
+			}
+			if includes.has(linkTypeIncludeAuthor) {
+				authors, err := includeLinkTypeAuthors(ctx.Context, appl, ctx.Request, appLinkTypes.Data...)
+				if err != nil {
+					return err
+				}
+				appLinkTypes.Included = append(appLinkTypes.Included, authors...)
+			}
+			return nil
+		})
+		if err != nil {
+			return errs.Wrap(err, "Failed to enrich link types")
+		}
+		return ctx.OK(appLinkTypes)
 	}
-	ctx.ResponseData.Header().Set("Location", app.WorkItemLinkTypeHref(createdModelLinkType.SpaceID, appLinkType.Data.ID))
-	return ctx.Created(&appLinkType)
+	// The usage counts change with the links and not with the link types, so
+	// a response including them can't be validated by the link types' ETag or
+	// modification time.
+	if ctx.IncludeUsage {
+		return render()
+	}
+	return ctx.ConditionalEntities(modelLinkTypes, c.config.GetCacheControlWorkItemLinkTypes, render, revisions...)
 }
 
-// Delete runs the delete action.
-func (c *WorkItemLinkTypeController) Delete(ctx *app.DeleteWorkItemLinkTypeContext) error {
-	// Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
-	if true {
-		return ctx.MethodNotAllowed()
+// Codes of the problems reported by the lint action
+const (
+	linkTypeLintDuplicateName    = "duplicate_name"
+	linkTypeLintOrphanedCategory = "orphaned_category"
+	linkTypeLintUnused           = "unused"
+)
+
+// Lint runs the lint action.
+func (c *WorkItemLinkTypeController) Lint(ctx *app.LintWorkItemLinkTypeContext) error {
+	res := app.WorkItemLinkTypeLintWarningList{
+		Data: []*app.WorkItemLinkTypeLintWarning{},
+		Meta: &app.WorkItemLinkTypeListMeta{},
 	}
 	err := application.Transactional(c.db, func(appl application.Application) error {
-		err := appl.WorkItemLinkTypes().Delete(ctx.Context, ctx.SpaceID, ctx.WiltID)
+		if _, err := appl.Spaces().Load(ctx.Context, ctx.SpaceID); err != nil {
+			return err
+		}
+		// The list of a space also contains the link types of the system
+		// space which are not checked.
+		modelLinkTypes, _, err := appl.WorkItemLinkTypes().List(ctx.Context, ctx.SpaceID, nil, nil, nil, false, nil, nil, nil)
 		if err != nil {
 			return err
 		}
-		return ctx.OK([]byte{})
+		linkTypes := []link.WorkItemLinkType{}
+		ids := []uuid.UUID{}
+		for _, t := range modelLinkTypes {
+			if t.SpaceID == ctx.SpaceID && t.SpaceID != space.SystemSpace {
+				linkTypes = append(linkTypes, t)
+				ids = append(ids, t.ID)
+			}
+		}
+		categories, err := appl.WorkItemLinkCategories().List(ctx.Context)
+		if err != nil {
+			return err
+		}
+		categoryIDs := make(map[uuid.UUID]struct{}, len(categories))
+		for _, cat := range categories {
+			categoryIDs[cat.ID] = struct{}{}
+		}
+		usage, err := appl.WorkItemLinks().CountByTypeIDs(ctx.Context, ids...)
+		if err != nil {
+			return err
+		}
+		warn := func(t link.WorkItemLinkType, code, detail string) {
+			res.Data = append(res.Data, &app.WorkItemLinkTypeLintWarning{
+				Code:   code,
+				ID:     t.ID,
+				Name:   t.Name,
+				Detail: detail,
+			})
+		}
+		for _, t := range linkTypes {
+			if err := appl.WorkItemLinkTypes().CheckUniqueForwardAndReverseName(ctx.Context, t); err != nil {
+				if _, ok := errs.Cause(err).(errors.DataConflictError); !ok {
+					return err
+				}
+				warn(t, linkTypeLintDuplicateName, errs.Cause(err).Error())
+			}
+			if _, ok := categoryIDs[t.LinkCategoryID]; !ok {
+				warn(t, linkTypeLintOrphanedCategory, fmt.Sprintf("work item link category %s doesn't exist", t.LinkCategoryID))
+			}
+			if usage[t.ID] == 0 {
+				warn(t, linkTypeLintUnused, "there are no work item links of this type")
+			}
+		}
+		res.Meta.TotalCount = len(res.Data)
+		return nil
 	})
 	if err != nil {
-		return jsonapi.JSONErrorResponse(ctx, err)
+		return linkTypeErrorResponse(ctx, "lint", ctx.SpaceID, nil, err)
 	}
-	return nil
+	return ctx.OK(&res)
 }
 
-// List runs the list action.
-func (c *WorkItemLinkTypeController) List(ctx *app.ListWorkItemLinkTypeContext) error {
-	var modelLinkTypes []link.WorkItemLinkType
+// Count runs the count action.
+func (c *WorkItemLinkTypeController) Count(ctx *app.CountWorkItemLinkTypeContext) error {
+	var count int
 	err := application.Transactional(c.db, func(appl application.Application) error {
 		var err error
-		modelLinkTypes, err = appl.WorkItemLinkTypes().List(ctx.Context, ctx.SpaceID)
+		count, err = appl.WorkItemLinkTypes().Count(ctx.Context, ctx.SpaceID)
 		return err
 	})
 	if err != nil {
-		return jsonapi.JSONErrorResponse(ctx, err)
+		return linkTypeErrorResponse(ctx, "count", ctx.SpaceID, nil, err)
 	}
-	return ctx.ConditionalEntities(modelLinkTypes, c.config.GetCacheControlWorkItemLinkTypes, func() error {
-		// convert to rest representation
-		appLinkTypes := app.WorkItemLinkTypeList{}
-		appLinkTypes.Data = make([]*app.WorkItemLinkTypeData, len(modelLinkTypes))
-		for index, modelLinkType := range modelLinkTypes {
-			appLinkType := ConvertWorkItemLinkTypeFromModel(ctx.Request, modelLinkType)
-			appLinkTypes.Data[index] = appLinkType.Data
-		}
-		// TODO: When adding pagination, this must not be len(rows) but
-		// the overall total number of elements from all pages.
-		appLinkTypes.Meta = &app.WorkItemLinkTypeListMeta{
-			TotalCount: len(modelLinkTypes),
-		}
-		// Enrich
-		HrefFunc := func(obj interface{}) string {
-			return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
-		}
-		err := application.Transactional(c.db, func(appl application.Application) error {
-			linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, nil)
-			return enrichLinkTypeList(linkCtx, &appLinkTypes)
-		})
+	return ctx.OK(&app.WorkItemLinkTypeCount{
+		Meta: &app.WorkItemLinkTypeListMeta{
+			TotalCount: count,
+		},
+	})
+}
+
+// Usage runs the usage action.
+func (c *WorkItemLinkTypeController) Usage(ctx *app.UsageWorkItemLinkTypeContext) error {
+	to := time.Now()
+	if ctx.To != nil {
+		to = *ctx.To
+	}
+	var buckets []link.UsageBucket
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		linkType, err := appl.WorkItemLinkTypes().Load(ctx, ctx.WiltID)
 		if err != nil {
-			return errs.Wrap(err, "Failed to enrich link types")
+			return err
+		}
+		// the usage of link types of other spaces is not visible from this one
+		if linkType.SpaceID != ctx.SpaceID {
+			return errors.NewNotFoundError("work item link type", ctx.WiltID.String())
 		}
-		return ctx.OK(&appLinkTypes)
+		buckets, err = appl.WorkItemLinks().UsageOverTime(ctx, ctx.WiltID, ctx.From, to, link.UsageGranularity(ctx.Granularity))
+		return err
 	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "usage", ctx.SpaceID, &ctx.WiltID, err)
+	}
+	res := &app.WorkItemLinkTypeUsageList{
+		Data: make([]*app.WorkItemLinkTypeUsageData, len(buckets)),
+		Meta: &app.WorkItemLinkTypeListMeta{
+			TotalCount: len(buckets),
+		},
+	}
+	for i, b := range buckets {
+		res.Data[i] = &app.WorkItemLinkTypeUsageData{
+			Type: "workitemlinktypeusages",
+			Attributes: &app.WorkItemLinkTypeUsageAttributes{
+				Start: b.Start,
+				Count: b.Count,
+			},
+		}
+	}
+	return ctx.OK(res)
 }
 
 // Show runs the show action.
 func (c *WorkItemLinkTypeController) Show(ctx *app.ShowWorkItemLinkTypeContext) error {
-	err := application.Transactional(c.db, func(appl application.Application) error {
+	includes, err := newLinkTypeIncludes(ctx.Include)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "show", ctx.SpaceID, &ctx.WiltID, err)
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
 		modelLinkType, err := appl.WorkItemLinkTypes().Load(ctx.Context, ctx.WiltID)
 		if err != nil {
-			return jsonapi.JSONErrorResponse(ctx, err)
+			return linkTypeErrorResponse(ctx, "show", ctx.SpaceID, &ctx.WiltID, err)
 		}
-		return ctx.ConditionalRequest(*modelLinkType, c.config.GetCacheControlWorkItemLinkType, func() error {
+		render := func() error {
 			// Convert the created link type entry into a rest representation
 			appLinkType := ConvertWorkItemLinkTypeFromModel(ctx.Request, *modelLinkType)
+			if ctx.IncludeUsage {
+				count, err := appl.WorkItemLinks().CountByTypeID(ctx.Context, modelLinkType.ID)
+				if err != nil {
+					return linkTypeErrorResponse(ctx, "show", ctx.SpaceID, &ctx.WiltID, err)
+				}
+				appLinkType.Data.Attributes.UsageCount = &count
+			}
+			newLinkTypeFieldset(ctx.FieldsWorkitemlinktypes).apply(appLinkType.Data)
 
 			// Enrich
 			HrefFunc := func(obj interface{}) string {
 				return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
 			}
 			linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, nil)
-			err = enrichLinkTypeSingle(linkCtx, &appLinkType)
+			err = enrichLinkTypeSingle(linkCtx, &appLinkType, includes)
 			if err != nil {
 				return goa.ErrInternal("Failed to enrich link type: %s", err.Error())
 			}
+			if includes.has(linkTypeIncludeAuthor) {
+				authors, err := includeLinkTypeAuthors(ctx.Context, appl, ctx.Request, appLinkType.Data)
+				if err != nil {
+					return linkTypeErrorResponse(ctx, "show", ctx.SpaceID, &ctx.WiltID, err)
+				}
+				appLinkType.Included = append(appLinkType.Included, authors...)
+			}
 			return ctx.OK(&appLinkType)
-		})
+		}
+		// The usage count changes with the links and not with the link type,
+		// so a response including it can't be validated by the link type's
+		// ETag or modification time.
+		if ctx.IncludeUsage {
+			return render()
+		}
+		return ctx.ConditionalRequest(*modelLinkType, c.config.GetCacheControlWorkItemLinkType, render)
 	})
 	if err != nil {
-		return jsonapi.JSONErrorResponse(ctx, err)
+		return linkTypeErrorResponse(ctx, "show", ctx.SpaceID, &ctx.WiltID, err)
 	}
 	return nil
 }
 
+// errConsolidationDryRun is used to roll back the transaction of a dry-run
+// consolidation.
+var errConsolidationDryRun = errs.New("dry-run of work item link type consolidation")
+
+// Consolidate runs the consolidate action.
+func (c *WorkItemLinkTypeController) Consolidate(ctx *app.ConsolidateWorkItemLinkTypeContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "consolidate", ctx.SpaceID, nil, errors.NewUnauthorizedError(err.Error()))
+	}
+	var consolidations []link.TypeConsolidation
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		s, err := appl.Spaces().Load(ctx.Context, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		if !uuid.Equal(*currentUserIdentityID, s.OwnerID) {
+			log.Error(ctx, map[string]interface{}{
+				"space_id":     ctx.SpaceID,
+				"current_user": *currentUserIdentityID,
+				"space_owner":  s.OwnerID,
+			}, "current user is not the space owner")
+			return errors.NewForbiddenError("user is not the space owner")
+		}
+		consolidations, err = appl.WorkItemLinks().ConsolidateDuplicateTypes(ctx.Context, ctx.SpaceID, *currentUserIdentityID)
+		if err != nil {
+			return err
+		}
+		// the transaction is rolled back so nothing is changed
+		if ctx.DryRun {
+			return errConsolidationDryRun
+		}
+		return nil
+	})
+	if err != nil && errs.Cause(err) != errConsolidationDryRun {
+		return linkTypeErrorResponse(ctx, "consolidate", ctx.SpaceID, nil, err)
+	}
+	res := &app.WorkItemLinkTypeConsolidationList{
+		Data: make([]*app.WorkItemLinkTypeConsolidation, len(consolidations)),
+	}
+	for i, cons := range consolidations {
+		res.Data[i] = &app.WorkItemLinkTypeConsolidation{
+			Name:           cons.Name,
+			Kept:           cons.KeptTypeID,
+			Removed:        cons.RemovedTypeIDs,
+			RepointedLinks: cons.RepointedLinks,
+			MergedLinks:    cons.MergedLinks,
+		}
+	}
+	if !ctx.DryRun {
+		removedIDs := []uuid.UUID{}
+		for _, cons := range consolidations {
+			removedIDs = append(removedIDs, cons.RemovedTypeIDs...)
+		}
+		logLinkTypeMutation(ctx, "consolidate", ctx.SpaceID, removedIDs...)
+	}
+	return ctx.OK(res)
+}
+
+// Suggest runs the suggest action.
+func (c *WorkItemLinkTypeController) Suggest(ctx *app.SuggestWorkItemLinkTypeContext) error {
+	var appLinkTypes *app.WorkItemLinkTypeList
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		src, err := appl.WorkItems().LoadByID(ctx.Context, ctx.Source)
+		if err != nil {
+			return err
+		}
+		if src.SpaceID != ctx.SpaceID {
+			return errors.NewBadParameterError("source", ctx.Source).Expected("work item of space " + ctx.SpaceID.String())
+		}
+		modelLinkTypes, err := appl.WorkItemLinkTypes().Suggest(ctx.Context, ctx.Source, ctx.Target)
+		if err != nil {
+			return err
+		}
+		appLinkTypes, err = ConvertLinkTypesFromModels(ctx.Request, modelLinkTypes)
+		if err != nil {
+			return err
+		}
+		// Enrich
+		HrefFunc := func(obj interface{}) string {
+			return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
+		}
+		linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, nil)
+		return enrichLinkTypeList(linkCtx, appLinkTypes, defaultLinkTypeIncludes)
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "suggest", ctx.SpaceID, nil, err)
+	}
+	return ctx.OK(appLinkTypes)
+}
+
+// ShowDefault runs the show_default action.
+func (c *WorkItemLinkTypeController) ShowDefault(ctx *app.ShowDefaultWorkItemLinkTypeContext) error {
+	var appLinkType app.WorkItemLinkTypeSingle
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		s, err := appl.Spaces().Load(ctx.Context, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		var modelLinkType *link.WorkItemLinkType
+		if s.DefaultLinkTypeID != nil {
+			modelLinkType, err = appl.WorkItemLinkTypes().Load(ctx.Context, *s.DefaultLinkTypeID)
+			if err != nil {
+				if _, ok := errs.Cause(err).(errors.NotFoundError); !ok {
+					return err
+				}
+				// the default link type was deleted in the meantime
+				log.Warn(ctx, map[string]interface{}{
+					"space_id":             ctx.SpaceID,
+					"default_link_type_id": *s.DefaultLinkTypeID,
+				}, "default work item link type of space not found, falling back to the system default")
+				modelLinkType = nil
+			}
+		}
+		if modelLinkType == nil {
+			modelLinkType, err = appl.WorkItemLinkTypes().Load(ctx.Context, link.SystemWorkItemLinkPlannerItemRelatedID)
+			if err != nil {
+				return err
+			}
+		}
+		appLinkType = ConvertWorkItemLinkTypeFromModel(ctx.Request, *modelLinkType)
+		// Enrich
+		HrefFunc := func(obj interface{}) string {
+			return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
+		}
+		linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, nil)
+		return enrichLinkTypeSingle(linkCtx, &appLinkType, defaultLinkTypeIncludes)
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "show_default", ctx.SpaceID, nil, err)
+	}
+	return ctx.OK(&appLinkType)
+}
+
+// SetDefault runs the set_default action.
+func (c *WorkItemLinkTypeController) SetDefault(ctx *app.SetDefaultWorkItemLinkTypeContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "set_default", ctx.SpaceID, &ctx.Payload.Data.ID, errors.NewUnauthorizedError(err.Error()))
+	}
+	var appLinkType app.WorkItemLinkTypeSingle
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		s, err := appl.Spaces().Load(ctx.Context, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		if !uuid.Equal(*currentUserIdentityID, s.OwnerID) {
+			log.Error(ctx, map[string]interface{}{
+				"space_id":     ctx.SpaceID,
+				"current_user": *currentUserIdentityID,
+				"space_owner":  s.OwnerID,
+			}, "current user is not the space owner")
+			return errors.NewForbiddenError("user is not the space owner")
+		}
+		linkTypeID := ctx.Payload.Data.ID
+		modelLinkType, err := appl.WorkItemLinkTypes().Load(ctx.Context, linkTypeID)
+		if err != nil {
+			if _, ok := errs.Cause(err).(errors.NotFoundError); ok {
+				return errors.NewBadParameterError("data.id", linkTypeID).Expected("an existing work item link type")
+			}
+			return err
+		}
+		if modelLinkType.SpaceID != ctx.SpaceID && modelLinkType.SpaceID != space.SystemSpace {
+			return errors.NewBadParameterError("data.id", linkTypeID).Expected("work item link type of space " + ctx.SpaceID.String())
+		}
+		s.DefaultLinkTypeID = &linkTypeID
+		if _, err := appl.Spaces().Save(ctx.Context, s); err != nil {
+			return err
+		}
+		appLinkType = ConvertWorkItemLinkTypeFromModel(ctx.Request, *modelLinkType)
+		// Enrich
+		HrefFunc := func(obj interface{}) string {
+			return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
+		}
+		linkCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, currentUserIdentityID)
+		return enrichLinkTypeSingle(linkCtx, &appLinkType, defaultLinkTypeIncludes)
+	})
+	if err != nil {
+		return linkTypeErrorResponse(ctx, "set_default", ctx.SpaceID, &ctx.Payload.Data.ID, err)
+	}
+	logLinkTypeMutation(ctx, "set_default", ctx.SpaceID, ctx.Payload.Data.ID)
+	return ctx.OK(&appLinkType)
+}
+
 // Update runs the update action.
 func (c *WorkItemLinkTypeController) Update(ctx *app.UpdateWorkItemLinkTypeContext) error {
+	defer metric.ReportLinkTypeOperation(metric.LinkTypeOperationUpdate, linkTypeMetricSpace(ctx.SpaceID), time.Now())
 	// Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
 	if true {
 		return ctx.MethodNotAllowed()
 	}
 	currentUserIdentityID, err := login.ContextIdentity(ctx)
 	if err != nil {
-		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+		return linkTypeErrorResponse(ctx, "update", ctx.SpaceID, &ctx.WiltID, errors.NewUnauthorizedError(err.Error()))
 	}
 	var appLinkType app.WorkItemLinkTypeSingle
 	err = application.Transactional(c.db, func(appl application.Application) error {
 		toSave := app.WorkItemLinkTypeSingle{
 			Data: ctx.Payload.Data,
 		}
-		if toSave.Data.ID == nil {
-			return errors.NewBadParameterError("work item link type", nil)
+		if toSave.Data.ID != nil && *toSave.Data.ID != ctx.WiltID {
+			return errors.NewBadParameterError("data.id", *toSave.Data.ID).Expected(ctx.WiltID.String())
 		}
-		modelLinkTypeToSave, err := ConvertWorkItemLinkTypeToModel(toSave)
+		// Only the fields that are set in the payload are changed, the
+		// others keep their stored values
+		modelLinkTypeToSave, err := appl.WorkItemLinkTypes().Load(ctx.Context, ctx.WiltID)
 		if err != nil {
 			return err
 		}
+		if modelLinkTypeToSave.SpaceID != ctx.SpaceID {
+			return errors.NewNotFoundError("work item link type", ctx.WiltID.String())
+		}
+		// a link type can't be moved to another space
+		if rel := toSave.Data.Relationships; rel != nil && rel.Space != nil && rel.Space.Data != nil && rel.Space.Data.ID != nil && *rel.Space.Data.ID != modelLinkTypeToSave.SpaceID {
+			return errors.NewBadParameterError("data.relationships.space.data.id", *rel.Space.Data.ID).Expected(modelLinkTypeToSave.SpaceID.String())
+		}
+		existingVersion := modelLinkTypeToSave.Version
+		existingETag := app.GenerateEntityTag(*modelLinkTypeToSave)
+		if err := ApplyWorkItemLinkTypeToModel(toSave, modelLinkTypeToSave); err != nil {
+			return err
+		}
+		// An If-Match header takes precedence over the version in the payload
+		if ctx.IfMatch != nil {
+			if *ctx.IfMatch != existingETag {
+				return errors.NewPreconditionFailedError(fmt.Sprintf("ETag %s doesn't match the current ETag of work item link type %s", *ctx.IfMatch, modelLinkTypeToSave.ID))
+			}
+			modelLinkTypeToSave.Version = existingVersion
+		}
+		// the version is bumped when saving
 		modelLinkTypeSaved, err := appl.WorkItemLinkTypes().Save(ctx.Context, *modelLinkTypeToSave)
 		if err != nil {
 			return err
@@ -287,11 +1809,12 @@ func (c *WorkItemLinkTypeController) Update(ctx *app.UpdateWorkItemLinkTypeConte
 			return fmt.Sprintf(app.WorkItemLinkTypeHref(ctx.SpaceID, "%v"), obj)
 		}
 		linkTypeCtx := newWorkItemLinkContext(ctx.Context, ctx.Service, appl, c.db, ctx.Request, ctx.ResponseWriter, HrefFunc, currentUserIdentityID)
-		return enrichLinkTypeSingle(linkTypeCtx, &appLinkType)
+		return enrichLinkTypeSingle(linkTypeCtx, &appLinkType, defaultLinkTypeIncludes)
 	})
 	if err != nil {
-		return jsonapi.JSONErrorResponse(ctx, err)
+		return linkTypeErrorResponse(ctx, "update", ctx.SpaceID, &ctx.WiltID, err)
 	}
+	logLinkTypeMutation(ctx, "update", ctx.SpaceID, ctx.WiltID)
 	return ctx.OK(&appLinkType)
 }
 
@@ -301,19 +1824,28 @@ func ConvertWorkItemLinkTypeFromModel(request *http.Request, modelLinkType link.
 	linkCategoryRelatedURL := rest.AbsoluteURL(request, app.WorkItemLinkCategoryHref(modelLinkType.LinkCategoryID.String()))
 
 	topologyStr := modelLinkType.Topology.String()
+	displayTemplate := modelLinkType.DisplayTemplate()
+	descriptionMarkup := rendering.NilSafeGetMarkup(&modelLinkType.DescriptionMarkup)
 	var converted = app.WorkItemLinkTypeSingle{
 		Data: &app.WorkItemLinkTypeData{
 			Type: link.EndpointWorkItemLinkTypes,
 			ID:   &modelLinkType.ID,
 			Attributes: &app.WorkItemLinkTypeAttributes{
-				Name:        &modelLinkType.Name,
-				Description: modelLinkType.Description,
-				Version:     &modelLinkType.Version,
-				CreatedAt:   &modelLinkType.CreatedAt,
-				UpdatedAt:   &modelLinkType.UpdatedAt,
-				ForwardName: &modelLinkType.ForwardName,
-				ReverseName: &modelLinkType.ReverseName,
-				Topology:    &topologyStr,
+				Name:                  &modelLinkType.Name,
+				Description:           modelLinkType.Description,
+				DescriptionMarkup:     &descriptionMarkup,
+				Version:               &modelLinkType.Version,
+				CreatedAt:             &modelLinkType.CreatedAt,
+				UpdatedAt:             &modelLinkType.UpdatedAt,
+				DeletedAt:             modelLinkType.DeletedAt,
+				ForwardName:           &modelLinkType.ForwardName,
+				ReverseName:           &modelLinkType.ReverseName,
+				Topology:              &topologyStr,
+				SelfReferenceAllowed:  &modelLinkType.SelfReferenceAllowed,
+				SourceWorkItemTypeIDs: modelLinkType.SourceWorkItemTypeIDs,
+				TargetWorkItemTypeIDs: modelLinkType.TargetWorkItemTypeIDs,
+				// read-only, ignored by ConvertWorkItemLinkTypeToModel
+				DisplayTemplate: &displayTemplate,
 			},
 			Relationships: &app.WorkItemLinkTypeRelationships{
 				LinkCategory: &app.RelationWorkItemLinkCategory{
@@ -326,26 +1858,61 @@ func ConvertWorkItemLinkTypeFromModel(request *http.Request, modelLinkType link.
 						Related: &linkCategoryRelatedURL,
 					},
 				},
-				Space: app.NewSpaceRelation(modelLinkType.SpaceID, spaceRelatedURL),
+				Space:  app.NewSpaceRelation(modelLinkType.SpaceID, spaceRelatedURL),
+				Author: &app.RelationWorkItemLinkTypeAuthor{},
 			},
 		},
 	}
+	if modelLinkType.CreatedBy != nil {
+		converted.Data.Relationships.Author.Data = &app.GenericData{
+			Type:  ptr.String(APIStringTypeUser),
+			ID:    ptr.String(modelLinkType.CreatedBy.String()),
+			Links: createUserLinks(request, *modelLinkType.CreatedBy),
+		}
+	}
 	return converted
 }
 
 // ConvertWorkItemLinkTypeToModel converts the incoming app representation of a work item link type to the model layout.
-// Values are only overwrriten if they are set in "in", otherwise the values in "out" remain.
+// Unlike ApplyWorkItemLinkTypeToModel it requires the attributes and the space relationship to be set.
+// All invalid fields are reported at once in an errors.BadParameterErrorCollection.
 func ConvertWorkItemLinkTypeToModel(appLinkType app.WorkItemLinkTypeSingle) (*link.WorkItemLinkType, error) {
 	modelLinkType := link.WorkItemLinkType{}
+	err := ApplyWorkItemLinkTypeToModel(appLinkType, &modelLinkType)
 	if appLinkType.Data == nil {
-		return nil, errors.NewBadParameterError("data", nil).Expected("not <nil>")
+		return nil, err
+	}
+	badParams := errors.NewBadParameterErrorCollection()
+	if err != nil {
+		ok, collection := errors.IsBadParameterErrorCollection(err)
+		if !ok {
+			return nil, err
+		}
+		badParams.Add(collection.(errors.BadParameterErrorCollection).Errors...)
 	}
 	if appLinkType.Data.Attributes == nil {
-		return nil, errors.NewBadParameterError("data.attributes", nil).Expected("not <nil>")
+		badParams.Add(errors.NewBadParameterError("data.attributes", nil).Expected("not <nil>"))
 	}
 	if appLinkType.Data.Relationships == nil {
-		return nil, errors.NewBadParameterError("data.relationships", nil).Expected("not <nil>")
+		badParams.Add(errors.NewBadParameterError("data.relationships", nil).Expected("not <nil>"))
+	} else if appLinkType.Data.Relationships.Space == nil {
+		badParams.Add(errors.NewBadParameterError("data.relationships.space.data.id", nil).Expected("not <nil>"))
+	}
+	if err := badParams.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return &modelLinkType, nil
+}
+
+// ApplyWorkItemLinkTypeToModel only overwrites those fields of the given
+// model that are set in the REST representation, so that the fields that a
+// client omits keep their current values. Only the fields that are set are
+// validated.
+func ApplyWorkItemLinkTypeToModel(appLinkType app.WorkItemLinkTypeSingle, modelLinkType *link.WorkItemLinkType) error {
+	if appLinkType.Data == nil {
+		return errors.NewBadParameterErrorCollection(errors.NewBadParameterError("data", nil).Expected("not <nil>"))
 	}
+	badParams := errors.NewBadParameterErrorCollection()
 
 	attrs := appLinkType.Data.Attributes
 	rel := appLinkType.Data.Relationships
@@ -358,7 +1925,7 @@ func ConvertWorkItemLinkTypeToModel(appLinkType app.WorkItemLinkTypeSingle) (*li
 		// If the name is not nil, it MUST NOT be empty
 		if attrs.Name != nil {
 			if *attrs.Name == "" {
-				return nil, errors.NewBadParameterError("data.attributes.name", *attrs.Name)
+				badParams.Add(errors.NewBadParameterError("data.attributes.name", *attrs.Name).Expected("not empty"))
 			}
 			modelLinkType.Name = *attrs.Name
 		}
@@ -367,6 +1934,13 @@ func ConvertWorkItemLinkTypeToModel(appLinkType app.WorkItemLinkTypeSingle) (*li
 			modelLinkType.Description = attrs.Description
 		}
 
+		if attrs.DescriptionMarkup != nil {
+			if !rendering.IsMarkupSupported(*attrs.DescriptionMarkup) {
+				badParams.Add(errors.NewBadParameterError("data.attributes.description_markup", *attrs.DescriptionMarkup).Expected(rendering.SystemMarkupPlainText + " or " + rendering.SystemMarkupMarkdown))
+			}
+			modelLinkType.DescriptionMarkup = *attrs.DescriptionMarkup
+		}
+
 		if attrs.Version != nil {
 			modelLinkType.Version = *attrs.Version
 		}
@@ -374,7 +1948,7 @@ func ConvertWorkItemLinkTypeToModel(appLinkType app.WorkItemLinkTypeSingle) (*li
 		// If the forwardName is not nil, it MUST NOT be empty
 		if attrs.ForwardName != nil {
 			if *attrs.ForwardName == "" {
-				return nil, errors.NewBadParameterError("data.attributes.forward_name", *attrs.ForwardName)
+				badParams.Add(errors.NewBadParameterError("data.attributes.forward_name", *attrs.ForwardName).Expected("not empty"))
 			}
 			modelLinkType.ForwardName = *attrs.ForwardName
 		}
@@ -382,7 +1956,7 @@ func ConvertWorkItemLinkTypeToModel(appLinkType app.WorkItemLinkTypeSingle) (*li
 		// If the ReverseName is not nil, it MUST NOT be empty
 		if attrs.ReverseName != nil {
 			if *attrs.ReverseName == "" {
-				return nil, errors.NewBadParameterError("data.attributes.reverse_name", *attrs.ReverseName)
+				badParams.Add(errors.NewBadParameterError("data.attributes.reverse_name", *attrs.ReverseName).Expected("not empty"))
 			}
 			modelLinkType.ReverseName = *attrs.ReverseName
 		}
@@ -390,21 +1964,47 @@ func ConvertWorkItemLinkTypeToModel(appLinkType app.WorkItemLinkTypeSingle) (*li
 		if attrs.Topology != nil {
 			modelLinkType.Topology = link.Topology(*attrs.Topology)
 			if err := modelLinkType.Topology.CheckValid(); err != nil {
-				return nil, err
+				valid := make([]string, len(link.ValidTopologies))
+				for i, t := range link.ValidTopologies {
+					valid[i] = t.String()
+				}
+				badParams.Add(errors.NewBadParameterError("data.attributes.topology", *attrs.Topology).Expected("one of " + strings.Join(valid, ", ")))
 			}
 		}
-	}
 
-	if rel != nil && rel.LinkCategory != nil && rel.LinkCategory.Data != nil {
-		modelLinkType.LinkCategoryID = rel.LinkCategory.Data.ID
+		if attrs.SelfReferenceAllowed != nil {
+			modelLinkType.SelfReferenceAllowed = *attrs.SelfReferenceAllowed
+		}
+		if attrs.SourceWorkItemTypeIDs != nil {
+			modelLinkType.SourceWorkItemTypeIDs = attrs.SourceWorkItemTypeIDs
+		}
+		if attrs.TargetWorkItemTypeIDs != nil {
+			modelLinkType.TargetWorkItemTypeIDs = attrs.TargetWorkItemTypeIDs
+		}
 	}
-	if rel != nil && rel.Space != nil && rel.Space.Data != nil {
-		modelLinkType.SpaceID = *rel.Space.Data.ID
+
+	if rel != nil {
+		if rel.LinkCategory != nil && rel.LinkCategory.Data != nil {
+			modelLinkType.LinkCategoryID = rel.LinkCategory.Data.ID
+		}
+		if rel.Space != nil {
+			if rel.Space.Data == nil || rel.Space.Data.ID == nil {
+				badParams.Add(errors.NewBadParameterError("data.relationships.space.data.id", nil).Expected("not <nil>"))
+			} else if uuid.Equal(*rel.Space.Data.ID, uuid.Nil) {
+				badParams.Add(errors.NewBadParameterError("data.relationships.space.data.id", *rel.Space.Data.ID).Expected("not the nil UUID"))
+			} else {
+				modelLinkType.SpaceID = *rel.Space.Data.ID
+			}
+		}
 	}
 
-	return &modelLinkType, nil
+	return badParams.ErrorOrNil()
 }
 
+// ConvertLinkTypesFromModels converts a list of work item link types from
+// model to REST representation. The returned list always has a non-nil "data"
+// array and a "meta" object so that an empty result is serialized as
+// `"data":[]` and `"totalCount":0` rather than `null`.
 func ConvertLinkTypesFromModels(request *http.Request, modelLinkTypes []link.WorkItemLinkType) (*app.WorkItemLinkTypeList, error) {
 	appLinkTypes := app.WorkItemLinkTypeList{}
 	appLinkTypes.Data = make([]*app.WorkItemLinkTypeData, len(modelLinkTypes))
@@ -412,8 +2012,8 @@ func ConvertLinkTypesFromModels(request *http.Request, modelLinkTypes []link.Wor
 		appLinkType := ConvertWorkItemLinkTypeFromModel(request, modelLinkType)
 		appLinkTypes.Data[index] = appLinkType.Data
 	}
-	// TODO: When adding pagination, this must not be len(rows) but
-	// the overall total number of elements from all pages.
+	// Paged lists must overwrite this with the overall total number of
+	// elements from all pages.
 	appLinkTypes.Meta = &app.WorkItemLinkTypeListMeta{
 		TotalCount: len(modelLinkTypes),
 	}
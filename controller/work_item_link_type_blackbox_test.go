@@ -3,6 +3,7 @@ package controller_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -12,17 +13,22 @@ import (
 	"github.com/fabric8-services/fabric8-wit/app/test"
 	"github.com/fabric8-services/fabric8-wit/application"
 	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormapplication"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/rendering"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	"github.com/fabric8-services/fabric8-wit/space"
 	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
 	testtoken "github.com/fabric8-services/fabric8-wit/test/token"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -140,12 +146,12 @@ func TestNewWorkItemLinkTypeControllerDBNull(t *testing.T) {
 	})
 }
 
-// Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
-// TestCreateWorkItemLinkType tests if we can create the s.linkTypeName work item link type
+// TestCreateAndDeleteWorkItemLinkType tests if we can create the
+// s.linkTypeName work item link type. Deleting it is still disabled as part of
+// https://github.com/fabric8-services/fabric8-wit/issues/1299
 func (s *workItemLinkTypeSuite) TestCreateAndDeleteWorkItemLinkType() {
-	s.T().Skip("skipped because Work Item Link Type Create/Update/Delete endpoints are disabled")
 	createPayload := s.createDemoLinkType(s.linkTypeName)
-	_, workItemLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *createPayload.Data.Relationships.Space.Data.ID, createPayload)
+	_, workItemLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *createPayload.Data.Relationships.Space.Data.ID, false, createPayload)
 	require.NotNil(s.T(), workItemLinkType)
 
 	// Check that the link category is included in the response in the "included" array
@@ -159,7 +165,158 @@ func (s *workItemLinkTypeSuite) TestCreateAndDeleteWorkItemLinkType() {
 	require.True(s.T(), ok)
 	require.Equal(s.T(), s.spaceName, *spaceData.Attributes.Name, "The work item link type's space should have the name 'test-space'.")
 
-	_ = test.DeleteWorkItemLinkTypeOK(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *workItemLinkType.Data.Relationships.Space.Data.ID, *workItemLinkType.Data.ID)
+	test.DeleteWorkItemLinkTypeMethodNotAllowed(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *workItemLinkType.Data.Relationships.Space.Data.ID, *workItemLinkType.Data.ID, false)
+}
+
+func (s *workItemLinkTypeSuite) TestCreateWorkItemLinkType() {
+	// given a space owned by the first and a collaboration of the second identity
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(3),
+		tf.Spaces(1),
+		tf.WorkItemLinkCategories(1),
+	)
+	owner := *fxt.Identities[0]
+	spaceID := fxt.Spaces[0].ID
+	authzSrv := &TestSpaceAuthzService{owner, fxt.Identities[1].ID.String()}
+	s.T().Run("owner", func(t *testing.T) {
+		// when
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", owner, authzSrv)
+		payload := newCreateWorkItemLinkTypePayload("created by owner", fxt.WorkItemLinkCategories[0].ID, spaceID)
+		res, lt := test.CreateWorkItemLinkTypeCreated(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+		// then
+		require.NotNil(t, lt.Data.ID)
+		require.Equal(t, app.WorkItemLinkTypeHref(spaceID, *lt.Data.ID), res.Header()["Location"][0])
+		require.Equal(t, spaceID, *lt.Data.Relationships.Space.Data.ID)
+	})
+	s.T().Run("collaborator", func(t *testing.T) {
+		// when
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[1], authzSrv)
+		payload := newCreateWorkItemLinkTypePayload("created by collaborator", fxt.WorkItemLinkCategories[0].ID, spaceID)
+		_, lt := test.CreateWorkItemLinkTypeCreated(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+		// then
+		require.NotNil(t, lt.Data.ID)
+	})
+	s.T().Run("fixed ID", func(t *testing.T) {
+		// given
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", owner, authzSrv)
+		id := uuid.NewV4()
+		payload := newCreateWorkItemLinkTypePayload("fixed ID", fxt.WorkItemLinkCategories[0].ID, spaceID)
+		payload.Data.ID = &id
+		// when
+		_, lt := test.CreateWorkItemLinkTypeCreated(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+		// then
+		require.Equal(t, id, *lt.Data.ID)
+		t.Run("conflict when already in use", func(t *testing.T) {
+			payload := newCreateWorkItemLinkTypePayload("fixed ID again", fxt.WorkItemLinkCategories[0].ID, spaceID)
+			payload.Data.ID = &id
+			test.CreateWorkItemLinkTypeConflict(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+		})
+		t.Run("conflict when used by a deleted link type", func(t *testing.T) {
+			require.NoError(t, link.NewWorkItemLinkTypeRepository(s.DB).Delete(svc.Context, spaceID, id))
+			payload := newCreateWorkItemLinkTypePayload("fixed ID after delete", fxt.WorkItemLinkCategories[0].ID, spaceID)
+			payload.Data.ID = &id
+			test.CreateWorkItemLinkTypeConflict(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+		})
+	})
+	s.T().Run("conflict on reused forward or reverse name", func(t *testing.T) {
+		// given
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", owner, authzSrv)
+		existing := newCreateWorkItemLinkTypePayload("existing names", fxt.WorkItemLinkCategories[0].ID, spaceID)
+		test.CreateWorkItemLinkTypeCreated(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, existing)
+		t.Run("same forward name", func(t *testing.T) {
+			payload := newCreateWorkItemLinkTypePayload("same forward name", fxt.WorkItemLinkCategories[0].ID, spaceID)
+			payload.Data.Attributes.ForwardName = existing.Data.Attributes.ForwardName
+			test.CreateWorkItemLinkTypeConflict(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+		})
+		t.Run("same reverse name", func(t *testing.T) {
+			payload := newCreateWorkItemLinkTypePayload("same reverse name", fxt.WorkItemLinkCategories[0].ID, spaceID)
+			payload.Data.Attributes.ReverseName = existing.Data.Attributes.ReverseName
+			test.CreateWorkItemLinkTypeConflict(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+		})
+	})
+	s.T().Run("description markup", func(t *testing.T) {
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", owner, authzSrv)
+		t.Run("defaults to plain text", func(t *testing.T) {
+			// given
+			payload := newCreateWorkItemLinkTypePayload("plain text description", fxt.WorkItemLinkCategories[0].ID, spaceID)
+			payload.Data.Attributes.DescriptionMarkup = nil
+			// when
+			_, lt := test.CreateWorkItemLinkTypeCreated(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+			// then
+			require.Equal(t, rendering.SystemMarkupPlainText, *lt.Data.Attributes.DescriptionMarkup)
+		})
+		t.Run("markdown", func(t *testing.T) {
+			// given
+			payload := newCreateWorkItemLinkTypePayload("markdown description", fxt.WorkItemLinkCategories[0].ID, spaceID)
+			payload.Data.Attributes.DescriptionMarkup = ptr.String(rendering.SystemMarkupMarkdown)
+			// when
+			_, lt := test.CreateWorkItemLinkTypeCreated(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+			// then
+			require.Equal(t, rendering.SystemMarkupMarkdown, *lt.Data.Attributes.DescriptionMarkup)
+			_, shown := test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, *lt.Data.ID, nil, nil, false, nil, nil)
+			require.Equal(t, rendering.SystemMarkupMarkdown, *shown.Data.Attributes.DescriptionMarkup)
+		})
+	})
+	s.T().Run("dry run", func(t *testing.T) {
+		// when
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", owner, authzSrv)
+		payload := newCreateWorkItemLinkTypePayload("dry run", fxt.WorkItemLinkCategories[0].ID, spaceID)
+		res, lt := test.CreateWorkItemLinkTypeOK(t, svc.Context, svc, s.linkTypeCtrl, spaceID, true, payload)
+		// then the link type is returned but not persisted
+		require.Equal(t, "true", res.Header().Get("X-Dry-Run"))
+		require.NotNil(t, lt.Data.ID)
+		require.Equal(t, "dry run", *lt.Data.Attributes.Name)
+		_, err := link.NewWorkItemLinkTypeRepository(s.DB).Load(svc.Context, *lt.Data.ID)
+		require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+	s.T().Run("dry run validates the payload", func(t *testing.T) {
+		// when/then
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", owner, authzSrv)
+		payload := newCreateWorkItemLinkTypePayload("", fxt.WorkItemLinkCategories[0].ID, spaceID)
+		test.CreateWorkItemLinkTypeBadRequest(t, svc.Context, svc, s.linkTypeCtrl, spaceID, true, payload)
+	})
+	s.T().Run("forbidden for others", func(t *testing.T) {
+		// when/then
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[2], authzSrv)
+		payload := newCreateWorkItemLinkTypePayload("created by stranger", fxt.WorkItemLinkCategories[0].ID, spaceID)
+		test.CreateWorkItemLinkTypeForbidden(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+	})
+	s.T().Run("bad request due to empty name", func(t *testing.T) {
+		// when/then
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", owner, authzSrv)
+		payload := newCreateWorkItemLinkTypePayload("", fxt.WorkItemLinkCategories[0].ID, spaceID)
+		test.CreateWorkItemLinkTypeBadRequest(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+	})
+	s.T().Run("bad request due to wrong topology", func(t *testing.T) {
+		// when/then
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", owner, authzSrv)
+		payload := newCreateWorkItemLinkTypePayload("wrong topology", fxt.WorkItemLinkCategories[0].ID, spaceID)
+		wrongTopology := "wrongtopology"
+		payload.Data.Attributes.Topology = &wrongTopology
+		test.CreateWorkItemLinkTypeBadRequest(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+	})
+	s.T().Run("bad request lists every invalid field", func(t *testing.T) {
+		// given
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", owner, authzSrv)
+		payload := newCreateWorkItemLinkTypePayload("", fxt.WorkItemLinkCategories[0].ID, spaceID)
+		emptyName := ""
+		payload.Data.Attributes.ForwardName = &emptyName
+		wrongTopology := "wrongtopology"
+		payload.Data.Attributes.Topology = &wrongTopology
+		// when
+		_, jerrs := test.CreateWorkItemLinkTypeBadRequest(t, svc.Context, svc, s.linkTypeCtrl, spaceID, false, payload)
+		// then
+		pointers := []interface{}{}
+		for _, jerr := range jerrs.Errors {
+			pointers = append(pointers, jerr.Source["pointer"])
+		}
+		require.Equal(t, []interface{}{"/data/attributes/name", "/data/attributes/forward_name", "/data/attributes/topology"}, pointers)
+	})
+	s.T().Run("unauthorized", func(t *testing.T) {
+		// when/then
+		payload := newCreateWorkItemLinkTypePayload("anonymous", fxt.WorkItemLinkCategories[0].ID, spaceID)
+		test.CreateWorkItemLinkTypeUnauthorized(t, nil, nil, s.linkTypeCtrl, spaceID, false, payload)
+	})
 }
 
 //func (s *workItemLinkTypeSuite) TestCreateWorkItemLinkTypeBadRequest() {
@@ -184,7 +341,29 @@ func (s *workItemLinkTypeSuite) TestCreateAndDeleteWorkItemLinkType() {
 // Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
 func (s *workItemLinkTypeSuite) TestDeleteWorkItemLinkTypeNotFound() {
 	s.T().Skip("skipped because Work Item Link Type Create/Update/Delete endpoints are disabled")
-	test.DeleteWorkItemLinkTypeNotFound(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, space.SystemSpace, uuid.FromStringOrNil("1e9a8b53-73a6-40de-b028-5177add79ffa"))
+	test.DeleteWorkItemLinkTypeNotFound(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, space.SystemSpace, uuid.FromStringOrNil("1e9a8b53-73a6-40de-b028-5177add79ffa"), false)
+}
+
+// Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
+func (s *workItemLinkTypeSuite) TestDeleteWorkItemLinkTypeInUse() {
+	s.T().Skip("skipped because Work Item Link Type Create/Update/Delete endpoints are disabled")
+	// given a link type that is used by one link
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(2, tf.SetWorkItemTitles("A", "B")),
+		tf.WorkItemLinkTypes(1, tf.SetWorkItemLinkTypeNames("used")),
+		tf.WorkItemLinksCustom(1, tf.BuildLinks(tf.L("A", "B", "used"))),
+	)
+	svc := testsupport.ServiceAsUser("WorkItemLinkType-Service", *fxt.Identities[0])
+	s.T().Run("conflict", func(t *testing.T) {
+		test.DeleteWorkItemLinkTypeConflict(t, svc.Context, svc, s.linkTypeCtrl, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID, false)
+		_, err := s.appDB.WorkItemLinks().Load(s.Ctx, fxt.WorkItemLinks[0].ID)
+		require.NoError(t, err)
+	})
+	s.T().Run("force", func(t *testing.T) {
+		test.DeleteWorkItemLinkTypeOK(t, svc.Context, svc, s.linkTypeCtrl, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID, true)
+		_, err := s.appDB.WorkItemLinks().Load(s.Ctx, fxt.WorkItemLinks[0].ID)
+		require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
 }
 
 // Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
@@ -197,7 +376,7 @@ func (s *workItemLinkTypeSuite) TestUpdateWorkItemLinkTypeNotFound() {
 	updateLinkTypePayload := &app.UpdateWorkItemLinkTypePayload{
 		Data: createPayload.Data,
 	}
-	test.UpdateWorkItemLinkTypeNotFound(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *updateLinkTypePayload.Data.Relationships.Space.Data.ID, *updateLinkTypePayload.Data.ID, updateLinkTypePayload)
+	test.UpdateWorkItemLinkTypeNotFound(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *updateLinkTypePayload.Data.Relationships.Space.Data.ID, *updateLinkTypePayload.Data.ID, nil, updateLinkTypePayload)
 }
 
 // func (s *workItemLinkTypeSuite) TestUpdateWorkItemLinkTypeBadRequestDueToBadID() {
@@ -216,7 +395,7 @@ func (s *workItemLinkTypeSuite) TestUpdateWorkItemLinkTypeOK() {
 	s.T().Skip("skipped because Work Item Link Type Create/Update/Delete endpoints are disabled")
 	// given
 	createPayload := s.createDemoLinkType(s.linkTypeName)
-	_, workItemLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *createPayload.Data.Relationships.Space.Data.ID, createPayload)
+	_, workItemLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *createPayload.Data.Relationships.Space.Data.ID, false, createPayload)
 	require.NotNil(s.T(), workItemLinkType)
 	// Specify new description for link type that we just created
 	// Wrap data portion in an update payload instead of a create payload
@@ -226,7 +405,7 @@ func (s *workItemLinkTypeSuite) TestUpdateWorkItemLinkTypeOK() {
 	newDescription := "Lalala this is a new description for the work item type"
 	updateLinkTypePayload.Data.Attributes.Description = &newDescription
 	// when
-	_, lt := test.UpdateWorkItemLinkTypeOK(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *updateLinkTypePayload.Data.Relationships.Space.Data.ID, *updateLinkTypePayload.Data.ID, updateLinkTypePayload)
+	_, lt := test.UpdateWorkItemLinkTypeOK(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *updateLinkTypePayload.Data.Relationships.Space.Data.ID, *updateLinkTypePayload.Data.ID, nil, updateLinkTypePayload)
 	// then
 	require.NotNil(s.T(), lt.Data)
 	require.NotNil(s.T(), lt.Data.Attributes)
@@ -243,12 +422,45 @@ func (s *workItemLinkTypeSuite) TestUpdateWorkItemLinkTypeOK() {
 	require.Equal(s.T(), s.spaceName, *spaceData.Attributes.Name, "The work item link type's space should have the name 'test-space'.")
 }
 
+// Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
+func (s *workItemLinkTypeSuite) TestUpdateWorkItemLinkTypePartially() {
+	s.T().Skip("skipped because Work Item Link Type Create/Update/Delete endpoints are disabled")
+	// given
+	createPayload := s.createDemoLinkType(s.linkTypeName)
+	description := "some description"
+	createPayload.Data.Attributes.Description = &description
+	_, workItemLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *createPayload.Data.Relationships.Space.Data.ID, false, createPayload)
+	require.NotNil(s.T(), workItemLinkType)
+	// only send the forward name
+	forwardName := "new forward name"
+	updateLinkTypePayload := &app.UpdateWorkItemLinkTypePayload{
+		Data: &app.WorkItemLinkTypeData{
+			ID:   workItemLinkType.Data.ID,
+			Type: workItemLinkType.Data.Type,
+			Attributes: &app.WorkItemLinkTypeAttributes{
+				ForwardName: &forwardName,
+				Version:     workItemLinkType.Data.Attributes.Version,
+			},
+			Relationships: &app.WorkItemLinkTypeRelationships{
+				Space: workItemLinkType.Data.Relationships.Space,
+			},
+		},
+	}
+	// when
+	_, lt := test.UpdateWorkItemLinkTypeOK(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *workItemLinkType.Data.Relationships.Space.Data.ID, *workItemLinkType.Data.ID, nil, updateLinkTypePayload)
+	// then
+	require.Equal(s.T(), forwardName, *lt.Data.Attributes.ForwardName)
+	require.Equal(s.T(), description, *lt.Data.Attributes.Description)
+	require.Equal(s.T(), *workItemLinkType.Data.Attributes.ReverseName, *lt.Data.Attributes.ReverseName)
+	require.Equal(s.T(), *workItemLinkType.Data.Attributes.Version+1, *lt.Data.Attributes.Version)
+}
+
 // Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
 func (s *workItemLinkTypeSuite) TestUpdateWorkItemLinkTypeConflict() {
 	s.T().Skip("skipped because Work Item Link Type Create/Update/Delete endpoints are disabled")
 	// given
 	createPayload := s.createDemoLinkType(s.linkTypeName)
-	_, workItemLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *createPayload.Data.Relationships.Space.Data.ID, createPayload)
+	_, workItemLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *createPayload.Data.Relationships.Space.Data.ID, false, createPayload)
 	require.NotNil(s.T(), workItemLinkType)
 	// Specify new description for link type that we just created
 	// Wrap data portion in an update payload instead of a create payload
@@ -260,7 +472,25 @@ func (s *workItemLinkTypeSuite) TestUpdateWorkItemLinkTypeConflict() {
 	version := 123456
 	updateLinkTypePayload.Data.Attributes.Version = &version
 	// when/then
-	test.UpdateWorkItemLinkTypeConflict(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *updateLinkTypePayload.Data.Relationships.Space.Data.ID, *updateLinkTypePayload.Data.ID, updateLinkTypePayload)
+	test.UpdateWorkItemLinkTypeConflict(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *updateLinkTypePayload.Data.Relationships.Space.Data.ID, *updateLinkTypePayload.Data.ID, nil, updateLinkTypePayload)
+}
+
+// Currently not used. Disabled as part of https://github.com/fabric8-services/fabric8-wit/issues/1299
+func (s *workItemLinkTypeSuite) TestUpdateWorkItemLinkTypePreconditionFailed() {
+	s.T().Skip("skipped because Work Item Link Type Create/Update/Delete endpoints are disabled")
+	// given
+	createPayload := s.createDemoLinkType(s.linkTypeName)
+	_, workItemLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *createPayload.Data.Relationships.Space.Data.ID, false, createPayload)
+	require.NotNil(s.T(), workItemLinkType)
+	updateLinkTypePayload := &app.UpdateWorkItemLinkTypePayload{
+		Data: workItemLinkType.Data,
+	}
+	newDescription := "Lalala this is a new description for the work item type"
+	updateLinkTypePayload.Data.Attributes.Description = &newDescription
+	// the ETag takes precedence over the (matching) version in the payload
+	ifMatch := "not-the-current-etag"
+	// when/then
+	test.UpdateWorkItemLinkTypePreconditionFailed(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *updateLinkTypePayload.Data.Relationships.Space.Data.ID, *updateLinkTypePayload.Data.ID, &ifMatch, updateLinkTypePayload)
 }
 
 // func (s *workItemLinkTypeSuite) TestUpdateWorkItemLinkTypeBadRequest() {
@@ -326,7 +556,8 @@ func (s *workItemLinkTypeSuite) TestShowWorkItemLinkTypeOK() {
 	// given
 	createdWorkItemLinkType := s.createWorkItemLinkType()
 	// when
-	res, readWorkItemLinkType := test.ShowWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, *createdWorkItemLinkType.Data.ID, nil, nil)
+	include := "link_category,space"
+	res, readWorkItemLinkType := test.ShowWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, *createdWorkItemLinkType.Data.ID, nil, &include, false, nil, nil)
 	// then
 	assertWorkItemLinkType(s.T(), createdWorkItemLinkType, s.spaceName, s.categoryName, readWorkItemLinkType)
 	assertResponseHeaders(s.T(), res)
@@ -337,7 +568,8 @@ func (s *workItemLinkTypeSuite) TestShowWorkItemLinkTypeOKUsingExpiredIfModified
 	createdWorkItemLinkType := s.createWorkItemLinkType()
 	// when
 	ifModifiedSinceHeader := app.ToHTTPTime(createdWorkItemLinkType.Data.Attributes.UpdatedAt.Add(-1 * time.Hour))
-	res, readWorkItemLinkType := test.ShowWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, *createdWorkItemLinkType.Data.ID, &ifModifiedSinceHeader, nil)
+	include := "link_category,space"
+	res, readWorkItemLinkType := test.ShowWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, *createdWorkItemLinkType.Data.ID, nil, &include, false, &ifModifiedSinceHeader, nil)
 	// then
 	assertWorkItemLinkType(s.T(), createdWorkItemLinkType, s.spaceName, s.categoryName, readWorkItemLinkType)
 	assertResponseHeaders(s.T(), res)
@@ -348,7 +580,8 @@ func (s *workItemLinkTypeSuite) TestShowWorkItemLinkTypeOKUsingExpiredIfNoneMatc
 	createdWorkItemLinkType := s.createWorkItemLinkType()
 	// when
 	ifNoneMatch := "foo"
-	res, readWorkItemLinkType := test.ShowWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, *createdWorkItemLinkType.Data.ID, nil, &ifNoneMatch)
+	include := "link_category,space"
+	res, readWorkItemLinkType := test.ShowWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, *createdWorkItemLinkType.Data.ID, nil, &include, false, nil, &ifNoneMatch)
 	// then
 	assertWorkItemLinkType(s.T(), createdWorkItemLinkType, s.spaceName, s.categoryName, readWorkItemLinkType)
 	assertResponseHeaders(s.T(), res)
@@ -358,7 +591,7 @@ func (s *workItemLinkTypeSuite) TestShowWorkItemLinkTypeNotModifiedUsingIfModifi
 	createdWorkItemLinkType := s.createWorkItemLinkType()
 	// when
 	ifModifiedSinceHeader := app.ToHTTPTime(*createdWorkItemLinkType.Data.Attributes.UpdatedAt)
-	res := test.ShowWorkItemLinkTypeNotModified(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, *createdWorkItemLinkType.Data.ID, &ifModifiedSinceHeader, nil)
+	res := test.ShowWorkItemLinkTypeNotModified(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, *createdWorkItemLinkType.Data.ID, nil, nil, false, &ifModifiedSinceHeader, nil)
 	// then
 	assertResponseHeaders(s.T(), res)
 }
@@ -370,14 +603,26 @@ func (s *workItemLinkTypeSuite) TestShowWorkItemLinkTypeNotModifiedUsingIfNoneMa
 	createdWorkItemLinkTypeModel, err := ConvertWorkItemLinkTypeToModel(*createdWorkItemLinkType)
 	require.NoError(s.T(), err)
 	ifNoneMatch := app.GenerateEntityTag(createdWorkItemLinkTypeModel)
-	res := test.ShowWorkItemLinkTypeNotModified(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, *createdWorkItemLinkType.Data.ID, nil, &ifNoneMatch)
+	res := test.ShowWorkItemLinkTypeNotModified(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, *createdWorkItemLinkType.Data.ID, nil, nil, false, nil, &ifNoneMatch)
 	// then
 	assertResponseHeaders(s.T(), res)
 }
 
+func (s *workItemLinkTypeSuite) TestShowWorkItemLinkTypeOKWithUsageUsingIfNoneMatchHeader() {
+	// given
+	createdWorkItemLinkType := s.createWorkItemLinkType()
+	createdWorkItemLinkTypeModel, err := ConvertWorkItemLinkTypeToModel(*createdWorkItemLinkType)
+	require.NoError(s.T(), err)
+	ifNoneMatch := app.GenerateEntityTag(createdWorkItemLinkTypeModel)
+	// when
+	_, readWorkItemLinkType := test.ShowWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, *createdWorkItemLinkType.Data.ID, nil, nil, true, nil, &ifNoneMatch)
+	// then the usage count is returned even though the link type is unchanged
+	require.NotNil(s.T(), readWorkItemLinkType.Data.Attributes.UsageCount)
+}
+
 // TestShowWorkItemLinkTypeNotFound tests if we can fetch a non existing work item link type
 func (s *workItemLinkTypeSuite) TestShowWorkItemLinkTypeNotFound() {
-	test.ShowWorkItemLinkTypeNotFound(s.T(), nil, nil, s.linkTypeCtrl, space.SystemSpace, uuid.NewV4(), nil, nil)
+	test.ShowWorkItemLinkTypeNotFound(s.T(), nil, nil, s.linkTypeCtrl, space.SystemSpace, uuid.NewV4(), nil, nil, false, nil, nil)
 }
 func (s *workItemLinkTypeSuite) createWorkItemLinkTypes() (*app.WorkItemTypeSingle, *app.WorkItemLinkTypeSingle) {
 	bugBlockerPayload := s.createDemoLinkType(s.linkTypeName)
@@ -429,18 +674,215 @@ func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeOK() {
 	// given
 	_, createdWorkItemLinkType := s.createWorkItemLinkTypes()
 	// when fetching all work item link type in a give space
-	res, linkTypes := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, nil, nil)
+	include := "link_category,space"
+	res, linkTypes := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, nil, nil, nil, nil, nil, &include, false, false, false, nil, nil, nil, nil, nil)
 	// then
 	assertWorkItemLinkTypes(s.T(), s.spaceName, s.categoryName, s.linkTypeName, s.linkName, linkTypes)
 	assertResponseHeaders(s.T(), res)
 }
 
+func (s *workItemLinkTypeSuite) TestCountWorkItemLinkTypeOK() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.WorkItemLinkTypes(3))
+	_, linkTypes := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, false, false, true, nil, nil, nil, nil, nil)
+	// when
+	_, res := test.CountWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, fxt.Spaces[0].ID)
+	// then
+	require.NotNil(s.T(), res.Meta)
+	require.Equal(s.T(), linkTypes.Meta.TotalCount, res.Meta.TotalCount)
+}
+
+func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeOKOmitIncluded() {
+	// given
+	_, createdWorkItemLinkType := s.createWorkItemLinkTypes()
+	// when fetching all work item link type in a give space without the included resources
+	_, linkTypes := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, nil, nil, nil, nil, nil, nil, false, false, true, nil, nil, nil, nil, nil)
+	// then
+	require.NotNil(s.T(), linkTypes)
+	require.NotEmpty(s.T(), linkTypes.Data)
+	require.Empty(s.T(), linkTypes.Included)
+	for _, linkTypeData := range linkTypes.Data {
+		require.NotNil(s.T(), linkTypeData.Relationships.LinkCategory.Data.ID)
+		require.NotNil(s.T(), linkTypeData.Relationships.Space.Data.ID)
+		require.NotNil(s.T(), linkTypeData.Links)
+	}
+}
+
+func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeOKPaged() {
+	// given five link types created by the same identity
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(1),
+		tf.WorkItemLinkTypes(5, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].CreatedBy = &fxt.Identities[0].ID
+			return nil
+		}),
+	)
+	spaceID := fxt.Spaces[0].ID
+	createdBy := fxt.Identities[0].ID
+	s.T().Run("first page", func(t *testing.T) {
+		// when
+		limit := 2
+		_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, &createdBy, nil, nil, nil, nil, false, false, false, &limit, nil, nil, nil, nil)
+		// then
+		require.Len(t, res.Data, 2)
+		require.Equal(t, 5, res.Meta.TotalCount)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].ID, *res.Data[0].ID)
+		require.NotNil(t, res.Links.First)
+		require.NotNil(t, res.Links.Next)
+		require.NotNil(t, res.Links.Last)
+		require.Nil(t, res.Links.Prev)
+		require.Contains(t, *res.Links.Next, "filter[createdBy]="+createdBy.String())
+	})
+	s.T().Run("last page", func(t *testing.T) {
+		// when
+		offset := "4"
+		limit := 2
+		_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, &createdBy, nil, nil, nil, nil, false, false, false, &limit, &offset, nil, nil, nil)
+		// then
+		require.Len(t, res.Data, 1)
+		require.Equal(t, 5, res.Meta.TotalCount)
+		require.Equal(t, fxt.WorkItemLinkTypes[4].ID, *res.Data[0].ID)
+		require.NotNil(t, res.Links.Prev)
+		require.Nil(t, res.Links.Next)
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeFilteredByTopology() {
+	// given a tree and a network link type
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(1),
+		tf.WorkItemLinkTypes(2, tf.SetTopologies(link.TopologyTree, link.TopologyNetwork), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].CreatedBy = &fxt.Identities[0].ID
+			return nil
+		}),
+	)
+	createdBy := fxt.Identities[0].ID
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		topology := link.TopologyTree.String()
+		_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, fxt.Spaces[0].ID, nil, &createdBy, nil, &topology, nil, nil, false, false, false, nil, nil, nil, nil, nil)
+		// then
+		require.Len(t, res.Data, 1)
+		require.Equal(t, 1, res.Meta.TotalCount)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].ID, *res.Data[0].ID)
+	})
+	s.T().Run("unknown topology", func(t *testing.T) {
+		// when/then
+		topology := "foo"
+		test.ListWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, fxt.Spaces[0].ID, nil, &createdBy, nil, &topology, nil, nil, false, false, false, nil, nil, nil, nil, nil)
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeFilteredByLinkCategory() {
+	// given two link types in different link categories
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItemLinkCategories(2),
+		tf.WorkItemLinkTypes(2, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].LinkCategoryID = fxt.WorkItemLinkCategories[idx].ID
+			return nil
+		}),
+	)
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		include := "link_category,space"
+		_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, fxt.Spaces[0].ID, nil, nil, &fxt.WorkItemLinkCategories[0].ID, nil, nil, &include, false, false, false, nil, nil, nil, nil, nil)
+		// then
+		require.Len(t, res.Data, 1)
+		require.Equal(t, 1, res.Meta.TotalCount)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].ID, *res.Data[0].ID)
+		require.Len(t, res.Included, 2, "only the filtered category and the space should be included")
+	})
+	s.T().Run("unknown category", func(t *testing.T) {
+		// when
+		unknownID := uuid.NewV4()
+		_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, fxt.Spaces[0].ID, nil, nil, &unknownID, nil, nil, nil, false, false, false, nil, nil, nil, nil, nil)
+		// then
+		require.Empty(t, res.Data)
+		require.Equal(t, 0, res.Meta.TotalCount)
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeByIDs() {
+	// given three link types of the first space and one of the second space
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.Spaces(2), tf.WorkItemLinkTypes(4, func(fxt *tf.TestFixture, idx int) error {
+		if idx == 3 {
+			fxt.WorkItemLinkTypes[idx].SpaceID = fxt.Spaces[1].ID
+		}
+		return nil
+	}))
+	spaceID := fxt.Spaces[0].ID
+	ids := fxt.WorkItemLinkTypes[2].ID.String() + "," + fxt.WorkItemLinkTypes[0].ID.String()
+
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &ids, nil, false, false, false, nil, nil, nil, nil, nil)
+		// then the link types are returned in the requested order
+		require.Len(t, res.Data, 2)
+		require.Equal(t, 2, res.Meta.TotalCount)
+		require.Equal(t, fxt.WorkItemLinkTypes[2].ID, *res.Data[0].ID)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].ID, *res.Data[1].ID)
+	})
+	s.T().Run("not modified", func(t *testing.T) {
+		// given
+		res, _ := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &ids, nil, false, false, false, nil, nil, nil, nil, nil)
+		ifNoneMatch := res.Header()[app.ETag][0]
+		// when/then
+		test.ListWorkItemLinkTypeNotModified(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &ids, nil, false, false, false, nil, nil, nil, nil, &ifNoneMatch)
+	})
+	s.T().Run("modified", func(t *testing.T) {
+		// given
+		res, _ := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &ids, nil, false, false, false, nil, nil, nil, nil, nil)
+		ifNoneMatch := res.Header()[app.ETag][0]
+		linkType := *fxt.WorkItemLinkTypes[0]
+		linkType.Name = "renamed " + linkType.Name
+		_, err := s.appDB.WorkItemLinkTypes().Save(s.Ctx, linkType)
+		require.NoError(t, err)
+		// when
+		res, _ = test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &ids, nil, false, false, false, nil, nil, nil, nil, &ifNoneMatch)
+		// then
+		require.NotEqual(t, ifNoneMatch, res.Header()[app.ETag][0])
+	})
+	s.T().Run(http.StatusText(http.StatusBadRequest), func(t *testing.T) {
+		invalid := fxt.WorkItemLinkTypes[0].ID.String() + ",foo"
+		test.ListWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &invalid, nil, false, false, false, nil, nil, nil, nil, nil)
+	})
+	s.T().Run(http.StatusText(http.StatusNotFound), func(t *testing.T) {
+		unknown := fxt.WorkItemLinkTypes[0].ID.String() + "," + uuid.NewV4().String()
+		test.ListWorkItemLinkTypeNotFound(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &unknown, nil, false, false, false, nil, nil, nil, nil, nil)
+	})
+	s.T().Run("link type of another space", func(t *testing.T) {
+		otherSpace := fxt.WorkItemLinkTypes[0].ID.String() + "," + fxt.WorkItemLinkTypes[3].ID.String()
+		test.ListWorkItemLinkTypeNotFound(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &otherSpace, nil, false, false, false, nil, nil, nil, nil, nil)
+	})
+	s.T().Run("link type of the system space", func(t *testing.T) {
+		systemIDs := fxt.WorkItemLinkTypes[0].ID.String() + "," + link.SystemWorkItemLinkTypeBugBlockerID.String()
+		_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &systemIDs, nil, false, false, false, nil, nil, nil, nil, nil)
+		require.Len(t, res.Data, 2)
+		require.Equal(t, link.SystemWorkItemLinkTypeBugBlockerID, *res.Data[1].ID)
+	})
+	s.T().Run("combined with filters or paging", func(t *testing.T) {
+		createdBy := fxt.Identities[0].ID
+		topology := string(link.TopologyNetwork)
+		limit := 1
+		offset := "0"
+		sort := "name"
+		test.ListWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, spaceID, nil, &createdBy, nil, nil, &ids, nil, false, false, false, nil, nil, nil, nil, nil)
+		test.ListWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, &fxt.WorkItemLinkCategories[0].ID, nil, &ids, nil, false, false, false, nil, nil, nil, nil, nil)
+		test.ListWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, &topology, &ids, nil, false, false, false, nil, nil, nil, nil, nil)
+		test.ListWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &ids, nil, true, false, false, nil, nil, nil, nil, nil)
+		test.ListWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &ids, nil, false, false, false, &limit, nil, nil, nil, nil)
+		test.ListWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &ids, nil, false, false, false, nil, &offset, nil, nil, nil)
+		test.ListWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, &ids, nil, false, false, false, nil, nil, &sort, nil, nil)
+	})
+}
+
 func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeOKUsingExpiredIfModifiedSinceHeader() {
 	// given
 	_, createdWorkItemLinkType := s.createWorkItemLinkTypes()
 	// when fetching all work item link type in a give space
 	ifModifiedSinceHeader := app.ToHTTPTime(createdWorkItemLinkType.Data.Attributes.UpdatedAt.Add(-1 * time.Hour))
-	res, linkTypes := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, &ifModifiedSinceHeader, nil)
+	include := "link_category,space"
+	res, linkTypes := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, nil, nil, nil, nil, nil, &include, false, false, false, nil, nil, nil, &ifModifiedSinceHeader, nil)
 	// then
 	assertWorkItemLinkTypes(s.T(), s.spaceName, s.categoryName, s.linkTypeName, s.linkName, linkTypes)
 	assertResponseHeaders(s.T(), res)
@@ -451,7 +893,8 @@ func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeOKUsingExpiredIfNoneMatc
 	_, createdWorkItemLinkType := s.createWorkItemLinkTypes()
 	// when fetching all work item link type in a give space
 	ifNoneMatch := "foo"
-	res, linkTypes := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, nil, &ifNoneMatch)
+	include := "link_category,space"
+	res, linkTypes := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, nil, nil, nil, nil, nil, &include, false, false, false, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	assertWorkItemLinkTypes(s.T(), s.spaceName, s.categoryName, s.linkTypeName, s.linkName, linkTypes)
 	assertResponseHeaders(s.T(), res)
@@ -462,7 +905,7 @@ func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeNotModifiedUsingIfModifi
 	_, workItemLinkType := s.createWorkItemLinkTypes()
 	// when fetching all work item link type in a give space
 	ifModifiedSinceHeader := app.ToHTTPTime(*workItemLinkType.Data.Attributes.UpdatedAt)
-	res := test.ListWorkItemLinkTypeNotModified(s.T(), nil, nil, s.linkTypeCtrl, *workItemLinkType.Data.Relationships.Space.Data.ID, &ifModifiedSinceHeader, nil)
+	res := test.ListWorkItemLinkTypeNotModified(s.T(), nil, nil, s.linkTypeCtrl, *workItemLinkType.Data.Relationships.Space.Data.ID, nil, nil, nil, nil, nil, nil, false, false, false, nil, nil, nil, &ifModifiedSinceHeader, nil)
 	// then
 	assertResponseHeaders(s.T(), res)
 }
@@ -470,24 +913,70 @@ func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeNotModifiedUsingIfModifi
 func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeNotModifiedUsingIfNoneMatchHeader() {
 	// given
 	_, createdWorkItemLinkType := s.createWorkItemLinkTypes()
-	_, existingLinkTypes := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, nil, nil)
+	res, _ := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, nil, nil, nil, nil, nil, nil, false, false, false, nil, nil, nil, nil, nil)
 	// when fetching all work item link type in a give space
-	createdWorkItemLinkTypeModels := make([]app.ConditionalRequestEntity, len(existingLinkTypes.Data))
-	for i, linkTypeData := range existingLinkTypes.Data {
-		createdWorkItemLinkTypeModel, err := ConvertWorkItemLinkTypeToModel(
-			app.WorkItemLinkTypeSingle{
-				Data: linkTypeData,
-			},
-		)
-		require.NoError(s.T(), err)
-		createdWorkItemLinkTypeModels[i] = *createdWorkItemLinkTypeModel
-	}
-	ifNoneMatch := app.GenerateEntitiesTag(createdWorkItemLinkTypeModels)
-	res := test.ListWorkItemLinkTypeNotModified(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, nil, &ifNoneMatch)
+	ifNoneMatch := res.Header()[app.ETag][0]
+	res = test.ListWorkItemLinkTypeNotModified(s.T(), nil, nil, s.linkTypeCtrl, *createdWorkItemLinkType.Data.Relationships.Space.Data.ID, nil, nil, nil, nil, nil, nil, false, false, false, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	assertResponseHeaders(s.T(), res)
 }
 
+func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeOKWithUsageUsingIfNoneMatchHeader() {
+	// given
+	_, createdWorkItemLinkType := s.createWorkItemLinkTypes()
+	spaceID := *createdWorkItemLinkType.Data.Relationships.Space.Data.ID
+	res, _ := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, nil, nil, false, false, false, nil, nil, nil, nil, nil)
+	// when fetching the same list with usage counts
+	ifNoneMatch := res.Header()[app.ETag][0]
+	res, linkTypes := test.ListWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, nil, nil, false, true, false, nil, nil, nil, nil, &ifNoneMatch)
+	// then the counts are always returned
+	require.NotEmpty(s.T(), linkTypes.Data)
+	for _, lt := range linkTypes.Data {
+		require.NotNil(s.T(), lt.Attributes.UsageCount)
+	}
+}
+
+func (s *workItemLinkTypeSuite) TestListWorkItemLinkTypeETagChangesWithSpaceRevision() {
+	// given two link types in a space and a page that only shows the first
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(1),
+		tf.WorkItemLinkTypes(2, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].CreatedBy = &fxt.Identities[0].ID
+			return nil
+		}),
+	)
+	spaceID := fxt.Spaces[0].ID
+	limit := 1
+	list := func(t *testing.T) string {
+		res, linkTypes := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, &fxt.Identities[0].ID, nil, nil, nil, nil, false, false, false, &limit, nil, nil, nil, nil)
+		require.Len(t, linkTypes.Data, 1)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].ID, *linkTypes.Data[0].ID)
+		return res.Header()[app.ETag][0]
+	}
+	s.T().Run("unchanged", func(t *testing.T) {
+		require.Equal(t, list(t), list(t))
+	})
+	s.T().Run("changed by an update outside of the page", func(t *testing.T) {
+		// given
+		before := list(t)
+		// when
+		linkType := *fxt.WorkItemLinkTypes[1]
+		linkType.Description = ptr.String("changed")
+		_, err := link.NewWorkItemLinkTypeRepository(s.DB).Save(s.Ctx, linkType)
+		require.NoError(t, err)
+		// then
+		require.NotEqual(t, before, list(t))
+	})
+	s.T().Run("changed by a deletion outside of the page", func(t *testing.T) {
+		// given
+		before := list(t)
+		// when
+		require.NoError(t, link.NewWorkItemLinkTypeRepository(s.DB).Delete(s.Ctx, spaceID, fxt.WorkItemLinkTypes[1].ID))
+		// then
+		require.NotEqual(t, before, list(t))
+	})
+}
+
 func (s *workItemLinkTypeSuite) getWorkItemLinkTypeTestDataFunc() func(t *testing.T) []testSecureAPI {
 	return func(t *testing.T) []testSecureAPI {
 
@@ -633,3 +1122,933 @@ func (s *workItemLinkTypeSuite) TestUnauthorizeWorkItemLinkTypeCUD() {
 		return nil
 	})
 }
+
+func (s *workItemLinkTypeSuite) TestSetCategoryOfWorkItemLinkTypes() {
+	// given two link types of the same space and one of another space
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(2),
+		tf.Spaces(2),
+		tf.WorkItemLinkCategories(2),
+		tf.WorkItemLinkTypes(3, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].LinkCategoryID = fxt.WorkItemLinkCategories[0].ID
+			if idx == 2 {
+				fxt.WorkItemLinkTypes[idx].SpaceID = fxt.Spaces[1].ID
+			}
+			return nil
+		}),
+	)
+	spaceID := fxt.Spaces[0].ID
+	authzSrv := &TestSpaceAuthzService{*fxt.Identities[0], ""}
+	ownerSvc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+	newCategoryID := fxt.WorkItemLinkCategories[1].ID
+	payload := func(ids ...uuid.UUID) *app.SetWorkItemLinkTypesCategoryPayload {
+		p := &app.SetWorkItemLinkTypesCategoryPayload{
+			Data: make([]*app.RelationWorkItemLinkTypeData, len(ids)),
+			LinkCategory: &app.RelationWorkItemLinkCategoryData{
+				Type: link.EndpointWorkItemLinkCategories,
+				ID:   newCategoryID,
+			},
+		}
+		for i, id := range ids {
+			p.Data[i] = &app.RelationWorkItemLinkTypeData{
+				Type: link.EndpointWorkItemLinkTypes,
+				ID:   id,
+			}
+		}
+		return p
+	}
+
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		_, res := test.SetCategoryWorkItemLinkTypeOK(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, spaceID, payload(fxt.WorkItemLinkTypes[0].ID, fxt.WorkItemLinkTypes[2].ID, uuid.NewV4()))
+		// then
+		require.Len(t, res.Data, 3)
+		require.True(t, res.Data[0].Moved)
+		require.Nil(t, res.Data[0].Error)
+		require.Equal(t, newCategoryID, res.Data[0].Data.Relationships.LinkCategory.Data.ID)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].Version+1, *res.Data[0].Data.Attributes.Version)
+		for _, r := range res.Data[1:] {
+			require.False(t, r.Moved)
+			require.NotNil(t, r.Error)
+		}
+		categoryData, ok := res.Included[0].(*app.WorkItemLinkCategoryData)
+		require.True(t, ok)
+		require.Equal(t, newCategoryID, *categoryData.ID)
+		// only the first link type was moved
+		linkType, err := s.appDB.WorkItemLinkTypes().Load(s.Ctx, fxt.WorkItemLinkTypes[1].ID)
+		require.NoError(t, err)
+		require.Equal(t, fxt.WorkItemLinkCategories[0].ID, linkType.LinkCategoryID)
+		linkType, err = s.appDB.WorkItemLinkTypes().Load(s.Ctx, fxt.WorkItemLinkTypes[2].ID)
+		require.NoError(t, err)
+		require.Equal(t, fxt.WorkItemLinkCategories[0].ID, linkType.LinkCategoryID)
+	})
+
+	s.T().Run("unknown category", func(t *testing.T) {
+		p := payload(fxt.WorkItemLinkTypes[1].ID)
+		p.LinkCategory.ID = uuid.NewV4()
+		test.SetCategoryWorkItemLinkTypeBadRequest(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, spaceID, p)
+	})
+
+	s.T().Run("forbidden for others", func(t *testing.T) {
+		// when
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[1], authzSrv)
+		test.SetCategoryWorkItemLinkTypeForbidden(t, svc.Context, svc, s.linkTypeCtrl, spaceID, payload(fxt.WorkItemLinkTypes[1].ID))
+		// then
+		linkType, err := s.appDB.WorkItemLinkTypes().Load(s.Ctx, fxt.WorkItemLinkTypes[1].ID)
+		require.NoError(t, err)
+		require.Equal(t, fxt.WorkItemLinkCategories[0].ID, linkType.LinkCategoryID)
+	})
+
+	s.T().Run("unauthorized", func(t *testing.T) {
+		svc := goa.New("workItemLinkTypeSuite-Service")
+		test.SetCategoryWorkItemLinkTypeUnauthorized(t, svc.Context, svc, s.linkTypeCtrl, spaceID, payload(fxt.WorkItemLinkTypes[1].ID))
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestValidateWorkItemLinkTypes() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.WorkItemLinkTypes(1))
+	spaceID := fxt.Spaces[0].ID
+	catID := fxt.WorkItemLinkCategories[0].ID
+	valid := newCreateWorkItemLinkTypePayload("valid link type", catID, spaceID)
+	existingName := newCreateWorkItemLinkTypePayload(fxt.WorkItemLinkTypes[0].Name, catID, spaceID)
+	duplicateInBatch := newCreateWorkItemLinkTypePayload("valid link type", catID, spaceID)
+	unknownCategory := newCreateWorkItemLinkTypePayload("unknown category", uuid.NewV4(), spaceID)
+	existingForwardName := newCreateWorkItemLinkTypePayload("existing forward name", catID, spaceID)
+	existingForwardName.Data.Attributes.ForwardName = &fxt.WorkItemLinkTypes[0].ForwardName
+	reverseNameInBatch := newCreateWorkItemLinkTypePayload("reverse name in batch", catID, spaceID)
+	reverseNameInBatch.Data.Attributes.ReverseName = valid.Data.Attributes.ReverseName
+	payload := &app.ValidateWorkItemLinkTypesPayload{
+		Data: []*app.WorkItemLinkTypeData{valid.Data, existingName.Data, duplicateInBatch.Data, unknownCategory.Data, existingForwardName.Data, reverseNameInBatch.Data},
+	}
+	// when
+	_, res := test.ValidateWorkItemLinkTypeOK(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, spaceID, payload)
+	// then
+	require.Len(s.T(), res.Data, 6)
+	require.True(s.T(), res.Data[0].Valid)
+	require.Empty(s.T(), res.Data[0].Errors)
+	for _, r := range res.Data[1:] {
+		require.False(s.T(), r.Valid)
+		require.NotEmpty(s.T(), r.Errors)
+	}
+	// nothing must have been created
+	linkTypes, _, err := s.appDB.WorkItemLinkTypes().List(s.Ctx, spaceID, nil, nil, nil, false, nil, nil, nil)
+	require.NoError(s.T(), err)
+	for _, lt := range linkTypes {
+		require.NotEqual(s.T(), "valid link type", lt.Name)
+	}
+}
+
+func (s *workItemLinkTypeSuite) TestWorkItemLinkTypeUsage() {
+	// given a link of a type of the first space and a second space
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.Spaces(2), tf.WorkItemLinks(1))
+	linkTypeID := fxt.WorkItemLinkTypes[0].ID
+	now := time.Now()
+
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		_, res := test.UsageWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, fxt.Spaces[0].ID, linkTypeID, now.Add(-24*time.Hour), "day", &now)
+		// then
+		require.Len(t, res.Data, 2)
+		require.Equal(t, 1, res.Data[1].Attributes.Count)
+	})
+
+	s.T().Run("link type of another space", func(t *testing.T) {
+		test.UsageWorkItemLinkTypeNotFound(t, nil, nil, s.linkTypeCtrl, fxt.Spaces[1].ID, linkTypeID, now.Add(-24*time.Hour), "day", &now)
+	})
+
+	s.T().Run("too many buckets", func(t *testing.T) {
+		test.UsageWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, fxt.Spaces[0].ID, linkTypeID, now.AddDate(-10, 0, 0), "day", &now)
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestDefaultWorkItemLinkType() {
+	// given a space with its own link type and a link type of another space
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Spaces(2),
+		tf.WorkItemLinkTypes(2, func(fxt *tf.TestFixture, idx int) error {
+			if idx == 1 {
+				fxt.WorkItemLinkTypes[idx].SpaceID = fxt.Spaces[1].ID
+			}
+			return nil
+		}),
+	)
+	spaceID := fxt.Spaces[0].ID
+	ownerSvc := testsupport.ServiceAsUser("TestDefaultWorkItemLinkType-Service", *fxt.Identities[0])
+	payload := func(id uuid.UUID) *app.SetDefaultWorkItemLinkTypePayload {
+		return &app.SetDefaultWorkItemLinkTypePayload{
+			Data: &app.RelationWorkItemLinkTypeData{
+				Type: link.EndpointWorkItemLinkTypes,
+				ID:   id,
+			},
+		}
+	}
+
+	s.T().Run("falls back to system type", func(t *testing.T) {
+		// when
+		_, res := test.ShowDefaultWorkItemLinkTypeOK(t, s.svc.Context, s.svc, s.linkTypeCtrl, spaceID)
+		// then
+		require.Equal(t, link.SystemWorkItemLinkPlannerItemRelatedID, *res.Data.ID)
+	})
+
+	s.T().Run("set and show", func(t *testing.T) {
+		// when
+		_, res := test.SetDefaultWorkItemLinkTypeOK(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, spaceID, payload(fxt.WorkItemLinkTypes[0].ID))
+		// then
+		require.Equal(t, fxt.WorkItemLinkTypes[0].ID, *res.Data.ID)
+		_, res = test.ShowDefaultWorkItemLinkTypeOK(t, s.svc.Context, s.svc, s.linkTypeCtrl, spaceID)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].ID, *res.Data.ID)
+	})
+
+	s.T().Run("link type of another space", func(t *testing.T) {
+		test.SetDefaultWorkItemLinkTypeBadRequest(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, spaceID, payload(fxt.WorkItemLinkTypes[1].ID))
+	})
+
+	s.T().Run("unknown link type", func(t *testing.T) {
+		test.SetDefaultWorkItemLinkTypeBadRequest(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, spaceID, payload(uuid.NewV4()))
+	})
+
+	s.T().Run("not the space owner", func(t *testing.T) {
+		test.SetDefaultWorkItemLinkTypeForbidden(t, s.svc.Context, s.svc, s.linkTypeCtrl, spaceID, payload(fxt.WorkItemLinkTypes[0].ID))
+	})
+
+	s.T().Run("unknown space", func(t *testing.T) {
+		test.ShowDefaultWorkItemLinkTypeNotFound(t, s.svc.Context, s.svc, s.linkTypeCtrl, uuid.NewV4())
+	})
+
+	s.T().Run("deleted default falls back to system type", func(t *testing.T) {
+		// given a default link type that is deleted without resetting the
+		// space's default
+		test.SetDefaultWorkItemLinkTypeOK(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, spaceID, payload(fxt.WorkItemLinkTypes[0].ID))
+		err := s.appDB.WorkItemLinkTypes().Delete(s.Ctx, spaceID, fxt.WorkItemLinkTypes[0].ID)
+		require.NoError(t, err)
+		// when
+		_, res := test.ShowDefaultWorkItemLinkTypeOK(t, s.svc.Context, s.svc, s.linkTypeCtrl, spaceID)
+		// then
+		require.Equal(t, link.SystemWorkItemLinkPlannerItemRelatedID, *res.Data.ID)
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestConsolidateWorkItemLinkTypes() {
+	// given two link types with the same name in different categories
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItemLinkCategories(2),
+		tf.WorkItemLinkTypes(2, tf.SetWorkItemLinkTypeNames("dup", "dup"), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].LinkCategoryID = fxt.WorkItemLinkCategories[idx].ID
+			return nil
+		}),
+	)
+	spaceID := fxt.Spaces[0].ID
+	ownerSvc := testsupport.ServiceAsUser("TestConsolidateWorkItemLinkTypes-Service", *fxt.Identities[0])
+
+	s.T().Run("not the space owner", func(t *testing.T) {
+		test.ConsolidateWorkItemLinkTypeForbidden(t, s.svc.Context, s.svc, s.linkTypeCtrl, spaceID, false)
+	})
+
+	s.T().Run("dry-run", func(t *testing.T) {
+		// when
+		_, res := test.ConsolidateWorkItemLinkTypeOK(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, spaceID, true)
+		// then
+		require.Len(t, res.Data, 1)
+		require.Equal(t, "dup", res.Data[0].Name)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].ID, res.Data[0].Kept)
+		require.Equal(t, []uuid.UUID{fxt.WorkItemLinkTypes[1].ID}, res.Data[0].Removed)
+		// nothing was changed
+		_, err := s.appDB.WorkItemLinkTypes().Load(s.Ctx, fxt.WorkItemLinkTypes[1].ID)
+		require.NoError(t, err)
+	})
+
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		_, res := test.ConsolidateWorkItemLinkTypeOK(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, spaceID, false)
+		// then
+		require.Len(t, res.Data, 1)
+		_, err := s.appDB.WorkItemLinkTypes().Load(s.Ctx, fxt.WorkItemLinkTypes[1].ID)
+		require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestCreateWorkItemLinkTypesWithCategory() {
+	// given a space owned by the first identity with the third identity as a
+	// collaborator
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.Identities(3), tf.Spaces(1))
+	spaceID := fxt.Spaces[0].ID
+	authzSrv := &TestSpaceAuthzService{*fxt.Identities[0], fxt.Identities[2].ID.String()}
+	newPayload := func(categoryName string, linkTypeNames ...string) *app.CreateWorkItemLinkTypesWithCategoryPayload {
+		payload := &app.CreateWorkItemLinkTypesWithCategoryPayload{
+			Category: newCreateWorkItemLinkCategoryPayload(categoryName).Data,
+		}
+		for _, name := range linkTypeNames {
+			payload.Data = append(payload.Data, newCreateWorkItemLinkTypePayload(name, uuid.Nil, spaceID).Data)
+		}
+		return payload
+	}
+	categoryExists := func(t *testing.T, name string) bool {
+		categories, err := s.appDB.WorkItemLinkCategories().List(s.Ctx)
+		require.NoError(t, err)
+		for _, cat := range categories {
+			if cat.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		categoryName := testsupport.CreateRandomValidTestName("new category ")
+		payload := newPayload(categoryName, "first", "second")
+		// when
+		_, res := test.CreateWithCategoryWorkItemLinkTypeCreated(t, svc.Context, svc, s.linkTypeCtrl, spaceID, payload)
+		// then
+		require.Len(t, res.Data, 2)
+		require.Equal(t, "first", *res.Data[0].Attributes.Name)
+		require.Equal(t, "second", *res.Data[1].Attributes.Name)
+		categoryID := res.Data[0].Relationships.LinkCategory.Data.ID
+		require.Equal(t, categoryID, res.Data[1].Relationships.LinkCategory.Data.ID)
+		require.Equal(t, spaceID, *res.Data[0].Relationships.Space.Data.ID)
+		var includedCategory *app.WorkItemLinkCategoryData
+		for _, included := range res.Included {
+			if cat, ok := included.(*app.WorkItemLinkCategoryData); ok {
+				includedCategory = cat
+			}
+		}
+		require.NotNil(t, includedCategory, "the new category must be included")
+		require.Equal(t, categoryID, *includedCategory.ID)
+		require.Equal(t, categoryName, *includedCategory.Attributes.Name)
+	})
+
+	s.T().Run("everything is rolled back on failure", func(t *testing.T) {
+		// given two link types with the same forward and reverse names
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		categoryName := testsupport.CreateRandomValidTestName("rolled back category ")
+		payload := newPayload(categoryName, "dup", "dup")
+		// when
+		test.CreateWithCategoryWorkItemLinkTypeConflict(t, svc.Context, svc, s.linkTypeCtrl, spaceID, payload)
+		// then neither the category nor a link type was created
+		require.False(t, categoryExists(t, categoryName))
+		linkTypes, _, err := s.appDB.WorkItemLinkTypes().List(s.Ctx, spaceID, nil, nil, nil, false, nil, nil, nil)
+		require.NoError(t, err)
+		for _, lt := range linkTypes {
+			require.NotEqual(t, "dup", lt.Name)
+		}
+	})
+
+	s.T().Run("bad request due to invalid link type", func(t *testing.T) {
+		// given
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		categoryName := testsupport.CreateRandomValidTestName("invalid category ")
+		payload := newPayload(categoryName, "valid", "invalid")
+		wrongTopology := "wrongtopology"
+		payload.Data[1].Attributes.Topology = &wrongTopology
+		// when
+		test.CreateWithCategoryWorkItemLinkTypeBadRequest(t, svc.Context, svc, s.linkTypeCtrl, spaceID, payload)
+		// then
+		require.False(t, categoryExists(t, categoryName))
+	})
+
+	s.T().Run("forbidden for others", func(t *testing.T) {
+		// given
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[1], authzSrv)
+		categoryName := testsupport.CreateRandomValidTestName("forbidden category ")
+		// when
+		test.CreateWithCategoryWorkItemLinkTypeForbidden(t, svc.Context, svc, s.linkTypeCtrl, spaceID, newPayload(categoryName, "forbidden"))
+		// then
+		require.False(t, categoryExists(t, categoryName))
+	})
+
+	s.T().Run("forbidden for collaborators", func(t *testing.T) {
+		// given
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[2], authzSrv)
+		categoryName := testsupport.CreateRandomValidTestName("collaborator category ")
+		// when
+		test.CreateWithCategoryWorkItemLinkTypeForbidden(t, svc.Context, svc, s.linkTypeCtrl, spaceID, newPayload(categoryName, "collaborator"))
+		// then
+		require.False(t, categoryExists(t, categoryName))
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestDeleteAllWorkItemLinkTypes() {
+	s.T().Run("not the space owner", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinkTypes(2))
+		// when
+		test.DeleteAllWorkItemLinkTypeForbidden(t, s.svc.Context, s.svc, s.linkTypeCtrl, fxt.Spaces[0].ID, false)
+		// then nothing was deleted
+		_, err := s.appDB.WorkItemLinkTypes().Load(s.Ctx, fxt.WorkItemLinkTypes[0].ID)
+		require.NoError(t, err)
+	})
+
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinkTypes(2))
+		ownerSvc := testsupport.ServiceAsUser("TestDeleteAllWorkItemLinkTypes-Service", *fxt.Identities[0])
+		// when
+		_, res := test.DeleteAllWorkItemLinkTypeOK(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, fxt.Spaces[0].ID, false)
+		// then
+		require.Equal(t, 2, res.Meta.TotalCount)
+		for _, lt := range fxt.WorkItemLinkTypes {
+			_, err := s.appDB.WorkItemLinkTypes().Load(s.Ctx, lt.ID)
+			require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+		}
+		// the system link types are kept
+		_, err := s.appDB.WorkItemLinkTypes().Load(s.Ctx, link.SystemWorkItemLinkTypeBugBlockerID)
+		require.NoError(t, err)
+	})
+
+	s.T().Run("default link type is reset", func(t *testing.T) {
+		// given a space whose default link type is one of its own
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinkTypes(1))
+		spaceID := fxt.Spaces[0].ID
+		ownerSvc := testsupport.ServiceAsUser("TestDeleteAllWorkItemLinkTypes-Service", *fxt.Identities[0])
+		test.SetDefaultWorkItemLinkTypeOK(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, spaceID, &app.SetDefaultWorkItemLinkTypePayload{
+			Data: &app.RelationWorkItemLinkTypeData{
+				Type: link.EndpointWorkItemLinkTypes,
+				ID:   fxt.WorkItemLinkTypes[0].ID,
+			},
+		})
+		// when
+		test.DeleteAllWorkItemLinkTypeOK(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, spaceID, false)
+		// then
+		sp, err := s.appDB.Spaces().Load(s.Ctx, spaceID)
+		require.NoError(t, err)
+		require.Nil(t, sp.DefaultLinkTypeID)
+	})
+
+	s.T().Run("in use", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(1))
+		ownerSvc := testsupport.ServiceAsUser("TestDeleteAllWorkItemLinkTypes-Service", *fxt.Identities[0])
+		t.Run("conflict without force", func(t *testing.T) {
+			// when
+			test.DeleteAllWorkItemLinkTypeConflict(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, fxt.Spaces[0].ID, false)
+			// then nothing was deleted
+			_, err := s.appDB.WorkItemLinkTypes().Load(s.Ctx, fxt.WorkItemLinkTypes[0].ID)
+			require.NoError(t, err)
+			_, err = s.appDB.WorkItemLinks().Load(s.Ctx, fxt.WorkItemLinks[0].ID)
+			require.NoError(t, err)
+		})
+		t.Run("ok with force", func(t *testing.T) {
+			// when
+			_, res := test.DeleteAllWorkItemLinkTypeOK(t, ownerSvc.Context, ownerSvc, s.linkTypeCtrl, fxt.Spaces[0].ID, true)
+			// then
+			require.Equal(t, 1, res.Meta.TotalCount)
+			_, err := s.appDB.WorkItemLinks().Load(s.Ctx, fxt.WorkItemLinks[0].ID)
+			require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+		})
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestResolvedLinkCategoryRelationship() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.WorkItemLinkTypes(1))
+	spaceID := fxt.Spaces[0].ID
+	category := fxt.WorkItemLinkCategories[0]
+	// the list also contains the link types of the system space
+	findLinkType := func(t *testing.T, list *app.WorkItemLinkTypeList, id uuid.UUID) *app.WorkItemLinkTypeData {
+		for _, lt := range list.Data {
+			if *lt.ID == id {
+				return lt
+			}
+		}
+		require.Fail(t, "link type not found in list", "%s", id)
+		return nil
+	}
+	requireResolved := func(t *testing.T, rel *app.RelationWorkItemLinkCategory) {
+		require.NotNil(t, rel.Links, "links must be kept for full navigation")
+		require.Equal(t, true, rel.Meta["resolved"])
+		require.Equal(t, category.Name, rel.Meta["name"])
+	}
+	include := "link_category"
+	s.T().Run("show", func(t *testing.T) {
+		// when
+		_, res := test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, fxt.WorkItemLinkTypes[0].ID, nil, &include, false, nil, nil)
+		// then
+		requireResolved(t, res.Data.Relationships.LinkCategory)
+	})
+	s.T().Run("list", func(t *testing.T) {
+		// when
+		_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, nil, &include, false, false, false, nil, nil, nil, nil, nil)
+		// then
+		lt := findLinkType(t, res, fxt.WorkItemLinkTypes[0].ID)
+		requireResolved(t, lt.Relationships.LinkCategory)
+	})
+	s.T().Run("list without included", func(t *testing.T) {
+		// when
+		_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, nil, &include, false, false, true, nil, nil, nil, nil, nil)
+		// then
+		lt := findLinkType(t, res, fxt.WorkItemLinkTypes[0].ID)
+		require.Nil(t, lt.Relationships.LinkCategory.Meta)
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestShowWorkItemLinkTypeWithUsage() {
+	// given a link type that is used by two links
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinkTypes(1, tf.SetWorkItemLinkTypeNames("used")),
+		tf.WorkItemLinksCustom(2, tf.BuildLinks(tf.L("A", "B", "used"), tf.L("A", "C", "used"))),
+	)
+	s.T().Run("with usage", func(t *testing.T) {
+		// when
+		_, res := test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID, nil, nil, true, nil, nil)
+		// then
+		require.NotNil(t, res.Data.Attributes.UsageCount)
+		require.Equal(t, 2, *res.Data.Attributes.UsageCount)
+	})
+	s.T().Run("without usage", func(t *testing.T) {
+		// when
+		_, res := test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID, nil, nil, false, nil, nil)
+		// then
+		require.Nil(t, res.Data.Attributes.UsageCount)
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestRestoreWorkItemLinkType() {
+	// given a deleted link type in a space of the first identity
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(2),
+		tf.WorkItemLinkTypes(1),
+	)
+	spaceID := fxt.Spaces[0].ID
+	require.NoError(s.T(), application.Transactional(s.appDB, func(appl application.Application) error {
+		return appl.WorkItemLinkTypes().Delete(s.Ctx, spaceID, fxt.WorkItemLinkTypes[0].ID)
+	}))
+	authzSrv := &TestSpaceAuthzService{*fxt.Identities[0], ""}
+	s.T().Run("forbidden for others", func(t *testing.T) {
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[1], authzSrv)
+		test.RestoreWorkItemLinkTypeForbidden(t, svc.Context, svc, s.linkTypeCtrl, spaceID, fxt.WorkItemLinkTypes[0].ID)
+	})
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		_, res := test.RestoreWorkItemLinkTypeOK(t, svc.Context, svc, s.linkTypeCtrl, spaceID, fxt.WorkItemLinkTypes[0].ID)
+		// then
+		require.Equal(t, fxt.WorkItemLinkTypes[0].ID, *res.Data.ID)
+		require.Nil(t, res.Data.Attributes.DeletedAt)
+		test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, fxt.WorkItemLinkTypes[0].ID, nil, nil, false, nil, nil)
+	})
+	s.T().Run("not deleted", func(t *testing.T) {
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		test.RestoreWorkItemLinkTypeNotFound(t, svc.Context, svc, s.linkTypeCtrl, spaceID, fxt.WorkItemLinkTypes[0].ID)
+	})
+	s.T().Run("forward name reused in the meantime", func(t *testing.T) {
+		// given the link type is deleted again and its forward name is reused
+		deleted := *fxt.WorkItemLinkTypes[0]
+		require.NoError(t, application.Transactional(s.appDB, func(appl application.Application) error {
+			if err := appl.WorkItemLinkTypes().Delete(s.Ctx, spaceID, deleted.ID); err != nil {
+				return err
+			}
+			_, err := appl.WorkItemLinkTypes().Create(s.Ctx, &link.WorkItemLinkType{
+				Name:           "new " + deleted.Name,
+				Topology:       deleted.Topology,
+				ForwardName:    deleted.ForwardName,
+				ReverseName:    "new " + deleted.ReverseName,
+				LinkCategoryID: deleted.LinkCategoryID,
+				SpaceID:        spaceID,
+			})
+			return err
+		}))
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		// when/then
+		test.RestoreWorkItemLinkTypeConflict(t, svc.Context, svc, s.linkTypeCtrl, spaceID, deleted.ID)
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestSparseFieldsets() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.WorkItemLinkTypes(1))
+	spaceID := fxt.Spaces[0].ID
+	linkTypeID := fxt.WorkItemLinkTypes[0].ID
+	s.T().Run("show only attributes", func(t *testing.T) {
+		// when
+		fields := "name,topology,unknown"
+		_, res := test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, linkTypeID, &fields, nil, false, nil, nil)
+		// then
+		require.Equal(t, fxt.WorkItemLinkTypes[0].Name, *res.Data.Attributes.Name)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].Topology.String(), *res.Data.Attributes.Topology)
+		require.Nil(t, res.Data.Attributes.Description)
+		require.Nil(t, res.Data.Attributes.ForwardName)
+		require.Nil(t, res.Data.Relationships)
+		require.Empty(t, res.Included)
+	})
+	s.T().Run("show with link category", func(t *testing.T) {
+		// when
+		fields := "name,link_category"
+		include := "link_category,space"
+		_, res := test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, linkTypeID, &fields, &include, false, nil, nil)
+		// then
+		require.NotNil(t, res.Data.Relationships.LinkCategory)
+		require.Nil(t, res.Data.Relationships.Space)
+		require.Len(t, res.Included, 1)
+	})
+	s.T().Run("list only attributes", func(t *testing.T) {
+		// when
+		fields := "name,topology"
+		_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, &fields, nil, nil, nil, nil, nil, false, false, false, nil, nil, nil, nil, nil)
+		// then
+		require.NotEmpty(t, res.Data)
+		for _, data := range res.Data {
+			require.NotNil(t, data.Attributes.Name)
+			require.NotNil(t, data.Attributes.Topology)
+			require.Nil(t, data.Attributes.Description)
+			require.Nil(t, data.Relationships)
+		}
+		require.Empty(t, res.Included)
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestAuthorRelationship() {
+	// given one link type with and one without a known author
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(1),
+		tf.WorkItemLinkTypes(2, func(fxt *tf.TestFixture, idx int) error {
+			if idx == 0 {
+				fxt.WorkItemLinkTypes[idx].CreatedBy = &fxt.Identities[0].ID
+			}
+			return nil
+		}),
+	)
+	spaceID := fxt.Spaces[0].ID
+	s.T().Run("known author", func(t *testing.T) {
+		// when
+		_, res := test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, fxt.WorkItemLinkTypes[0].ID, nil, nil, false, nil, nil)
+		// then
+		require.NotNil(t, res.Data.Relationships.Author.Data)
+		require.Equal(t, fxt.Identities[0].ID.String(), *res.Data.Relationships.Author.Data.ID)
+		require.Empty(t, res.Included)
+	})
+	s.T().Run("known author included", func(t *testing.T) {
+		// when
+		include := "author"
+		_, res := test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, fxt.WorkItemLinkTypes[0].ID, nil, &include, false, nil, nil)
+		// then
+		require.Len(t, res.Included, 1)
+		author, ok := res.Included[0].(*app.UserData)
+		require.True(t, ok)
+		require.Equal(t, fxt.Identities[0].ID.String(), *author.ID)
+		require.Equal(t, fxt.Identities[0].Username, *author.Attributes.Username)
+	})
+	s.T().Run("unknown author", func(t *testing.T) {
+		// when
+		include := "author"
+		_, res := test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, fxt.WorkItemLinkTypes[1].ID, nil, &include, false, nil, nil)
+		// then
+		require.Nil(t, res.Data.Relationships.Author.Data)
+		require.Empty(t, res.Included)
+		serialized, err := json.Marshal(res.Data.Relationships.Author)
+		require.NoError(t, err)
+		require.Contains(t, string(serialized), `"data":null`)
+	})
+	s.T().Run("list with authors included", func(t *testing.T) {
+		// when
+		include := "author"
+		_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, nil, &include, false, false, false, nil, nil, nil, nil, nil)
+		// then
+		authorIDs := []string{}
+		for _, included := range res.Included {
+			if author, ok := included.(*app.UserData); ok {
+				authorIDs = append(authorIDs, *author.ID)
+			}
+		}
+		require.Contains(t, authorIDs, fxt.Identities[0].ID.String())
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestIncludeParameter() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.WorkItemLinkTypes(1))
+	spaceID := fxt.Spaces[0].ID
+	linkTypeID := fxt.WorkItemLinkTypes[0].ID
+	requireIncluded := func(t *testing.T, included []interface{}, categories, spaces int) {
+		actualCategories, actualSpaces := 0, 0
+		for _, i := range included {
+			switch i.(type) {
+			case *app.WorkItemLinkCategoryData:
+				actualCategories++
+			case *app.Space:
+				actualSpaces++
+			}
+		}
+		require.Len(t, included, categories+spaces)
+		require.Equal(t, categories, actualCategories)
+		require.Equal(t, spaces, actualSpaces)
+	}
+	testCases := []struct {
+		name       string
+		include    *string
+		categories int
+		spaces     int
+	}{
+		{"nothing by default", nil, 0, 0},
+		{"link category", ptr.String("link_category"), 1, 0},
+		{"space", ptr.String("space"), 0, 1},
+		{"link category and space", ptr.String("space, link_category"), 1, 1},
+	}
+	for _, tc := range testCases {
+		s.T().Run("show "+tc.name, func(t *testing.T) {
+			// when
+			_, res := test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, linkTypeID, nil, tc.include, false, nil, nil)
+			// then
+			requireIncluded(t, res.Included, tc.categories, tc.spaces)
+		})
+		s.T().Run("list "+tc.name, func(t *testing.T) {
+			// when
+			_, res := test.ListWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, nil, tc.include, false, false, false, nil, nil, nil, nil, nil)
+			// then
+			requireIncluded(t, res.Included, tc.categories, tc.spaces)
+		})
+	}
+	s.T().Run(http.StatusText(http.StatusBadRequest), func(t *testing.T) {
+		include := "link_category,unknown"
+		t.Run("show", func(t *testing.T) {
+			test.ShowWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, spaceID, linkTypeID, nil, &include, false, nil, nil)
+		})
+		t.Run("list", func(t *testing.T) {
+			test.ListWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, spaceID, nil, nil, nil, nil, nil, &include, false, false, false, nil, nil, nil, nil, nil)
+		})
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestCopyWorkItemLinkTypesFromSpace() {
+	// given link types "a" and "b" in the first space and a link type "b" in
+	// the second space
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(2),
+		tf.Spaces(2),
+		tf.WorkItemLinkTypes(3, tf.SetWorkItemLinkTypeNames("a", "b", "b"), func(fxt *tf.TestFixture, idx int) error {
+			if idx == 2 {
+				fxt.WorkItemLinkTypes[idx].SpaceID = fxt.Spaces[1].ID
+			}
+			return nil
+		}),
+	)
+	sourceSpaceID := fxt.Spaces[0].ID
+	targetSpaceID := fxt.Spaces[1].ID
+	authzSrv := &TestSpaceAuthzService{*fxt.Identities[0], ""}
+	s.T().Run("forbidden for others", func(t *testing.T) {
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[1], authzSrv)
+		test.CopyFromWorkItemLinkTypeForbidden(t, svc.Context, svc, s.linkTypeCtrl, targetSpaceID, sourceSpaceID)
+	})
+	s.T().Run("unknown source space", func(t *testing.T) {
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		test.CopyFromWorkItemLinkTypeNotFound(t, svc.Context, svc, s.linkTypeCtrl, targetSpaceID, uuid.NewV4())
+	})
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		_, res := test.CopyFromWorkItemLinkTypeOK(t, svc.Context, svc, s.linkTypeCtrl, targetSpaceID, sourceSpaceID)
+		// then
+		require.Len(t, res.Data, 1)
+		require.Equal(t, "a", *res.Data[0].Attributes.Name)
+		require.NotEqual(t, fxt.WorkItemLinkTypeByName("a").ID, *res.Data[0].ID)
+		require.Equal(t, targetSpaceID, *res.Data[0].Relationships.Space.Data.ID)
+		require.Equal(t, 1, res.Meta.TotalCount)
+		require.Equal(t, []string{"b"}, res.Meta.Skipped)
+		test.ShowWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, targetSpaceID, *res.Data[0].ID, nil, nil, false, nil, nil)
+	})
+	s.T().Run("copying again skips everything", func(t *testing.T) {
+		// when
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		_, res := test.CopyFromWorkItemLinkTypeOK(t, svc.Context, svc, s.linkTypeCtrl, targetSpaceID, sourceSpaceID)
+		// then
+		require.Empty(t, res.Data)
+		require.Equal(t, 0, res.Meta.TotalCount)
+		require.ElementsMatch(t, []string{"a", "b"}, res.Meta.Skipped)
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestCopyWorkItemLinkTypesWithTakenForwardName() {
+	// given link type "a" in the first space and link type "z" with the same
+	// forward name in the second space
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Spaces(2),
+		tf.WorkItemLinkTypes(2, tf.SetWorkItemLinkTypeNames("a", "z"), func(fxt *tf.TestFixture, idx int) error {
+			if idx == 1 {
+				fxt.WorkItemLinkTypes[idx].SpaceID = fxt.Spaces[1].ID
+				fxt.WorkItemLinkTypes[idx].ForwardName = fxt.WorkItemLinkTypes[0].ForwardName
+			}
+			return nil
+		}),
+	)
+	authzSrv := &TestSpaceAuthzService{*fxt.Identities[0], ""}
+	svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+	// when
+	_, res := test.CopyFromWorkItemLinkTypeOK(s.T(), svc.Context, svc, s.linkTypeCtrl, fxt.Spaces[1].ID, fxt.Spaces[0].ID)
+	// then "a" is skipped instead of failing the whole copy
+	require.Empty(s.T(), res.Data)
+	require.Equal(s.T(), []string{"a"}, res.Meta.Skipped)
+}
+
+func (s *workItemLinkTypeSuite) TestExportAndImportWorkItemLinkTypes() {
+	// given link types "a" and "b" in the first space and an empty second
+	// space with the third identity as a collaborator
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(3),
+		tf.Spaces(2),
+		tf.WorkItemLinkTypes(2, tf.SetWorkItemLinkTypeNames("a", "b")),
+	)
+	sourceSpaceID := fxt.Spaces[0].ID
+	targetSpaceID := fxt.Spaces[1].ID
+	authzSrv := &TestSpaceAuthzService{*fxt.Identities[0], fxt.Identities[2].ID.String()}
+	_, exported := test.ExportWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, sourceSpaceID)
+	require.Equal(s.T(), 1, exported.Version)
+	require.Len(s.T(), exported.LinkTypes, 2)
+	require.Equal(s.T(), "a", exported.LinkTypes[0].Name)
+	require.Equal(s.T(), fxt.WorkItemLinkCategories[0].Name, exported.LinkTypes[0].LinkCategory)
+	payload := &app.ImportWorkItemLinkTypePayload{
+		Version:   exported.Version,
+		LinkTypes: exported.LinkTypes,
+	}
+	s.T().Run("forbidden for others", func(t *testing.T) {
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[1], authzSrv)
+		test.ImportWorkItemLinkTypeForbidden(t, svc.Context, svc, s.linkTypeCtrl, targetSpaceID, payload)
+	})
+	s.T().Run("unknown version", func(t *testing.T) {
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		test.ImportWorkItemLinkTypeBadRequest(t, svc.Context, svc, s.linkTypeCtrl, targetSpaceID, &app.ImportWorkItemLinkTypePayload{
+			Version:   2,
+			LinkTypes: exported.LinkTypes,
+		})
+	})
+	s.T().Run("round trip", func(t *testing.T) {
+		// when
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		_, res := test.ImportWorkItemLinkTypeOK(t, svc.Context, svc, s.linkTypeCtrl, targetSpaceID, payload)
+		// then
+		require.Equal(t, 2, res.Meta.TotalCount)
+		require.Empty(t, res.Meta.Skipped)
+		_, reexported := test.ExportWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, targetSpaceID)
+		require.Equal(t, exported, reexported)
+	})
+	s.T().Run("importing again skips everything", func(t *testing.T) {
+		// when
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		_, res := test.ImportWorkItemLinkTypeOK(t, svc.Context, svc, s.linkTypeCtrl, targetSpaceID, payload)
+		// then
+		require.Empty(t, res.Data)
+		require.ElementsMatch(t, []string{"a", "b"}, res.Meta.Skipped)
+	})
+	s.T().Run("collaborators can't create missing categories", func(t *testing.T) {
+		// given
+		categoryName := "forbidden-" + uuid.NewV4().String()
+		entry := *exported.LinkTypes[0]
+		entry.Name = "collaborator link type"
+		entry.ForwardName = "collaborator forward name"
+		entry.ReverseName = "collaborator reverse name"
+		entry.LinkCategory = categoryName
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[2], authzSrv)
+		// when
+		test.ImportWorkItemLinkTypeForbidden(t, svc.Context, svc, s.linkTypeCtrl, targetSpaceID, &app.ImportWorkItemLinkTypePayload{
+			Version:   1,
+			LinkTypes: []*app.WorkItemLinkTypeExportEntry{&entry},
+		})
+		// then
+		_, categories := test.ListWorkItemLinkCategoryOK(t, nil, nil, s.linkCatCtrl, nil)
+		for _, cat := range categories.Data {
+			require.NotEqual(t, categoryName, *cat.Attributes.Name)
+		}
+	})
+	s.T().Run("missing categories are created", func(t *testing.T) {
+		// given
+		categoryName := "imported-" + uuid.NewV4().String()
+		entry := *exported.LinkTypes[0]
+		entry.LinkCategory = categoryName
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		// when
+		_, res := test.ImportWorkItemLinkTypeOK(t, svc.Context, svc, s.linkTypeCtrl, fxt.Spaces[1].ID, &app.ImportWorkItemLinkTypePayload{
+			Version:   1,
+			LinkTypes: []*app.WorkItemLinkTypeExportEntry{&entry},
+		})
+		// then the link type is skipped but the category exists
+		require.Empty(t, res.Data)
+		_, categories := test.ListWorkItemLinkCategoryOK(t, nil, nil, s.linkCatCtrl, nil)
+		names := []string{}
+		for _, cat := range categories.Data {
+			names = append(names, *cat.Attributes.Name)
+		}
+		require.Contains(t, names, categoryName)
+	})
+	s.T().Run("taken forward or reverse names are skipped", func(t *testing.T) {
+		// given entries with new names but the forward or reverse name of
+		// the link types imported before
+		sameForwardName := *exported.LinkTypes[0]
+		sameForwardName.Name = "same forward name"
+		sameForwardName.ReverseName = "unique reverse name"
+		sameReverseName := *exported.LinkTypes[1]
+		sameReverseName.Name = "same reverse name"
+		sameReverseName.ForwardName = "unique forward name"
+		svc := testsupport.ServiceAsSpaceUser("WorkItemLinkType-Service", *fxt.Identities[0], authzSrv)
+		// when
+		_, res := test.ImportWorkItemLinkTypeOK(t, svc.Context, svc, s.linkTypeCtrl, targetSpaceID, &app.ImportWorkItemLinkTypePayload{
+			Version:   1,
+			LinkTypes: []*app.WorkItemLinkTypeExportEntry{&sameForwardName, &sameReverseName},
+		})
+		// then
+		require.Empty(t, res.Data)
+		require.ElementsMatch(t, []string{"same forward name", "same reverse name"}, res.Meta.Skipped)
+	})
+}
+
+func (s *workItemLinkTypeSuite) TestLintWorkItemLinkTypes() {
+	// given three link types of which "used" is fine, "duplicate" shares the
+	// forward name of "used" and "orphaned" belongs to a deleted category
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(2, tf.SetWorkItemTitles("A", "B")),
+		tf.WorkItemLinkCategories(2),
+		tf.WorkItemLinkTypes(3, tf.SetWorkItemLinkTypeNames("used", "duplicate", "orphaned"), func(fxt *tf.TestFixture, idx int) error {
+			if idx == 2 {
+				fxt.WorkItemLinkTypes[idx].LinkCategoryID = fxt.WorkItemLinkCategories[1].ID
+			} else {
+				fxt.WorkItemLinkTypes[idx].LinkCategoryID = fxt.WorkItemLinkCategories[0].ID
+			}
+			return nil
+		}),
+		tf.WorkItemLinksCustom(2, tf.BuildLinks(tf.L("A", "B", "used"), tf.L("B", "A", "orphaned"))),
+	)
+	require.NoError(s.T(), s.DB.Model(fxt.WorkItemLinkTypeByName("duplicate")).Update("forward_name", fxt.WorkItemLinkTypeByName("used").ForwardName).Error)
+	require.NoError(s.T(), s.DB.Delete(fxt.WorkItemLinkCategories[1]).Error)
+	// when
+	_, res := test.LintWorkItemLinkTypeOK(s.T(), nil, nil, s.linkTypeCtrl, fxt.Spaces[0].ID)
+	// then
+	type warning struct {
+		code string
+		name string
+	}
+	warnings := []warning{}
+	for _, w := range res.Data {
+		warnings = append(warnings, warning{w.Code, w.Name})
+	}
+	require.ElementsMatch(s.T(), []warning{
+		{"duplicate_name", "used"},
+		{"duplicate_name", "duplicate"},
+		{"unused", "duplicate"},
+		{"orphaned_category", "orphaned"},
+	}, warnings)
+	require.Equal(s.T(), 4, res.Meta.TotalCount)
+}
+
+func (s *workItemLinkTypeSuite) TestSuggestWorkItemLinkTypes() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(2, tf.SetWorkItemTitles("A", "B")),
+		tf.WorkItemLinkTypes(1, tf.SetWorkItemLinkTypeNames("used")),
+		tf.WorkItemLinksCustom(1, tf.BuildLinks(tf.L("A", "B", "used"))),
+	)
+	spaceID := fxt.Spaces[0].ID
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		_, res := test.SuggestWorkItemLinkTypeOK(t, nil, nil, s.linkTypeCtrl, spaceID, fxt.WorkItemByTitle("A").ID, fxt.WorkItemByTitle("B").ID)
+		// then
+		require.NotEmpty(t, res.Data)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].ID, *res.Data[0].ID)
+		require.NotEmpty(t, res.Included)
+	})
+	s.T().Run("source of another space", func(t *testing.T) {
+		test.SuggestWorkItemLinkTypeBadRequest(t, nil, nil, s.linkTypeCtrl, space.SystemSpace, fxt.WorkItemByTitle("A").ID, fxt.WorkItemByTitle("B").ID)
+	})
+	s.T().Run("unknown source", func(t *testing.T) {
+		test.SuggestWorkItemLinkTypeNotFound(t, nil, nil, s.linkTypeCtrl, spaceID, uuid.NewV4(), fxt.WorkItemByTitle("B").ID)
+	})
+}
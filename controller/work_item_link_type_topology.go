@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+)
+
+// APIStringTypeWorkItemLinkTypeTopology contains the JSON API type for work
+// item link type topologies
+const APIStringTypeWorkItemLinkTypeTopology = "workitemlinktypetopologies"
+
+// WorkItemLinkTypeTopologyController implements the work_item_link_type_topology resource.
+type WorkItemLinkTypeTopologyController struct {
+	*goa.Controller
+	config WorkItemLinkTypeTopologyControllerConfiguration
+}
+
+// WorkItemLinkTypeTopologyControllerConfiguration the configuration for the
+// WorkItemLinkTypeTopologyController.
+type WorkItemLinkTypeTopologyControllerConfiguration interface {
+	GetCacheControlLinkTopologies() string
+}
+
+// NewWorkItemLinkTypeTopologyController creates a work_item_link_type_topology controller.
+func NewWorkItemLinkTypeTopologyController(service *goa.Service, config WorkItemLinkTypeTopologyControllerConfiguration) *WorkItemLinkTypeTopologyController {
+	return &WorkItemLinkTypeTopologyController{
+		Controller: service.NewController("WorkItemLinkTypeTopologyController"),
+		config:     config,
+	}
+}
+
+// List runs the list action.
+func (c *WorkItemLinkTypeTopologyController) List(ctx *app.ListWorkItemLinkTypeTopologyContext) error {
+	res := &app.WorkItemLinkTypeTopologyList{
+		Data: make([]*app.WorkItemLinkTypeTopologyData, len(link.ValidTopologies)),
+	}
+	etagData := make([]app.ConditionalRequestEntity, len(link.ValidTopologies))
+	for i, t := range link.ValidTopologies {
+		res.Data[i] = &app.WorkItemLinkTypeTopologyData{
+			Type: APIStringTypeWorkItemLinkTypeTopology,
+			ID:   t.String(),
+			Attributes: &app.WorkItemLinkTypeTopologyAttributes{
+				Description: t.Description(),
+			},
+		}
+		etagData[i] = topologyEtagData{t}
+	}
+	ctx.ResponseData.Header().Set(app.ETag, app.GenerateEntitiesTag(etagData))
+	ctx.ResponseData.Header().Set(app.LastModified, app.ToHTTPTime(time.Now()))
+	ctx.ResponseData.Header().Set(app.CacheControl, c.config.GetCacheControlLinkTopologies())
+	return ctx.OK(res)
+}
+
+// topologyEtagData carries the data to generate an ETag for a topology.
+type topologyEtagData struct {
+	topology link.Topology
+}
+
+// GetETagData returns the field values to compute the ETag.
+func (t topologyEtagData) GetETagData() []interface{} {
+	return []interface{}{t.topology, t.topology.Description()}
+}
+
+// GetLastModified returns the field values to compute the `Last-Modified`
+// response header.
+func (t topologyEtagData) GetLastModified() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,45 @@
+package controller_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	"github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type workItemLinkTypeTopologySuite struct {
+	// composing with the DBTestSuite to get the Configuration out-of-the-box, even though this particular Controller
+	// does not need an access to the DB.
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunWorkItemLinkTypeTopologySuite(t *testing.T) {
+	resource.Require(t, resource.Database)
+	pwd, err := os.Getwd()
+	require.NoError(t, err)
+	suite.Run(t, &workItemLinkTypeTopologySuite{DBTestSuite: gormtestsupport.NewDBTestSuite(pwd + "/../config.yaml")})
+}
+
+func (s *workItemLinkTypeTopologySuite) TestListOK() {
+	// given
+	svc := goa.New("workItemLinkTypeTopologyService")
+	ctrl := controller.NewWorkItemLinkTypeTopologyController(svc, s.Configuration)
+	// when
+	res, topologies := test.ListWorkItemLinkTypeTopologyOK(s.T(), svc.Context, svc, ctrl)
+	// then
+	require.Len(s.T(), topologies.Data, len(link.ValidTopologies))
+	for i, t := range link.ValidTopologies {
+		require.Equal(s.T(), t.String(), topologies.Data[i].ID)
+		require.Equal(s.T(), controller.APIStringTypeWorkItemLinkTypeTopology, topologies.Data[i].Type)
+		require.NotEmpty(s.T(), topologies.Data[i].Attributes.Description)
+	}
+	_, _, cacheControl := assertResponseHeaders(s.T(), res)
+	require.Equal(s.T(), s.Configuration.GetCacheControlLinkTopologies(), cacheControl)
+}
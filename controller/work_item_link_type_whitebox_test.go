@@ -0,0 +1,335 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkItemLinkType_ConvertLinkTypesFromModels(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	req := &http.Request{Host: "api.service.domain.org"}
+
+	t.Run("empty list", func(t *testing.T) {
+		for name, input := range map[string][]link.WorkItemLinkType{
+			"nil":   nil,
+			"empty": {},
+		} {
+			t.Run(name, func(t *testing.T) {
+				// when
+				list, err := ConvertLinkTypesFromModels(req, input)
+				// then
+				require.NoError(t, err)
+				require.NotNil(t, list.Data)
+				require.Empty(t, list.Data)
+				require.NotNil(t, list.Meta)
+				require.Equal(t, 0, list.Meta.TotalCount)
+				b, err := json.Marshal(list)
+				require.NoError(t, err)
+				require.Contains(t, string(b), `"data":[]`)
+				require.Contains(t, string(b), `"totalCount":0`)
+			})
+		}
+	})
+}
+
+func TestWorkItemLink_ConvertLinksFromModels(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	req := &http.Request{Host: "api.service.domain.org"}
+
+	t.Run("empty list", func(t *testing.T) {
+		for name, input := range map[string][]link.WorkItemLink{
+			"nil":   nil,
+			"empty": {},
+		} {
+			t.Run(name, func(t *testing.T) {
+				// when
+				list := ConvertLinksFromModels(req, input)
+				// then
+				require.NotNil(t, list.Data)
+				require.Empty(t, list.Data)
+				require.NotNil(t, list.Meta)
+				require.Equal(t, 0, list.Meta.TotalCount)
+				b, err := json.Marshal(list)
+				require.NoError(t, err)
+				require.Contains(t, string(b), `"data":[]`)
+				require.Contains(t, string(b), `"totalCount":0`)
+			})
+		}
+	})
+}
+
+func TestWorkItemLinkType_ConvertWorkItemLinkTypeToModel(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	req := &http.Request{Host: "api.service.domain.org"}
+	valid := func() app.WorkItemLinkTypeSingle {
+		return ConvertWorkItemLinkTypeFromModel(req, link.WorkItemLinkType{
+			ID:             uuid.NewV4(),
+			Name:           "foo",
+			Topology:       link.TopologyTree,
+			ForwardName:    "parent of",
+			ReverseName:    "child of",
+			LinkCategoryID: uuid.NewV4(),
+			SpaceID:        uuid.NewV4(),
+		})
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		// given
+		appLinkType := valid()
+		// when
+		modelLinkType, err := ConvertWorkItemLinkTypeToModel(appLinkType)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, *appLinkType.Data.Relationships.Space.Data.ID, modelLinkType.SpaceID)
+		require.Equal(t, "{source} parent of {target}", *appLinkType.Data.Attributes.DisplayTemplate)
+	})
+
+	t.Run("invalid topology", func(t *testing.T) {
+		// given
+		appLinkType := valid()
+		topology := "foo"
+		appLinkType.Data.Attributes.Topology = &topology
+		// when
+		_, err := ConvertWorkItemLinkTypeToModel(appLinkType)
+		// then
+		require.Error(t, err)
+		require.IsType(t, errors.BadParameterErrorCollection{}, err)
+		require.Len(t, err.(errors.BadParameterErrorCollection).Errors, 1)
+		require.Contains(t, err.Error(), "data.attributes.topology")
+		for _, validTopology := range link.ValidTopologies {
+			require.Contains(t, err.Error(), validTopology.String())
+		}
+	})
+
+	t.Run("many invalid fields", func(t *testing.T) {
+		// given
+		appLinkType := valid()
+		empty := ""
+		topology := "foo"
+		appLinkType.Data.Attributes.Name = &empty
+		appLinkType.Data.Attributes.ForwardName = &empty
+		appLinkType.Data.Attributes.ReverseName = &empty
+		appLinkType.Data.Attributes.Topology = &topology
+		appLinkType.Data.Relationships.Space = nil
+		// when
+		_, err := ConvertWorkItemLinkTypeToModel(appLinkType)
+		// then all problems are reported at once
+		require.Error(t, err)
+		require.IsType(t, errors.BadParameterErrorCollection{}, err)
+		params := []string{}
+		for _, badParam := range err.(errors.BadParameterErrorCollection).Errors {
+			params = append(params, badParam.Parameter())
+		}
+		require.Equal(t, []string{
+			"data.attributes.name",
+			"data.attributes.forward_name",
+			"data.attributes.reverse_name",
+			"data.attributes.topology",
+			"data.relationships.space.data.id",
+		}, params)
+	})
+
+	t.Run("missing attributes and relationships", func(t *testing.T) {
+		// given
+		appLinkType := valid()
+		appLinkType.Data.Attributes = nil
+		appLinkType.Data.Relationships = nil
+		// when
+		_, err := ConvertWorkItemLinkTypeToModel(appLinkType)
+		// then
+		require.Error(t, err)
+		require.IsType(t, errors.BadParameterErrorCollection{}, err)
+		require.Len(t, err.(errors.BadParameterErrorCollection).Errors, 2)
+	})
+
+	t.Run("invalid space relationship", func(t *testing.T) {
+		tests := map[string]func(appLinkType *app.WorkItemLinkTypeSingle){
+			"missing space": func(appLinkType *app.WorkItemLinkTypeSingle) { appLinkType.Data.Relationships.Space = nil },
+			"missing data":  func(appLinkType *app.WorkItemLinkTypeSingle) { appLinkType.Data.Relationships.Space.Data = nil },
+			"missing id":    func(appLinkType *app.WorkItemLinkTypeSingle) { appLinkType.Data.Relationships.Space.Data.ID = nil },
+			"nil uuid": func(appLinkType *app.WorkItemLinkTypeSingle) {
+				nilID := uuid.Nil
+				appLinkType.Data.Relationships.Space.Data.ID = &nilID
+			},
+		}
+		for name, modify := range tests {
+			t.Run(name, func(t *testing.T) {
+				// given
+				appLinkType := valid()
+				modify(&appLinkType)
+				// when
+				_, err := ConvertWorkItemLinkTypeToModel(appLinkType)
+				// then
+				require.Error(t, err)
+				require.IsType(t, errors.BadParameterErrorCollection{}, err)
+				require.Len(t, err.(errors.BadParameterErrorCollection).Errors, 1)
+				require.Contains(t, err.Error(), "data.relationships.space.data.id")
+			})
+		}
+	})
+}
+
+func TestWorkItemLinkType_ApplyWorkItemLinkTypeToModel(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	// given
+	description := "some description"
+	modelLinkType := link.WorkItemLinkType{
+		ID:             uuid.NewV4(),
+		Name:           "foo",
+		Description:    &description,
+		Version:        3,
+		Topology:       link.TopologyTree,
+		ForwardName:    "parent of",
+		ReverseName:    "child of",
+		LinkCategoryID: uuid.NewV4(),
+		SpaceID:        uuid.NewV4(),
+	}
+	forwardName := "ancestor of"
+	appLinkType := app.WorkItemLinkTypeSingle{
+		Data: &app.WorkItemLinkTypeData{
+			ID: &modelLinkType.ID,
+			Attributes: &app.WorkItemLinkTypeAttributes{
+				ForwardName: &forwardName,
+			},
+			Relationships: &app.WorkItemLinkTypeRelationships{
+				Space: app.NewSpaceRelation(modelLinkType.SpaceID, ""),
+			},
+		},
+	}
+	// when
+	err := ApplyWorkItemLinkTypeToModel(appLinkType, &modelLinkType)
+	// then only the forward name changed
+	require.NoError(t, err)
+	require.Equal(t, "ancestor of", modelLinkType.ForwardName)
+	require.Equal(t, "foo", modelLinkType.Name)
+	require.Equal(t, &description, modelLinkType.Description)
+	require.Equal(t, 3, modelLinkType.Version)
+	require.Equal(t, "child of", modelLinkType.ReverseName)
+	require.Equal(t, link.TopologyTree, modelLinkType.Topology)
+}
+
+func TestWorkItemLinkType_ApplyWorkItemLinkTypeToModelWithoutRelationships(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	stored := link.WorkItemLinkType{
+		ID:             uuid.NewV4(),
+		Name:           "foo",
+		Topology:       link.TopologyTree,
+		ForwardName:    "parent of",
+		ReverseName:    "child of",
+		LinkCategoryID: uuid.NewV4(),
+		SpaceID:        uuid.NewV4(),
+	}
+	name := "bar"
+
+	t.Run("no relationships", func(t *testing.T) {
+		// given
+		modelLinkType := stored
+		appLinkType := app.WorkItemLinkTypeSingle{
+			Data: &app.WorkItemLinkTypeData{
+				Attributes: &app.WorkItemLinkTypeAttributes{Name: &name},
+			},
+		}
+		// when
+		err := ApplyWorkItemLinkTypeToModel(appLinkType, &modelLinkType)
+		// then the relationships keep their stored values
+		require.NoError(t, err)
+		require.Equal(t, "bar", modelLinkType.Name)
+		require.Equal(t, stored.SpaceID, modelLinkType.SpaceID)
+		require.Equal(t, stored.LinkCategoryID, modelLinkType.LinkCategoryID)
+	})
+
+	t.Run("no attributes and no space relationship", func(t *testing.T) {
+		// given
+		modelLinkType := stored
+		categoryID := uuid.NewV4()
+		appLinkType := app.WorkItemLinkTypeSingle{
+			Data: &app.WorkItemLinkTypeData{
+				Relationships: &app.WorkItemLinkTypeRelationships{
+					LinkCategory: &app.RelationWorkItemLinkCategory{
+						Data: &app.RelationWorkItemLinkCategoryData{
+							Type: link.EndpointWorkItemLinkCategories,
+							ID:   categoryID,
+						},
+					},
+				},
+			},
+		}
+		// when
+		err := ApplyWorkItemLinkTypeToModel(appLinkType, &modelLinkType)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, categoryID, modelLinkType.LinkCategoryID)
+		require.Equal(t, stored.SpaceID, modelLinkType.SpaceID)
+		require.Equal(t, "foo", modelLinkType.Name)
+	})
+
+	t.Run("space relationship without an ID", func(t *testing.T) {
+		// given
+		modelLinkType := stored
+		appLinkType := app.WorkItemLinkTypeSingle{
+			Data: &app.WorkItemLinkTypeData{
+				Relationships: &app.WorkItemLinkTypeRelationships{
+					Space: &app.RelationSpaces{},
+				},
+			},
+		}
+		// when
+		err := ApplyWorkItemLinkTypeToModel(appLinkType, &modelLinkType)
+		// then
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "data.relationships.space.data.id")
+	})
+}
+
+func TestWorkItemLinkType_EnrichWithMissingRelationshipData(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	ctx := &workItemLinkContext{
+		Context:  context.Background(),
+		Request:  &http.Request{Host: "api.service.domain.org"},
+		LinkFunc: func(obj interface{}) string { return "/api/workitemlinktypes" },
+	}
+	newData := func(rel *app.WorkItemLinkTypeRelationships) *app.WorkItemLinkTypeData {
+		id := uuid.NewV4()
+		return &app.WorkItemLinkTypeData{ID: &id, Type: link.EndpointWorkItemLinkTypes, Relationships: rel}
+	}
+	testData := map[string]*app.WorkItemLinkTypeRelationships{
+		"space without data":    {Space: &app.RelationSpaces{}},
+		"space without id":      {Space: &app.RelationSpaces{Data: &app.RelationSpacesData{}}},
+		"category without data": {LinkCategory: &app.RelationWorkItemLinkCategory{}},
+	}
+	for name, rel := range testData {
+		t.Run(name, func(t *testing.T) {
+			t.Run("single", func(t *testing.T) {
+				single := &app.WorkItemLinkTypeSingle{Data: newData(rel)}
+				err := enrichLinkTypeSingle(ctx, single, defaultLinkTypeIncludes)
+				require.IsType(t, errors.InternalError{}, err)
+			})
+			t.Run("list", func(t *testing.T) {
+				list := &app.WorkItemLinkTypeList{Data: []*app.WorkItemLinkTypeData{newData(rel)}}
+				err := enrichLinkTypeList(ctx, list, defaultLinkTypeIncludes)
+				require.IsType(t, errors.InternalError{}, err)
+			})
+			t.Run("not included", func(t *testing.T) {
+				single := &app.WorkItemLinkTypeSingle{Data: newData(rel)}
+				require.NoError(t, enrichLinkTypeSingle(ctx, single, linkTypeIncludes{}))
+				list := &app.WorkItemLinkTypeList{Data: []*app.WorkItemLinkTypeData{newData(rel)}}
+				require.NoError(t, enrichLinkTypeList(ctx, list, linkTypeIncludes{}))
+			})
+		})
+	}
+}
@@ -1,11 +1,17 @@
 package controller
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
 )
 
 // WorkItemRelationshipsLinksController implements the work-item-relationships-links resource.
@@ -34,6 +40,10 @@ func (c *WorkItemRelationshipsLinksController) List(ctx *app.ListWorkItemRelatio
 	var modelLinks []link.WorkItemLink
 	err := application.Transactional(c.db, func(appl application.Application) error {
 		var err error
+		if ctx.FilterCrosses != nil {
+			modelLinks, err = appl.WorkItemLinks().ListByWorkItemCrossing(ctx.Context, ctx.WiID, link.Boundary(*ctx.FilterCrosses))
+			return err
+		}
 		modelLinks, err = appl.WorkItemLinks().ListByWorkItem(ctx.Context, ctx.WiID)
 		return err
 	})
@@ -41,20 +51,76 @@ func (c *WorkItemRelationshipsLinksController) List(ctx *app.ListWorkItemRelatio
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
 	return ctx.ConditionalEntities(modelLinks, c.config.GetCacheControlWorkItemLinks, func() error {
-		appLinks := app.WorkItemLinkList{}
-		appLinks.Data = make([]*app.WorkItemLinkData, len(modelLinks))
-		for index, modelLink := range modelLinks {
-			appLink := ConvertLinkFromModel(ctx.Request, modelLink)
-			appLinks.Data[index] = appLink.Data
-		}
-		// TODO: When adding pagination, this must not be len(rows) but
-		// the overall total number of elements from all pages.
-		appLinks.Meta = &app.WorkItemLinkListMeta{
-			TotalCount: len(modelLinks),
+		appLinks := ConvertLinksFromModels(ctx.Request, modelLinks)
+		if err := enrichLinkList(ctx.Context, c.db, ctx.Request, appLinks); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
 		}
-		if err := enrichLinkList(ctx.Context, c.db, ctx.Request, &appLinks); err != nil {
+		return ctx.OK(appLinks)
+	})
+}
+
+// ByDirection runs the by_direction action.
+func (c *WorkItemRelationshipsLinksController) ByDirection(ctx *app.ByDirectionWorkItemRelationshipsLinksContext) error {
+	var modelLinks []link.WorkItemLink
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		modelLinks, err = appl.WorkItemLinks().ListByWorkItem(ctx.Context, ctx.WiID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.ConditionalEntities(modelLinks, c.config.GetCacheControlWorkItemLinks, func() error {
+		appLinks := ConvertLinksFromModels(ctx.Request, modelLinks)
+		res, err := groupLinksByDirection(ctx.Context, c.db, ctx.Request, ctx.WiID, appLinks)
+		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, err)
 		}
-		return ctx.OK(&appLinks)
+		return ctx.OK(res)
 	})
 }
+
+// groupLinksByDirection separates the given links of a work item into outgoing
+// and incoming links and names each link as seen from the work item. The link
+// types and linked work items end up in the "included" array.
+func groupLinksByDirection(ctx context.Context, appl application.Application, req *http.Request, wiID uuid.UUID, appLinks *app.WorkItemLinkList) (*app.WorkItemLinksByDirectionSingle, error) {
+	if err := enrichLinkList(ctx, appl, req, appLinks); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	linkTypes := map[uuid.UUID]*link.WorkItemLinkType{}
+	for _, l := range appLinks.Data {
+		linkTypeID := l.Relationships.LinkType.Data.ID
+		if _, ok := linkTypes[linkTypeID]; ok {
+			continue
+		}
+		linkType, err := appl.WorkItemLinkTypes().Load(ctx, linkTypeID)
+		if err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to load work item link type %s of link %s", linkTypeID, *l.ID))
+		}
+		linkTypes[linkTypeID] = linkType
+	}
+	res := &app.WorkItemLinksByDirectionSingle{
+		Data: &app.WorkItemLinksByDirectionData{
+			Type:     "workitemlinksbydirection",
+			ID:       wiID,
+			Outgoing: []*app.WorkItemLinkDirected{},
+			Incoming: []*app.WorkItemLinkDirected{},
+		},
+		Included: appLinks.Included,
+	}
+	for _, l := range appLinks.Data {
+		linkType := linkTypes[l.Relationships.LinkType.Data.ID]
+		sourceID := l.Relationships.Source.Data.ID
+		targetID := l.Relationships.Target.Data.ID
+		name := linkType.NameForDirection(sourceID, wiID)
+		switch {
+		case sourceID == wiID && targetID == wiID:
+			res.Data.Outgoing = append(res.Data.Outgoing, &app.WorkItemLinkDirected{Direction: "self", Name: name, Link: l})
+		case sourceID == wiID:
+			res.Data.Outgoing = append(res.Data.Outgoing, &app.WorkItemLinkDirected{Direction: "outgoing", Name: name, Link: l})
+		default:
+			res.Data.Incoming = append(res.Data.Incoming, &app.WorkItemLinkDirected{Direction: "incoming", Name: name, Link: l})
+		}
+	}
+	return res, nil
+}
@@ -507,12 +507,12 @@ func (s *workItemTypeSuite) createWorkitemtypeLinks() (app.WorkItemLinkTypeSingl
 	s.T().Log("Created space")
 	// Create work item link type
 	linkTypePayload := newCreateWorkItemLinkTypePayload(animalLinksToBugStr, *linkCat.Data.ID, *sp.Data.ID)
-	_, sourceLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *sp.Data.ID, linkTypePayload)
+	_, sourceLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *sp.Data.ID, false, linkTypePayload)
 	require.NotNil(s.T(), sourceLinkType)
 	s.T().Log("Created work item source link")
 	// Create another work item link type
 	linkTypePayload = newCreateWorkItemLinkTypePayload(bugLinksToAnimalStr, *linkCat.Data.ID, *sp.Data.ID)
-	_, targetLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *sp.Data.ID, linkTypePayload)
+	_, targetLinkType := test.CreateWorkItemLinkTypeCreated(s.T(), s.svc.Context, s.svc, s.linkTypeCtrl, *sp.Data.ID, false, linkTypePayload)
 	require.NotNil(s.T(), targetLinkType)
 	s.T().Log("Created work item target link")
 	return *sourceLinkType, *targetLinkType
@@ -239,6 +239,7 @@ var _ = a.Resource("deployments", func() {
 			a.Param("podCount", d.Integer, "desired running pod count")
 		})
 		a.Response(d.OK)
+		a.Response(d.BadRequest, JSONAPIErrors)
 		a.Response(d.Unauthorized, JSONAPIErrors)
 		a.Response(d.InternalServerError, JSONAPIErrors)
 		a.Response(d.NotFound, JSONAPIErrors)
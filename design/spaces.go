@@ -75,6 +75,10 @@ var spaceAttributes = a.Type("SpaceAttributes", func() {
 	a.Attribute("updated-at", d.DateTime, "When the space was updated", func() {
 		a.Example("2016-11-29T23:18:14Z")
 	})
+	a.Attribute("allowCrossSpaceLinks", d.Boolean, `Whether work items of this space may be linked with work items of other spaces.
+Cross-space links require the other space to allow them as well and can only use global link types.`, func() {
+		a.Example(false)
+	})
 })
 
 var spaceListMeta = a.Type("SpaceListMeta", func() {
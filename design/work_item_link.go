@@ -60,6 +60,16 @@ See also see http://jsonapi.org/format/#document-resource-object-attributes`)
 	a.Attribute("version", d.Integer, "Version for optimistic concurrency control (optional during creating)", func() {
 		a.Example(0)
 	})
+	a.Attribute("reverse", d.Boolean, `When set to true during creation, the given source and target are swapped
+before the link is stored. This allows to create a link from the perspective of
+its target (e.g. "B is child of A" is stored as "A is parent of B").`, func() {
+		a.Example(false)
+	})
+	a.Attribute("idempotent", d.Boolean, `When set to true during creation, an existing link with the same source,
+target and link type is returned with a 200 status instead of failing with a
+409 conflict. This allows imports to be re-run without creating duplicates.`, func() {
+		a.Example(false)
+	})
 
 	// IMPORTANT: We cannot require any field here because these "attributes" will be used
 	// during the creation as well as the update of a work item link type.
@@ -94,6 +104,104 @@ var relationWorkItemData = a.Type("RelationWorkItemData", func() {
 	a.Required("type", "id")
 })
 
+// workItemLinksBatchPayload holds the work items whose links to fetch in one
+// request
+var workItemLinksBatchPayload = a.Type("WorkItemLinksBatchPayload", func() {
+	a.Attribute("data", a.ArrayOf(relationWorkItemData), "The work items whose links to return", func() {
+		a.MinLength(1)
+		a.MaxLength(100)
+	})
+	a.Required("data")
+})
+
+// createWorkItemLinksBulkPayload holds the work item links to create in one
+// request
+var createWorkItemLinksBulkPayload = a.Type("CreateWorkItemLinksBulkPayload", func() {
+	a.Attribute("data", a.ArrayOf(workItemLinkData), "The work item links to create", func() {
+		a.MaxLength(100)
+	})
+	a.Required("data")
+})
+
+// workItemLinkBulkResult holds the outcome of creating a single work item link
+// of a bulk request
+var workItemLinkBulkResult = a.Type("WorkItemLinkBulkResult", func() {
+	a.Attribute("created", d.Boolean, "true if the work item link was created")
+	a.Attribute("error", JSONAPIError, "The problem that prevented the work item link from being created")
+	a.Attribute("data", workItemLinkData, "The created work item link")
+	a.Required("created")
+})
+
+// workItemLinkGraphNode is a work item reached while traversing the link
+// graph of a work item
+var workItemLinkGraphNode = a.Type("WorkItemLinkGraphNode", func() {
+	a.Attribute("id", d.UUID, "ID of the work item", func() {
+		a.Example("6c5610be-30b2-4880-9fec-81e4f8e4fd76")
+	})
+	a.Attribute("number", d.Integer, "Number of the work item within its space")
+	a.Attribute("title", d.String, "Title of the work item")
+	a.Attribute("state", d.String, "State of the work item")
+	a.Attribute("depth", d.Integer, "Number of links between the work item and the work item whose graph was requested", func() {
+		a.Minimum(0)
+	})
+	a.Required("id", "depth")
+})
+
+// workItemLinkGraphData holds the work items and links that can be reached
+// from a work item
+var workItemLinkGraphData = a.Type("WorkItemLinkGraphData", func() {
+	a.Attribute("type", d.String, func() {
+		a.Enum("workitemlinkgraphs")
+	})
+	a.Attribute("id", d.UUID, "ID of the work item whose graph was requested", func() {
+		a.Example("6c5610be-30b2-4880-9fec-81e4f8e4fd76")
+	})
+	a.Attribute("nodes", a.ArrayOf(workItemLinkGraphNode), "The work items of the graph, starting with the requested one")
+	a.Attribute("edges", a.ArrayOf(workItemLinkData), "The links between the work items of the graph")
+	a.Required("type", "id", "nodes", "edges")
+})
+
+// workItemLinkGroup holds the links of a single work item
+var workItemLinkGroup = a.Type("WorkItemLinkGroup", func() {
+	a.Description("The work item links that have the given work item as source or target")
+	a.Attribute("type", d.String, func() {
+		a.Enum("workitemlinkgroups")
+	})
+	a.Attribute("id", d.UUID, "ID of the work item", func() {
+		a.Example("6c5610be-30b2-4880-9fec-81e4f8e4fd76")
+	})
+	a.Attribute("links", a.ArrayOf(workItemLinkData))
+	a.Required("type", "id", "links")
+})
+
+// workItemLinkDirected is a work item link as seen from one of its work items
+var workItemLinkDirected = a.Type("WorkItemLinkDirected", func() {
+	a.Attribute("direction", d.String, `"outgoing" if the work item is the source of the link,
+"incoming" if it is the target and "self" if it is both`, func() {
+		a.Enum("outgoing", "incoming", "self")
+	})
+	a.Attribute("name", d.String, `The forward name of the link type for outgoing links and self
+links and the reverse name for incoming links`, func() {
+		a.Example("blocks")
+	})
+	a.Attribute("link", workItemLinkData)
+	a.Required("direction", "name", "link")
+})
+
+// workItemLinksByDirectionData holds the links of a work item separated by
+// their direction
+var workItemLinksByDirectionData = a.Type("WorkItemLinksByDirectionData", func() {
+	a.Attribute("type", d.String, func() {
+		a.Enum("workitemlinksbydirection")
+	})
+	a.Attribute("id", d.UUID, "ID of the work item", func() {
+		a.Example("6c5610be-30b2-4880-9fec-81e4f8e4fd76")
+	})
+	a.Attribute("outgoing", a.ArrayOf(workItemLinkDirected), "Links with the work item as source, including links to itself")
+	a.Attribute("incoming", a.ArrayOf(workItemLinkDirected), "Links with the work item as target")
+	a.Required("type", "id", "outgoing", "incoming")
+})
+
 // ############################################################################
 //
 //  Media Type Definition
@@ -117,6 +225,43 @@ var workItemLinkList = JSONList(
 	workItemLinkListMeta,
 )
 
+// workItemLinkGraph is the media type for the link graph of a work item
+var workItemLinkGraph = JSONSingle(
+	"WorkItemLinkGraph",
+	"Holds the work items and links that can be reached from a work item",
+	workItemLinkGraphData,
+	nil,
+)
+
+// workItemLinksByDirection is the media type for the links of a work item
+// separated by their direction
+var workItemLinksByDirection = JSONSingle(
+	"WorkItemLinksByDirection",
+	"Holds the links of a work item separated into outgoing and incoming links",
+	workItemLinksByDirectionData,
+	nil,
+)
+
+// workItemLinkGroupList contains the links of many work items grouped by work
+// item
+var workItemLinkGroupList = JSONList(
+	"WorkItemLinkGroup",
+	"Holds the links of many work items grouped by work item",
+	workItemLinkGroup,
+	nil,
+	workItemLinkListMeta,
+)
+
+// workItemLinkBulkResults holds one result per work item link in the same
+// order as the links were given
+var workItemLinkBulkResults = JSONList(
+	"WorkItemLinkBulkResult",
+	"Holds the results of creating many work item links in one request",
+	workItemLinkBulkResult,
+	nil,
+	nil,
+)
+
 // ############################################################################
 //
 //  Resource Definition
@@ -150,6 +295,9 @@ var _ = a.Resource("work_item_link", func() {
 		a.Response(d.Created, "/workitemlinks/.*", func() {
 			a.Media(workItemLink)
 		})
+		a.Response(d.OK, func() {
+			a.Media(workItemLink)
+		})
 		a.Response(d.BadRequest, JSONAPIErrors)
 		a.Response(d.InternalServerError, JSONAPIErrors)
 		a.Response(d.Unauthorized, JSONAPIErrors)
@@ -157,6 +305,25 @@ var _ = a.Resource("work_item_link", func() {
 		a.Response(d.Conflict, JSONAPIErrors)
 		a.Response(d.Forbidden, JSONAPIErrors)
 	})
+	a.Action("bulk", func() {
+		a.Description(`Create many work item links in a single transaction.
+Either all links are created or none of them. Each link is validated like a link
+given to the create action. The result of each link holds the created link or
+the problem that prevented its creation. If any link can't be created, the
+results of all links are returned with a 422 status and nothing is created.
+The link types and work items of the created links are returned in the
+"included" array.`)
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/bulk"),
+		)
+		a.Payload(createWorkItemLinksBulkPayload)
+		a.Response(d.Created, workItemLinkBulkResults)
+		a.Response(d.UnprocessableEntity, workItemLinkBulkResults)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+	})
 	a.Action("delete", func() {
 		a.Description("Delete work item link with given id.")
 		a.Security("jwt")
@@ -173,6 +340,29 @@ var _ = a.Resource("work_item_link", func() {
 		a.Response(d.Unauthorized, JSONAPIErrors)
 		a.Response(d.Forbidden, JSONAPIErrors)
 	})
+	a.Action("delete_by_endpoints", func() {
+		a.Description(`Delete the work item link of the given type from the given source
+to the given target work item. This spares clients that don't keep track of link IDs
+looking up the link first. Responds with 404 if no such link exists and with 409 if
+more than one does.`)
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE(""),
+		)
+		a.Params(func() {
+			a.Param("sourceID", d.UUID, "ID of the source work item of the link to be deleted")
+			a.Param("targetID", d.UUID, "ID of the target work item of the link to be deleted")
+			a.Param("linkTypeID", d.UUID, "ID of the work item link type of the link to be deleted")
+			a.Required("sourceID", "targetID", "linkTypeID")
+		})
+		a.Response(d.OK)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
 })
 
 var _ = a.Resource("work_item_relationships_links", func() {
@@ -183,6 +373,12 @@ var _ = a.Resource("work_item_relationships_links", func() {
 		a.Routing(
 			a.GET(""),
 		)
+		a.Params(func() {
+			a.Param("filter[crosses]", d.String, `Only return links whose source and target work items belong to
+different iterations or areas (e.g. to highlight cross-team dependencies)`, func() {
+				a.Enum("iteration", "area")
+			})
+		})
 		a.UseTrait("conditional")
 		a.Response(d.OK, workItemLinkList)
 		a.Response(d.NotModified)
@@ -193,4 +389,66 @@ var _ = a.Resource("work_item_relationships_links", func() {
 			a.Description("This error arises when the given work item does not exist.")
 		})
 	})
+	a.Action("by_direction", func() {
+		a.Routing(
+			a.GET("/bydirection"),
+		)
+		a.Description(`List the work item links of the given work item separated into outgoing
+and incoming links. Each link is named with the forward or reverse name of its
+link type as seen from the given work item. Links from the work item to itself
+are only listed once as outgoing links with the "self" direction.`)
+		a.UseTrait("conditional")
+		a.Response(d.OK, workItemLinksByDirection)
+		a.Response(d.NotModified)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors, func() {
+			a.Description("This error arises when the given work item does not exist.")
+		})
+	})
+})
+
+var _ = a.Resource("work_item_link_graph", func() {
+	a.BasePath("/linkgraph")
+	a.Parent("workitem")
+	a.Action("show", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description(`Retrieve the work items and links that can be reached from the given work item
+by following links in either direction. Every work item and link is returned
+only once, even if the links form cycles. The link types of the links are
+returned in the "included" array.`)
+		a.Params(func() {
+			a.Param("depth", d.Integer, `Maximum number of links to follow from the given work item
+(defaults to the configured maximum, which must not be exceeded)`, func() {
+				a.Minimum(1)
+			})
+			a.Param("type", d.UUID, "Only follow links of the work item link type with this ID")
+		})
+		a.Response(d.OK, workItemLinkGraph)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors, func() {
+			a.Description("This error arises when the given work item does not exist.")
+		})
+		a.Response(d.InternalServerError, JSONAPIErrors)
+	})
+})
+
+var _ = a.Resource("space_work_item_links", func() {
+	a.BasePath("/workitemlinks")
+	a.Parent("space")
+	a.Action("batch", func() {
+		a.Routing(
+			a.POST("/batch"),
+		)
+		a.Description(`List the work item links of many work items of a space in one request.
+The links are grouped by work item. The link types and the work items referenced
+by the links are contained only once in the "included" array.`)
+		a.Payload(workItemLinksBatchPayload)
+		a.Response(d.OK, workItemLinkGroupList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+	})
 })
@@ -33,6 +33,16 @@ var workItemLinkCategoryListMeta = a.Type("WorkItemLinkCategoryListMeta", func()
 	a.Required("totalCount")
 })
 
+// workItemLinkCategoryImpactMeta holds meta information about the work item
+// link types that would be affected by deleting a work item link category
+var workItemLinkCategoryImpactMeta = a.Type("WorkItemLinkCategoryImpactMeta", func() {
+	a.Attribute("totalCount", d.Integer, func() {
+		a.Minimum(0)
+	})
+	a.Attribute("linkCounts", a.HashOf(d.String, d.Integer), "Number of work item links per work item link type ID")
+	a.Required("totalCount", "linkCounts")
+})
+
 // workItemLinkCategoryData is the JSONAPI store for the data of a work item link category.
 var workItemLinkCategoryData = a.Type("WorkItemLinkCategoryData", func() {
 	a.Description(`JSONAPI store the data of a work item link category.
@@ -71,6 +81,9 @@ See also http://jsonapi.org/format/#document-resource-object-attributes`)
 var relationWorkItemLinkCategory = a.Type("RelationWorkItemLinkCategory", func() {
 	a.Attribute("data", relationWorkItemLinkCategoryData)
 	a.Attribute("links", genericLinks)
+	a.Attribute("meta", a.HashOf(d.String, d.Any), `Non-standard meta-information about the related work item link category.
+When the category was loaded along with the resource, "resolved" is true and "name" holds the
+category's name, so that clients don't have to fetch the category just to display it.`)
 })
 
 // relationWorkItemLinkCategoryData is the JSONAPI data object of the the work item link category relationship objects
@@ -108,6 +121,16 @@ var workItemLinkCategoryList = JSONList(
 	workItemLinkCategoryListMeta,
 )
 
+// workItemLinkCategoryImpact lists the work item link types that reference a
+// work item link category
+var workItemLinkCategoryImpact = JSONList(
+	"WorkItemLinkCategoryImpact",
+	"Holds the work item link types that would be orphaned by deleting a work item link category",
+	workItemLinkTypeData,
+	nil,
+	workItemLinkCategoryImpactMeta,
+)
+
 // ############################################################################
 //
 //  Resource Definition
@@ -137,7 +160,16 @@ var _ = a.Resource("work_item_link_category", func() {
 		a.Routing(
 			a.GET(""),
 		)
-		a.Description("List work item link categories.")
+		a.Description(`List work item link categories.
+
+Use the "ids" parameter to only fetch the categories referenced by a work item
+link type list that was requested without its "included" array. Unknown IDs
+are ignored.`)
+		a.Params(func() {
+			a.Param("ids", d.String, "Comma-separated list of work item link category IDs to return", func() {
+				a.Example("6c5610be-30b2-4880-9fec-81e4f8e4fd76,2d98c73d-6969-4ea6-958a-812c832b6c18")
+			})
+		})
 		a.Response(d.OK, func() {
 			a.Media(workItemLinkCategoryList)
 		})
@@ -145,6 +177,20 @@ var _ = a.Resource("work_item_link_category", func() {
 		a.Response(d.InternalServerError, JSONAPIErrors)
 	})
 
+	a.Action("impact", func() {
+		a.Routing(
+			a.GET("/:id/impact"),
+		)
+		a.Description("List the work item link types (and their number of links) that reference the work item link category with the given id.")
+		a.Params(func() {
+			a.Param("id", d.UUID, "ID of the work item link category")
+		})
+		a.Response(d.OK, workItemLinkCategoryImpact)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
 	a.Action("create", func() {
 		a.Security("jwt")
 		a.Routing(
@@ -177,6 +223,7 @@ var _ = a.Resource("work_item_link_category", func() {
 		a.Response(d.InternalServerError, JSONAPIErrors)
 		a.Response(d.NotFound, JSONAPIErrors)
 		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
 	})
 
 	a.Action("update", func() {
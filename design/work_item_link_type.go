@@ -17,6 +17,40 @@ var updateWorkItemLinkTypePayload = a.Type("UpdateWorkItemLinkTypePayload", func
 	a.Required("data")
 })
 
+// validateWorkItemLinkTypesPayload defines the structure of a batch of work
+// item link type payloads in JSONAPI format that shall be validated
+var validateWorkItemLinkTypesPayload = a.Type("ValidateWorkItemLinkTypesPayload", func() {
+	a.Attribute("data", a.ArrayOf(workItemLinkTypeData))
+	a.Required("data")
+})
+
+// createWorkItemLinkTypesWithCategoryPayload defines a new work item link
+// category together with the work item link types to create in it
+var createWorkItemLinkTypesWithCategoryPayload = a.Type("CreateWorkItemLinkTypesWithCategoryPayload", func() {
+	a.Attribute("category", workItemLinkCategoryData, "The work item link category to create")
+	a.Attribute("data", a.ArrayOf(workItemLinkTypeData), "The work item link types to create in the new category", func() {
+		a.MinLength(1)
+	})
+	a.Required("category", "data")
+})
+
+// setWorkItemLinkTypesCategoryPayload defines the work item link types that
+// shall be moved to another work item link category
+var setWorkItemLinkTypesCategoryPayload = a.Type("SetWorkItemLinkTypesCategoryPayload", func() {
+	a.Attribute("data", a.ArrayOf(relationWorkItemLinkTypeData), "The work item link types to move", func() {
+		a.MinLength(1)
+	})
+	a.Attribute("link_category", relationWorkItemLinkCategoryData, "The work item link category to move the work item link types to")
+	a.Required("data", "link_category")
+})
+
+// setDefaultWorkItemLinkTypePayload defines the work item link type that
+// shall become the default link type of a space
+var setDefaultWorkItemLinkTypePayload = a.Type("SetDefaultWorkItemLinkTypePayload", func() {
+	a.Attribute("data", relationWorkItemLinkTypeData, "The work item link type to use by default")
+	a.Required("data")
+})
+
 // workItemLinkTypeListMeta holds meta information for a work item link type array response
 var workItemLinkTypeListMeta = a.Type("WorkItemLinkTypeListMeta", func() {
 	a.Attribute("totalCount", d.Integer, func() {
@@ -25,6 +59,16 @@ var workItemLinkTypeListMeta = a.Type("WorkItemLinkTypeListMeta", func() {
 	a.Required("totalCount")
 })
 
+// workItemLinkTypeCopyMeta holds meta information for the response to copying
+// the work item link types of one space into another
+var workItemLinkTypeCopyMeta = a.Type("WorkItemLinkTypeCopyMeta", func() {
+	a.Attribute("totalCount", d.Integer, "Number of work item link types that were copied", func() {
+		a.Minimum(0)
+	})
+	a.Attribute("skipped", a.ArrayOf(d.String), "Names of the work item link types that were not copied because the target space already has a link type with that name")
+	a.Required("totalCount", "skipped")
+})
+
 // workItemLinkTypeData is the JSONAPI store for the data of a work item link type.
 var workItemLinkTypeData = a.Type("WorkItemLinkTypeData", func() {
 	a.Description(`JSONAPI store for the data of a work item link type.
@@ -36,6 +80,10 @@ See also http://jsonapi.org/format/#document-resource-object`)
 	a.Attribute("attributes", workItemLinkTypeAttributes)
 	a.Attribute("relationships", workItemLinkTypeRelationships)
 	a.Attribute("links", genericLinks)
+	a.Attribute("meta", a.HashOf(d.String, d.Any), `Non-standard meta-information about the work item link type (read-only).
+When the usage is requested, directed link types carry "usage_forward" (number of work items
+that are the source of a link of this type) and "usage_reverse" (number of work items that
+are the target of a link of this type).`)
 	a.Required("type", "attributes")
 })
 
@@ -47,11 +95,15 @@ See also see http://jsonapi.org/format/#document-resource-object-attributes`)
 	a.Attribute("description", d.String, "Description of the work item link type (optional)", func() {
 		a.Example("A test work item can 'test' if a the code in a pull request passes the tests.")
 	})
+	a.Attribute("description_markup", d.String, "The markup language of the description (defaults to PlainText on creation)", func() {
+		a.Enum("PlainText", "Markdown")
+	})
 	a.Attribute("version", d.Integer, "Version for optimistic concurrency control (optional during creating)", func() {
 		a.Example(0)
 	})
 	a.Attribute("created-at", d.DateTime, "Time of creation of the given work item type")
 	a.Attribute("updated-at", d.DateTime, "Time of last update of the given work item type")
+	a.Attribute("deleted-at", d.DateTime, "Time when the work item link type was deleted (read-only and only set for deleted link types)")
 	a.Attribute("forward_name", d.String, `The forward oriented path from source to target is described with the forward name.
 For example, if a bug blocks a user story, the forward name is "blocks". See also reverse name.`, func() {
 		a.Example("test-workitemtype")
@@ -63,6 +115,17 @@ For example, if a bug blocks a user story, the reverse name name is "blocked by"
 	a.Attribute("topology", d.String, `The topology determines the restrictions placed on the usage of each work item link type.`, func() {
 		a.Enum("network", "tree")
 	})
+	a.Attribute("display_template", d.String, `A template to phrase a link of this type (read-only). The "{source}" and "{target}"
+placeholders are to be replaced with the source and target work items. Links of every topology are
+phrased with the forward name, because a link always points from its source to its target.`, func() {
+		a.Example("{source} blocks {target}")
+	})
+	a.Attribute("self_reference_allowed", d.Boolean, "Whether a work item can be linked to itself with this link type (defaults to false on creation)")
+	a.Attribute("source_work_item_type_ids", a.ArrayOf(d.UUID), "IDs of the work item types that links of this type can start at, empty or missing to allow any type")
+	a.Attribute("target_work_item_type_ids", a.ArrayOf(d.UUID), "IDs of the work item types that links of this type can end at, empty or missing to allow any type")
+	a.Attribute("usageCount", d.Integer, "Number of work item links of this type (read-only and only set when requested)", func() {
+		a.Minimum(0)
+	})
 
 	// IMPORTANT: We cannot require any field here because these "attributes" will be used
 	// during the creation as well as the update of a work item link type.
@@ -77,6 +140,83 @@ var workItemLinkTypeRelationships = a.Type("WorkItemLinkTypeRelationships", func
 See also http://jsonapi.org/format/#document-resource-object-relationships`)
 	a.Attribute("link_category", relationWorkItemLinkCategory, "The work item link category of this work item link type.")
 	a.Attribute("space", relationSpaces, "This defines the owning space of this work item link type.")
+	a.Attribute("author", relationWorkItemLinkTypeAuthor, "The identity that created this work item link type (read-only).")
+})
+
+// relationWorkItemLinkTypeAuthor is the JSONAPI store for the author
+// relationship of a work item link type. Its "data" is always serialized so
+// that link types without a known author carry "data": null.
+var relationWorkItemLinkTypeAuthor = a.Type("RelationWorkItemLinkTypeAuthor", func() {
+	a.Attribute("data", genericData, "The identity that created the work item link type or null if it is unknown", func() {
+		a.Metadata("struct:tag:json", "data")
+	})
+	a.Attribute("links", genericLinks)
+})
+
+// workItemLinkTypeUsageData is the JSONAPI store for one time bucket of the
+// usage of a work item link type.
+var workItemLinkTypeUsageData = a.Type("WorkItemLinkTypeUsageData", func() {
+	a.Attribute("type", d.String, func() {
+		a.Enum("workitemlinktypeusages")
+	})
+	a.Attribute("attributes", workItemLinkTypeUsageAttributes)
+	a.Required("type", "attributes")
+})
+
+// workItemLinkTypeUsageAttributes is the JSONAPI store for all the
+// "attributes" of one time bucket of the usage of a work item link type.
+var workItemLinkTypeUsageAttributes = a.Type("WorkItemLinkTypeUsageAttributes", func() {
+	a.Attribute("start", d.DateTime, "Start of the time bucket")
+	a.Attribute("count", d.Integer, "Number of work item links of the given type created in the time bucket", func() {
+		a.Minimum(0)
+	})
+	a.Required("start", "count")
+})
+
+// workItemLinkTypeValidationResult holds the outcome of validating a single
+// work item link type payload.
+var workItemLinkTypeValidationResult = a.Type("WorkItemLinkTypeValidationResult", func() {
+	a.Attribute("valid", d.Boolean, "true if the payload can be used to create a work item link type")
+	a.Attribute("errors", a.ArrayOf(JSONAPIError), "Problems that prevent the creation of the work item link type")
+	a.Attribute("warnings", a.ArrayOf(d.String), "Problems that don't prevent the creation of the work item link type")
+	a.Required("valid", "errors", "warnings")
+})
+
+// workItemLinkTypeCategoryChangeResult holds the outcome of moving a single
+// work item link type to another work item link category.
+var workItemLinkTypeCategoryChangeResult = a.Type("WorkItemLinkTypeCategoryChangeResult", func() {
+	a.Attribute("id", d.UUID, "ID of the work item link type")
+	a.Attribute("moved", d.Boolean, "true if the work item link type now belongs to the given category")
+	a.Attribute("error", JSONAPIError, "The problem that prevented the work item link type from being moved")
+	a.Attribute("data", workItemLinkTypeData, "The work item link type after it was moved")
+	a.Required("id", "moved")
+})
+
+// workItemLinkTypeConsolidation describes how the work item link types sharing
+// one name within a space were merged into a single link type.
+var workItemLinkTypeConsolidation = a.Type("WorkItemLinkTypeConsolidation", func() {
+	a.Attribute("name", d.String, "Name shared by the consolidated work item link types")
+	a.Attribute("kept", d.UUID, "ID of the work item link type that was kept because it had the most links")
+	a.Attribute("removed", a.ArrayOf(d.UUID), "IDs of the duplicate work item link types that were deleted")
+	a.Attribute("repointedLinks", d.Integer, "Number of links that were moved to the kept work item link type", func() {
+		a.Minimum(0)
+	})
+	a.Attribute("mergedLinks", d.Integer, "Number of links that were deleted because the kept work item link type already links the same work items", func() {
+		a.Minimum(0)
+	})
+	a.Required("name", "kept", "removed", "repointedLinks", "mergedLinks")
+})
+
+// workItemLinkTypeLintWarning describes a problem with the configuration of a
+// single work item link type.
+var workItemLinkTypeLintWarning = a.Type("WorkItemLinkTypeLintWarning", func() {
+	a.Attribute("code", d.String, "Kind of the problem", func() {
+		a.Enum("duplicate_name", "orphaned_category", "unused")
+	})
+	a.Attribute("id", d.UUID, "ID of the work item link type")
+	a.Attribute("name", d.String, "Name of the work item link type")
+	a.Attribute("detail", d.String, "Human readable description of the problem")
+	a.Required("code", "id", "name", "detail")
 })
 
 // relationWorkItemType is the JSONAPI store for the work item type relationship objects
@@ -136,10 +276,136 @@ var workItemLinkTypeList = JSONList(
 	"WorkItemLinkType",
 	"Holds the paginated response to a work item link type list request",
 	workItemLinkTypeData,
-	nil, //pagingLinks,
+	pagingLinks,
 	workItemLinkTypeListMeta,
 )
 
+// workItemLinkTypeCount only holds the number of work item link types of a
+// space without the link types themselves
+var workItemLinkTypeCount = a.MediaType("application/vnd.workitemlinktypecount+json", func() {
+	a.UseTrait("jsonapi-media-type")
+	a.TypeName("WorkItemLinkTypeCount")
+	a.Description("Holds the number of work item link types available in a space")
+	a.Attribute("meta", workItemLinkTypeListMeta)
+	a.Required("meta")
+	a.View("default", func() {
+		a.Attribute("meta")
+		a.Required("meta")
+	})
+})
+
+// workItemLinkTypeUsageList contains the time series of the number of links
+// created for a work item link type
+var workItemLinkTypeUsageList = JSONList(
+	"WorkItemLinkTypeUsage",
+	"Holds the number of work item links created per time bucket for a work item link type",
+	workItemLinkTypeUsageData,
+	nil,
+	workItemLinkTypeListMeta,
+)
+
+// workItemLinkTypeCategoryChangeResults holds one result per work item link
+// type in the same order as the link types were given
+var workItemLinkTypeCategoryChangeResults = JSONList(
+	"WorkItemLinkTypeCategoryChangeResult",
+	"Holds the results of moving work item link types to another work item link category",
+	workItemLinkTypeCategoryChangeResult,
+	nil,
+	nil,
+)
+
+// workItemLinkTypeLintWarnings holds the problems found with the work item
+// link types of a space
+var workItemLinkTypeLintWarnings = JSONList(
+	"WorkItemLinkTypeLintWarning",
+	"Holds the problems found with the configuration of the work item link types of a space",
+	workItemLinkTypeLintWarning,
+	nil,
+	workItemLinkTypeListMeta,
+)
+
+// workItemLinkTypeConsolidations holds one entry per group of duplicate work
+// item link types
+var workItemLinkTypeConsolidations = JSONList(
+	"WorkItemLinkTypeConsolidation",
+	"Holds the results of consolidating duplicate work item link types of a space",
+	workItemLinkTypeConsolidation,
+	nil,
+	nil,
+)
+
+// workItemLinkTypeCopyList holds the work item link types that were created
+// by copying them from another space
+var workItemLinkTypeCopyList = JSONList(
+	"WorkItemLinkTypeCopy",
+	"Holds the work item link types that were copied from another space",
+	workItemLinkTypeData,
+	nil,
+	workItemLinkTypeCopyMeta,
+)
+
+// workItemLinkTypeExportEntry describes a single work item link type in an
+// export document. The link category is referenced by name so that the
+// document can be imported into another environment.
+var workItemLinkTypeExportEntry = a.Type("WorkItemLinkTypeExportEntry", func() {
+	a.Attribute("name", d.String, "Name of the work item link type", func() {
+		a.Example("tested-by-link-type")
+	})
+	a.Attribute("description", d.String, "Description of the work item link type")
+	a.Attribute("description_markup", d.String, "The markup language of the description", func() {
+		a.Enum("PlainText", "Markdown")
+	})
+	a.Attribute("forward_name", d.String, "The forward oriented path from source to target", func() {
+		a.Example("tested by")
+	})
+	a.Attribute("reverse_name", d.String, "The backwards oriented path from target to source", func() {
+		a.Example("tests")
+	})
+	a.Attribute("topology", d.String, "The topology of the work item link type", func() {
+		a.Enum("network", "directed_network", "dependency", "tree")
+	})
+	a.Attribute("link_category", d.String, "Name of the work item link category of the link type", func() {
+		a.Example("user")
+	})
+	a.Attribute("self_reference_allowed", d.Boolean, "Whether a work item can be linked to itself with this link type")
+	a.Attribute("source_work_item_type_ids", a.ArrayOf(d.UUID), "IDs of the work item types that links of this type can start at")
+	a.Attribute("target_work_item_type_ids", a.ArrayOf(d.UUID), "IDs of the work item types that links of this type can end at")
+	a.Required("name", "forward_name", "reverse_name", "topology", "link_category")
+})
+
+// workItemLinkTypeExport is a self-contained document holding the work item
+// link types of a space
+var workItemLinkTypeExport = a.MediaType("application/vnd.workitemlinktypeexport+json", func() {
+	a.TypeName("WorkItemLinkTypeExport")
+	a.Description("Holds the work item link types of a space in a portable format")
+	a.Attribute("version", d.Integer, "Version of the export format", func() {
+		a.Enum(1)
+	})
+	a.Attribute("link_types", a.ArrayOf(workItemLinkTypeExportEntry))
+	a.Required("version", "link_types")
+	a.View("default", func() {
+		a.Attribute("version")
+		a.Attribute("link_types")
+		a.Required("version", "link_types")
+	})
+})
+
+// workItemLinkTypeValidationResults holds one validation result per payload
+// in the same order as the payloads were given
+var workItemLinkTypeValidationResults = a.MediaType("application/vnd.workitemlinktypevalidationresults+json", func() {
+	a.UseTrait("jsonapi-media-type")
+	a.TypeName("WorkItemLinkTypeValidationResults")
+	a.Description("Holds the validation results for a batch of work item link type payloads")
+	a.Attributes(func() {
+		a.Attribute("data", a.ArrayOf(workItemLinkTypeValidationResult))
+		a.Required("data")
+	})
+	a.View("default", func() {
+		a.Attribute("data")
+		a.Required("data")
+	})
+})
+
 // ############################################################################
 //
 //  Resource Definition
@@ -157,6 +423,17 @@ var _ = a.Resource("work_item_link_type", func() {
 		a.Description("Retrieve work item link type (as JSONAPI) for the given link ID.")
 		a.Params(func() {
 			a.Param("wiltID", d.UUID, "ID of the work item link type")
+			a.Param("fields[workitemlinktypes]", d.String, `Comma separated names of the attributes and relationships to return
+for each work item link type (see http://jsonapi.org/format/#fetching-sparse-fieldsets).
+Related resources are only included if their relationship is requested. Unknown names are ignored.`)
+			a.Param("include", d.String, `Comma separated related resources to add to the "included" array: "link_category",
+"space" and "author" (see http://jsonapi.org/format/#fetching-includes). Nothing is included
+without this parameter and unknown names are rejected.`, func() {
+				a.Example("link_category,space")
+			})
+			a.Param("includeUsage", d.Boolean, "Set the \"usageCount\" attribute to the number of work item links of this type", func() {
+				a.Default(false)
+			})
 		})
 		a.UseTrait("conditional")
 		a.Response(d.OK, workItemLinkType)
@@ -170,12 +447,217 @@ var _ = a.Resource("work_item_link_type", func() {
 		a.Routing(
 			a.GET(""),
 		)
-		a.Description("List work item link types.")
+		a.Description(`List work item link types.
+
+The link categories, spaces and authors referenced by the link types are only
+returned in the "included" array if requested with the "include" parameter.
+Otherwise the relationships only carry their IDs, and clients can fetch the
+categories separately with GET /workitemlinkcategories?ids=<id1>,<id2>,...
+Setting "omitIncluded" to true omits the "included" array regardless of the
+"include" parameter.
+
+Use the "ids" parameter to fetch many link types by their IDs in one request.
+Only link types of the space or of the system space can be fetched. The link
+types are returned in the given order, so "ids" can't be combined with the
+filters, "includeDeleted", sorting or paging parameters. The ETag of the response covers all of the
+requested link types, so a conditional request only returns "304 Not Modified"
+if none of them changed.`)
+		a.Params(func() {
+			a.Param("fields[workitemlinktypes]", d.String, `Comma separated names of the attributes and relationships to return
+for each work item link type (see http://jsonapi.org/format/#fetching-sparse-fieldsets).
+Related resources are only included if their relationship is requested. Unknown names are ignored.`)
+			a.Param("ids", d.String, "Comma-separated list of work item link type IDs to return", func() {
+				a.Example("6c5610be-30b2-4880-9fec-81e4f8e4fd76,2d98c73d-6969-4ea6-958a-812c832b6c18")
+			})
+			a.Param("filter[createdBy]", d.UUID, "ID of the identity that created the work item link types")
+			a.Param("filter[linkCategoryID]", d.UUID, "Only list work item link types that belong to the link category with the given ID")
+			a.Param("filter[topology]", d.String, "Only list work item link types of the given topology (e.g. \"tree\" or \"network\")")
+			a.Param("include", d.String, `Comma separated related resources to add to the "included" array: "link_category",
+"space" and "author" (see http://jsonapi.org/format/#fetching-includes). Nothing is included
+without this parameter and unknown names are rejected.`, func() {
+				a.Example("link_category,space")
+			})
+			a.Param("includeDeleted", d.Boolean, "Also list deleted work item link types", func() {
+				a.Default(false)
+			})
+			a.Param("includeUsage", d.Boolean, "Set the \"usageCount\" attribute and the directional usage meta of each work item link type", func() {
+				a.Default(false)
+			})
+			a.Param("omitIncluded", d.Boolean, "Omit the \"included\" array and only return relationship IDs", func() {
+				a.Default(false)
+			})
+			a.Param("page[offset]", d.String, "Paging start position")
+			a.Param("page[limit]", d.Integer, "Paging size")
+			a.Param("sort", d.String, `Sort the work item link types by the number of links using them, by name or by creation time.
+A leading "-" sorts in descending order. Without sorting the link types are listed in the order
+in which they were created.`, func() {
+				a.Enum("usage_count", "-usage_count", "name", "-name", "created-at", "-created-at")
+			})
+		})
 		a.UseTrait("conditional")
 		a.Response(d.OK, workItemLinkTypeList)
 		a.Response(d.NotModified)
 		a.Response(d.BadRequest, JSONAPIErrors)
 		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors, func() {
+			a.Description("This error arises when one of the link types given in \"ids\" does not exist.")
+		})
+	})
+
+	a.Action("count", func() {
+		a.Routing(
+			a.GET("/count"),
+		)
+		a.Description(`Retrieve the number of work item link types available in the space.
+
+This is a cheap alternative to the "list" action that only returns the
+"totalCount" meta without any link types. Deleted link types are not counted.`)
+		a.Response(d.OK, workItemLinkTypeCount)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+	})
+
+	a.Action("usage", func() {
+		a.Routing(
+			a.GET("/:wiltID/usage"),
+		)
+		a.Description(`Retrieve the number of work item links of the given type created per day, week or month.
+Deleted links are not counted. A time range of at most 1000 days, weeks or months can be requested at once.`)
+		a.Params(func() {
+			a.Param("wiltID", d.UUID, "ID of the work item link type")
+			a.Param("from", d.DateTime, "Beginning of the time range")
+			a.Param("to", d.DateTime, "End of the time range (defaults to now)")
+			a.Param("granularity", d.String, "Size of the time buckets", func() {
+				a.Enum("day", "week", "month")
+				a.Default("day")
+			})
+			a.Required("from")
+		})
+		a.Response(d.OK, workItemLinkTypeUsageList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("lint", func() {
+		a.Routing(
+			a.GET("/lint"),
+		)
+		a.Description(`Check the work item link types defined in this space for common configuration
+problems. Nothing is changed.
+
+The following problems are reported:
+
+* "duplicate_name": another link type of the space has the same forward or reverse name
+* "orphaned_category": the link category of the link type doesn't exist anymore
+* "unused": there are no links of the link type`)
+		a.Response(d.OK, workItemLinkTypeLintWarnings)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("validate", func() {
+		a.Routing(
+			a.POST("/validate"),
+		)
+		a.Description("Validate a batch of work item link type payloads without creating them.")
+		a.Payload(validateWorkItemLinkTypesPayload)
+		a.Response(d.OK, workItemLinkTypeValidationResults)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+	})
+
+	a.Action("set_category", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/category"),
+		)
+		a.Description(`Move work item link types of the space to another work item link category.
+All link types are moved in a single transaction. Link types that cannot be
+moved (e.g. because they don't exist or belong to another space) are reported
+in their result and don't prevent the other link types from being moved. The
+moved link types and their new category are returned in the "included" array.`)
+		a.Payload(setWorkItemLinkTypesCategoryPayload)
+		a.Response(d.OK, workItemLinkTypeCategoryChangeResults)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("consolidate", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/consolidate"),
+		)
+		a.Description(`Find work item link types with the same name in the space and merge each group into
+the link type with the most links. Links of the duplicates are moved to the kept link type
+(or deleted if the kept link type already links the same work items) and the duplicates are
+deleted. Everything happens in a single transaction; if a link cannot be moved without
+violating the topology of the kept link type nothing is changed. With "dryRun" the
+consolidation is only reported. Only the owner of the space can consolidate its link types.`)
+		a.Params(func() {
+			a.Param("dryRun", d.Boolean, "Only report what would be consolidated without changing anything", func() {
+				a.Default(false)
+			})
+		})
+		a.Response(d.OK, workItemLinkTypeConsolidations)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("suggest", func() {
+		a.Routing(
+			a.GET("/suggest"),
+		)
+		a.Description(`List the work item link types that can be used to link the given source and target
+work items, most suitable first. The link types are ranked by how often they were used between
+work items of the same types in the space, then by how often they were used recently. Tree link
+types are left out if the target already has a parent of that link type.`)
+		a.Params(func() {
+			a.Param("source", d.UUID, "ID of the source work item")
+			a.Param("target", d.UUID, "ID of the target work item")
+			a.Required("source", "target")
+		})
+		a.Response(d.OK, workItemLinkTypeList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("show_default", func() {
+		a.Routing(
+			a.GET("/default"),
+		)
+		a.Description(`Retrieve the work item link type that the space uses by default for quick-linking.
+If the space has no default link type set, the system "relates to" link type is returned.`)
+		a.Response(d.OK, workItemLinkType)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("set_default", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PUT("/default"),
+		)
+		a.Description(`Set the work item link type that the space uses by default for quick-linking.
+The link type must belong to the space or be a global link type of the system space.
+Only the owner of the space can change its default link type.`)
+		a.Payload(setDefaultWorkItemLinkTypePayload)
+		a.Response(d.OK, workItemLinkType)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
 	})
 
 	a.Action("create", func() {
@@ -183,15 +665,58 @@ var _ = a.Resource("work_item_link_type", func() {
 		a.Routing(
 			a.POST(""),
 		)
-		a.Description("Create a work item link type")
+		a.Description(`Create a work item link type.
+
+Only the space owner and the collaborators of the space are allowed to
+create work item link types in it. With "dryRun" the payload is validated
+and the link type that would be created is returned with a 200 status and
+the X-Dry-Run header, but nothing is persisted.
+
+The ID of the new link type can be given in "data.id" to get the same ID in
+every environment. If the ID is already used by another (possibly deleted)
+link type, a 409 status is returned. Without an ID a new one is generated.`)
+		a.Params(func() {
+			a.Param("dryRun", d.Boolean, "Only validate the link type and return it without persisting it", func() {
+				a.Default(false)
+			})
+		})
 		a.Payload(createWorkItemLinkTypePayload)
-		a.Response(d.MethodNotAllowed)
 		a.Response(d.Created, "/workitemlinktypes/.*", func() {
 			a.Media(workItemLinkType)
 		})
+		a.Response(d.OK, func() {
+			a.Media(workItemLinkType)
+			a.Headers(func() {
+				a.Header("X-Dry-Run", d.String, "Set to true when nothing was persisted")
+			})
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
+
+	a.Action("create_with_category", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/withCategory"),
+		)
+		a.Description(`Create a work item link category and one or more work item link types in it.
+
+The link category relationship of the given link types is ignored; all of them
+are created in the new category. Everything happens in a single transaction, so
+if the category or any of the link types cannot be created nothing is created at
+all. Link categories are shared by all spaces, so only the space owner is allowed
+to use this action. The new category is returned in the "included" array.`)
+		a.Payload(createWorkItemLinkTypesWithCategoryPayload)
+		a.Response(d.Created, workItemLinkTypeList)
 		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
 		a.Response(d.InternalServerError, JSONAPIErrors)
 		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
 		a.Response(d.Conflict, JSONAPIErrors)
 	})
 
@@ -200,9 +725,16 @@ var _ = a.Resource("work_item_link_type", func() {
 		a.Routing(
 			a.DELETE("/:wiltID"),
 		)
-		a.Description("Delete work item link type with given id.")
+		a.Description(`Delete work item link type with given id.
+
+A link type that is still used by work item links cannot be deleted unless
+"force" is set to true, in which case all links of that type are deleted as
+well.`)
 		a.Params(func() {
 			a.Param("wiltID", d.UUID, "wiltID")
+			a.Param("force", d.Boolean, "Delete the work item links of this type together with the type", func() {
+				a.Default(false)
+			})
 		})
 		a.Response(d.MethodNotAllowed)
 		a.Response(d.OK)
@@ -210,6 +742,114 @@ var _ = a.Resource("work_item_link_type", func() {
 		a.Response(d.InternalServerError, JSONAPIErrors)
 		a.Response(d.NotFound, JSONAPIErrors)
 		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
+
+	a.Action("delete_all", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE(""),
+		)
+		a.Description(`Delete all work item link types of the space in a single transaction,
+e.g. when the space is decommissioned. The link types of the system space are kept.
+
+If any of the link types is still used by work item links nothing is deleted unless
+"force" is set to true, in which case those links are deleted as well. Only the owner
+of the space can delete all of its link types. The number of deleted link types is
+returned in the "totalCount" meta.`)
+		a.Params(func() {
+			a.Param("force", d.Boolean, "Delete the work item links of the link types together with the types", func() {
+				a.Default(false)
+			})
+		})
+		a.Response(d.OK, workItemLinkTypeCount)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
+
+	a.Action("restore", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PATCH("/:wiltID/restore"),
+		)
+		a.Description("Restore the deleted work item link type with the given id.")
+		a.Params(func() {
+			a.Param("wiltID", d.UUID, "ID of the work item link type")
+		})
+		a.Response(d.OK, workItemLinkType)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
+
+	a.Action("copy_from", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/copyFrom/:sourceSpaceID"),
+		)
+		a.Description(`Copy all work item link types defined in the source space into this space.
+
+Link types of the system space are not copied. A link type whose name is
+already used by a link type of this space is skipped and its name is reported
+in the response meta. Everything happens in a single transaction. Only the
+space owner and the collaborators of the space are allowed to copy work item
+link types into it.`)
+		a.Params(func() {
+			a.Param("sourceSpaceID", d.UUID, "ID of the space to copy the work item link types from")
+		})
+		a.Response(d.OK, workItemLinkTypeCopyList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("export", func() {
+		a.Routing(
+			a.GET("/export"),
+		)
+		a.Description(`Export the work item link types defined in this space to a self-contained
+document that can be imported into another space, possibly in another
+environment, with the "import" action.
+
+Link types of the system space are not exported. Link categories are
+referenced by name instead of by ID.`)
+		a.Response(d.OK, workItemLinkTypeExport)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("import", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/import"),
+		)
+		a.Description(`Import the work item link types of a document created with the "export" action
+into this space.
+
+Link categories are looked up by name and created if they don't exist. A link
+type whose name is already used by a link type of this space is skipped and its
+name is reported in the response meta. Everything happens in a single
+transaction. Only the space owner and the collaborators of the space are
+allowed to import work item link types into it. Link categories are shared by
+all spaces, so only the space owner is allowed to import a document that needs
+a new link category.`)
+		a.Payload(workItemLinkTypeExport)
+		a.Response(d.OK, workItemLinkTypeCopyList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
 	})
 
 	a.Action("update", func() {
@@ -217,15 +857,21 @@ var _ = a.Resource("work_item_link_type", func() {
 		a.Routing(
 			a.PATCH("/:wiltID"),
 		)
-		a.Description("Update the given work item link type with given id.")
+		a.Description(`Update the given work item link type with given id.
+Optimistic locking is done with the ETag given in the If-Match header, if present;
+otherwise the version in the payload is used.`)
 		a.Params(func() {
 			a.Param("wiltID", d.UUID, "wiltID")
 		})
+		a.Headers(func() {
+			a.Header("If-Match", d.String, "ETag of the work item link type as last seen by the client")
+		})
 		a.Payload(updateWorkItemLinkTypePayload)
 		a.Response(d.MethodNotAllowed)
 		a.Response(d.OK, workItemLinkType)
 		a.Response(d.BadRequest, JSONAPIErrors)
 		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.PreconditionFailed, JSONAPIErrors)
 		a.Response(d.InternalServerError, JSONAPIErrors)
 		a.Response(d.NotFound, JSONAPIErrors)
 		a.Response(d.Unauthorized, JSONAPIErrors)
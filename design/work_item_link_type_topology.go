@@ -0,0 +1,54 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+// workItemLinkTypeTopologyData is the JSONAPI store for the data of a work
+// item link type topology.
+var workItemLinkTypeTopologyData = a.Type("WorkItemLinkTypeTopologyData", func() {
+	a.Description(`JSONAPI store for the data of a work item link type topology.
+See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("workitemlinktypetopologies")
+	})
+	a.Attribute("id", d.String, "Name of the topology as used in the topology attribute of a work item link type", func() {
+		a.Example("tree")
+	})
+	a.Attribute("attributes", workItemLinkTypeTopologyAttributes)
+	a.Required("type", "id", "attributes")
+})
+
+// workItemLinkTypeTopologyAttributes is the JSONAPI store for all the
+// "attributes" of a work item link type topology.
+var workItemLinkTypeTopologyAttributes = a.Type("WorkItemLinkTypeTopologyAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a work item link type topology.
+See also see http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("description", d.String, "Short description of how links of this topology can be created", func() {
+		a.Example("Links point from a parent to a child, every work item has at most one parent and links must not form a cycle.")
+	})
+	a.Required("description")
+})
+
+// workItemLinkTypeTopologyList holds all valid work item link type topologies
+var workItemLinkTypeTopologyList = JSONList(
+	"WorkItemLinkTypeTopology",
+	"Holds the list of valid work item link type topologies",
+	workItemLinkTypeTopologyData,
+	nil,
+	nil,
+)
+
+var _ = a.Resource("work_item_link_type_topology", func() {
+	a.BasePath("/workitemlinktypetopologies")
+	a.CanonicalActionName("list")
+	a.Action("list", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description("List the topologies that a work item link type can have.")
+		a.Response(d.OK, workItemLinkTypeTopologyList)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+	})
+})
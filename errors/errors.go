@@ -3,6 +3,7 @@ package errors
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	errs "github.com/pkg/errors"
 )
@@ -130,6 +131,27 @@ func IsVersionConflictError(err error) (bool, error) {
 	return true, e
 }
 
+// PreconditionFailedError means that a precondition given in the request
+// (e.g. an If-Match header) did not hold
+type PreconditionFailedError struct {
+	simpleError
+}
+
+// NewPreconditionFailedError returns the custom defined error of type PreconditionFailedError.
+func NewPreconditionFailedError(msg string) PreconditionFailedError {
+	return PreconditionFailedError{simpleError{msg}}
+}
+
+// IsPreconditionFailedError returns true if the cause of the given error can be
+// converted to an PreconditionFailedError, which is returned as the second result.
+func IsPreconditionFailedError(err error) (bool, error) {
+	e, ok := errs.Cause(err).(PreconditionFailedError)
+	if !ok {
+		return false, nil
+	}
+	return true, e
+}
+
 // BadParameterError means that a parameter was not as required
 type BadParameterError struct {
 	parameter        string
@@ -169,6 +191,56 @@ func IsBadParameterError(err error) (bool, error) {
 	return true, e
 }
 
+// Parameter returns the name of the parameter that was not as required
+func (err BadParameterError) Parameter() string {
+	return err.parameter
+}
+
+// BadParameterErrorCollection means that one or more parameters were not as
+// required. It allows all bad parameters of a request to be reported at once.
+type BadParameterErrorCollection struct {
+	Errors []BadParameterError
+}
+
+// Error implements the error interface
+func (err BadParameterErrorCollection) Error() string {
+	msgs := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Add appends the given bad parameters to the collection
+func (err *BadParameterErrorCollection) Add(badParams ...BadParameterError) {
+	err.Errors = append(err.Errors, badParams...)
+}
+
+// ErrorOrNil returns the collection if it holds any bad parameters and nil
+// otherwise
+func (err BadParameterErrorCollection) ErrorOrNil() error {
+	if len(err.Errors) == 0 {
+		return nil
+	}
+	return err
+}
+
+// NewBadParameterErrorCollection returns the custom defined error of type BadParameterErrorCollection.
+func NewBadParameterErrorCollection(badParams ...BadParameterError) BadParameterErrorCollection {
+	return BadParameterErrorCollection{Errors: badParams}
+}
+
+// IsBadParameterErrorCollection returns true if the cause of the given error
+// can be converted to an BadParameterErrorCollection, which is returned as the
+// second result.
+func IsBadParameterErrorCollection(err error) (bool, error) {
+	e, ok := errs.Cause(err).(BadParameterErrorCollection)
+	if !ok {
+		return false, nil
+	}
+	return true, e
+}
+
 // NewConversionError returns the custom defined error of type NewConversionError.
 func NewConversionError(msg string) ConversionError {
 	return ConversionError{simpleError{msg}}
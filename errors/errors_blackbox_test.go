@@ -42,6 +42,24 @@ func TestNewBadParameterError(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("Bad value for parameter '%s': '%v' (expected: '%v')", param, value, expectedValue), err.Error())
 }
 
+func TestNewBadParameterErrorCollection(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	t.Run("empty", func(t *testing.T) {
+		err := errors.NewBadParameterErrorCollection()
+		assert.Nil(t, err.ErrorOrNil())
+	})
+	t.Run("many", func(t *testing.T) {
+		err := errors.NewBadParameterErrorCollection(errors.NewBadParameterError("name", ""))
+		err.Add(errors.NewBadParameterError("topology", "foo").Expected("tree"))
+		require.Len(t, err.Errors, 2)
+		assert.Equal(t, "name", err.Errors[0].Parameter())
+		assert.Equal(t, "topology", err.Errors[1].Parameter())
+		assert.Equal(t, "Bad value for parameter 'name': ''; Bad value for parameter 'topology': 'foo' (expected: 'tree')", err.Error())
+		assert.Equal(t, err, err.ErrorOrNil())
+	})
+}
+
 func TestNewNotFoundError(t *testing.T) {
 	t.Parallel()
 	resource.Require(t, resource.UnitTest)
@@ -85,6 +103,9 @@ func TestIsXYError(t *testing.T) {
 		{"IsBadParameterError - is a BadParameterError", errors.NewBadParameterError("param", "actual"), errors.IsBadParameterError, true},
 		{"IsBadParameterError - is a wrapped BadParameterError", errs.Wrap(errs.Wrap(errors.NewBadParameterError("param", "actual"), "msg1"), "msg2"), errors.IsBadParameterError, true},
 		{"IsBadParameterError - is not a BadParameterError", errors.NewNotFoundError("foo", "bar"), errors.IsBadParameterError, false},
+		{"IsBadParameterErrorCollection - is a BadParameterErrorCollection", errors.NewBadParameterErrorCollection(errors.NewBadParameterError("param", "actual")), errors.IsBadParameterErrorCollection, true},
+		{"IsBadParameterErrorCollection - is a wrapped BadParameterErrorCollection", errs.Wrap(errs.Wrap(errors.NewBadParameterErrorCollection(errors.NewBadParameterError("param", "actual")), "msg1"), "msg2"), errors.IsBadParameterErrorCollection, true},
+		{"IsBadParameterErrorCollection - is not a BadParameterErrorCollection", errors.NewBadParameterError("param", "actual"), errors.IsBadParameterErrorCollection, false},
 		{"IsConversionError - is a ConversionError", errors.NewConversionError("some message"), errors.IsConversionError, true},
 		{"IsConversionError - is a wrapped ConversionError", errs.Wrap(errs.Wrap(errors.NewConversionError("some message"), "msg1"), "msg2"), errors.IsConversionError, true},
 		{"IsConversionError - is not a ConversionError", errors.NewNotFoundError("foo", "bar"), errors.IsConversionError, false},
@@ -100,6 +121,9 @@ func TestIsXYError(t *testing.T) {
 		{"IsVersionConflictError - is a VersionConflictError", errors.NewVersionConflictError("some message"), errors.IsVersionConflictError, true},
 		{"IsVersionConflictError - is a wrapped VersionConflictError", errs.Wrap(errs.Wrap(errors.NewVersionConflictError("some message"), "msg1"), "msg2"), errors.IsVersionConflictError, true},
 		{"IsVersionConflictError - is not a VersionConflictError", errors.NewInternalError(ctx, errs.New("some message")), errors.IsVersionConflictError, false},
+		{"IsPreconditionFailedError - is a PreconditionFailedError", errors.NewPreconditionFailedError("some message"), errors.IsPreconditionFailedError, true},
+		{"IsPreconditionFailedError - is a wrapped PreconditionFailedError", errs.Wrap(errs.Wrap(errors.NewPreconditionFailedError("some message"), "msg1"), "msg2"), errors.IsPreconditionFailedError, true},
+		{"IsPreconditionFailedError - is not a PreconditionFailedError", errors.NewVersionConflictError("some message"), errors.IsPreconditionFailedError, false},
 	}
 	for _, tc := range testCases {
 		// Note that we need to capture the range variable to ensure that tc
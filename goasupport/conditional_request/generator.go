@@ -374,12 +374,14 @@ func (ctx *{{$resp.Name}}) ConditionalRequest(entity {{$entity.DomainTypeName}},
 {{ end }}
 {{ if $entity.IsList }}
 // ConditionalEntities checks if the entities to return changed since the client's last call and returns a "304 Not Modified" response
-// or calls the 'nonConditionalCallback' function to carry on.
-func (ctx *{{$resp.Name}}) ConditionalEntities(entities []{{$entity.DomainTypeName}}, cacheControlConfig CacheControlConfig, nonConditionalCallback func() error) error {
-	conditionalEntities := make([]ConditionalRequestEntity, len(entities))
+// or calls the 'nonConditionalCallback' function to carry on. The optional 'additional' entities are not returned
+// but contribute to the ETag and the last modification time (e.g. the revision of the whole list).
+func (ctx *{{$resp.Name}}) ConditionalEntities(entities []{{$entity.DomainTypeName}}, cacheControlConfig CacheControlConfig, nonConditionalCallback func() error, additional ...ConditionalRequestEntity) error {
+	conditionalEntities := make([]ConditionalRequestEntity, len(entities), len(entities)+len(additional))
 	for i, entity := range entities {
 		conditionalEntities[i] = entity
 	}
+	conditionalEntities = append(conditionalEntities, additional...)
 	return doConditionalEntities(ctx, conditionalEntities, cacheControlConfig, nonConditionalCallback)
 }
 
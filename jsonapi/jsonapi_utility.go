@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/errors"
@@ -15,22 +16,24 @@ import (
 )
 
 const (
-	ErrorCodeNotFound          = "not_found"
-	ErrorCodeBadParameter      = "bad_parameter"
-	ErrorCodeVersionConflict   = "version_conflict"
-	ErrorCodeUnknownError      = "unknown_error"
-	ErrorCodeConversionError   = "conversion_error"
-	ErrorCodeInternalError     = "internal_error"
-	ErrorCodeUnauthorizedError = "unauthorized_error"
-	ErrorCodeForbiddenError    = "forbidden_error"
-	ErrorCodeJWTSecurityError  = "jwt_security_error"
-	ErrorCodeDataConflict      = "data_conflict_error"
+	ErrorCodeNotFound           = "not_found"
+	ErrorCodeBadParameter       = "bad_parameter"
+	ErrorCodeVersionConflict    = "version_conflict"
+	ErrorCodeUnknownError       = "unknown_error"
+	ErrorCodeConversionError    = "conversion_error"
+	ErrorCodeInternalError      = "internal_error"
+	ErrorCodeUnauthorizedError  = "unauthorized_error"
+	ErrorCodeForbiddenError     = "forbidden_error"
+	ErrorCodeJWTSecurityError   = "jwt_security_error"
+	ErrorCodeDataConflict       = "data_conflict_error"
+	ErrorCodePreconditionFailed = "precondition_failed_error"
 )
 
 // ErrorToJSONAPIError returns the JSONAPI representation
 // of an error and the HTTP status code that will be associated with it.
 // This function knows about the models package and the errors from there
-// as well as goa error classes.
+// as well as goa error classes. A BadParameterError points to the
+// parameter in its "source".
 func ErrorToJSONAPIError(ctx context.Context, err error) (app.JSONAPIError, int) {
 	cause := errs.Cause(err)
 	detail := cause.Error()
@@ -47,7 +50,7 @@ func ErrorToJSONAPIError(ctx context.Context, err error) (app.JSONAPIError, int)
 		code = ErrorCodeConversionError
 		title = "Conversion error"
 		statusCode = http.StatusBadRequest
-	case errors.BadParameterError:
+	case errors.BadParameterError, errors.BadParameterErrorCollection:
 		code = ErrorCodeBadParameter
 		title = "Bad parameter error"
 		statusCode = http.StatusBadRequest
@@ -59,6 +62,10 @@ func ErrorToJSONAPIError(ctx context.Context, err error) (app.JSONAPIError, int)
 		code = ErrorCodeDataConflict
 		title = "Data conflict error"
 		statusCode = http.StatusConflict
+	case errors.PreconditionFailedError:
+		code = ErrorCodePreconditionFailed
+		title = "Precondition failed error"
+		statusCode = http.StatusPreconditionFailed
 	case errors.InternalError:
 		code = ErrorCodeInternalError
 		title = "Internal error"
@@ -96,19 +103,48 @@ func ErrorToJSONAPIError(ctx context.Context, err error) (app.JSONAPIError, int)
 		Title:  &title,
 		Detail: detail,
 	}
+	if badParam, ok := cause.(errors.BadParameterError); ok {
+		jerr.Source = badParameterSource(badParam)
+	}
 	return jerr, statusCode
 }
 
 // ErrorToJSONAPIErrors is a convenience function if you
 // just want to return one error from the models package as a JSONAPI errors
 // array.
+//
+// A BadParameterErrorCollection is turned into one error per bad parameter,
+// each pointing to the parameter in its "source".
 func ErrorToJSONAPIErrors(ctx context.Context, err error) (*app.JSONAPIErrors, int) {
-	jerr, httpStatusCode := ErrorToJSONAPIError(ctx, err)
 	jerrors := app.JSONAPIErrors{}
+	if ok, e := errors.IsBadParameterErrorCollection(err); ok {
+		for _, badParam := range e.(errors.BadParameterErrorCollection).Errors {
+			jerr, _ := ErrorToJSONAPIError(ctx, badParam)
+			jerrors.Errors = append(jerrors.Errors, &jerr)
+		}
+		return &jerrors, http.StatusBadRequest
+	}
+	jerr, httpStatusCode := ErrorToJSONAPIError(ctx, err)
 	jerrors.Errors = append(jerrors.Errors, &jerr)
 	return &jerrors, httpStatusCode
 }
 
+// badParameterSource returns the JSONAPI error source of the given bad
+// parameter. Parameters of the request document (e.g. "data.attributes.name")
+// are referenced with a JSON pointer (e.g. "/data/attributes/name"), all others
+// as query parameters.
+func badParameterSource(err errors.BadParameterError) map[string]interface{} {
+	param := err.Parameter()
+	if param == "data" || strings.HasPrefix(param, "data.") {
+		return map[string]interface{}{
+			"pointer": "/" + strings.Replace(param, ".", "/", -1),
+		}
+	}
+	return map[string]interface{}{
+		"parameter": param,
+	}
+}
+
 // BadRequest represent a Context that can return a BadRequest HTTP status
 type BadRequestContext interface {
 	context.Context
@@ -145,6 +181,12 @@ type ConflictContext interface {
 	Conflict(*app.JSONAPIErrors) error
 }
 
+// PreconditionFailedContext represent a Context that can return a PreconditionFailed HTTP status
+type PreconditionFailedContext interface {
+	context.Context
+	PreconditionFailed(*app.JSONAPIErrors) error
+}
+
 // JSONErrorResponse auto maps the provided error to the correct response type
 // If all else fails, InternalServerError is returned
 func JSONErrorResponse(ctx InternalServerErrorContext, err error) error {
@@ -170,6 +212,10 @@ func JSONErrorResponse(ctx InternalServerErrorContext, err error) error {
 		if ctx, ok := ctx.(ConflictContext); ok {
 			return ctx.Conflict(jsonErr)
 		}
+	case http.StatusPreconditionFailed:
+		if ctx, ok := ctx.(PreconditionFailedContext); ok {
+			return ctx.PreconditionFailed(jsonErr)
+		}
 	}
 	sentry.Sentry().CaptureError(ctx, err)
 	return ctx.InternalServerError(jsonErr)
@@ -70,6 +70,14 @@ func TestErrorToJSONAPIError(t *testing.T) {
 	require.Equal(t, jsonapi.ErrorCodeForbiddenError, *jerr.Code)
 	require.Equal(t, strconv.Itoa(httpStatus), *jerr.Status)
 
+	// test precondition failed error
+	jerr, httpStatus = jsonapi.ErrorToJSONAPIError(nil, errors.NewPreconditionFailedError("foo"))
+	require.Equal(t, http.StatusPreconditionFailed, httpStatus)
+	require.NotNil(t, jerr.Code)
+	require.NotNil(t, jerr.Status)
+	require.Equal(t, jsonapi.ErrorCodePreconditionFailed, *jerr.Code)
+	require.Equal(t, strconv.Itoa(httpStatus), *jerr.Status)
+
 	// test unspecified error
 	jerr, httpStatus = jsonapi.ErrorToJSONAPIError(nil, fmt.Errorf("foobar"))
 	require.Equal(t, http.StatusInternalServerError, httpStatus)
@@ -78,3 +86,53 @@ func TestErrorToJSONAPIError(t *testing.T) {
 	require.Equal(t, jsonapi.ErrorCodeUnknownError, *jerr.Code)
 	require.Equal(t, strconv.Itoa(httpStatus), *jerr.Status)
 }
+
+func TestErrorToJSONAPIErrors(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	t.Run("single error", func(t *testing.T) {
+		jerrs, httpStatus := jsonapi.ErrorToJSONAPIErrors(nil, errors.NewBadParameterError("foo", "bar"))
+		require.Equal(t, http.StatusBadRequest, httpStatus)
+		require.Len(t, jerrs.Errors, 1)
+		require.Equal(t, map[string]interface{}{"parameter": "foo"}, jerrs.Errors[0].Source)
+	})
+
+	t.Run("single error of the payload", func(t *testing.T) {
+		err := errs.Wrap(errors.NewBadParameterError("data.attributes.name", ""), "validation failed")
+		jerrs, httpStatus := jsonapi.ErrorToJSONAPIErrors(nil, err)
+		require.Equal(t, http.StatusBadRequest, httpStatus)
+		require.Len(t, jerrs.Errors, 1)
+		require.Equal(t, map[string]interface{}{"pointer": "/data/attributes/name"}, jerrs.Errors[0].Source)
+	})
+
+	t.Run("other errors have no source", func(t *testing.T) {
+		jerrs, _ := jsonapi.ErrorToJSONAPIErrors(nil, errors.NewNotFoundError("foo", "bar"))
+		require.Len(t, jerrs.Errors, 1)
+		require.Nil(t, jerrs.Errors[0].Source)
+	})
+
+	t.Run("bad parameter collection", func(t *testing.T) {
+		err := errors.NewBadParameterErrorCollection(
+			errors.NewBadParameterError("data.attributes.name", ""),
+			errors.NewBadParameterError("data.attributes.topology", "foo").Expected("tree"),
+			errors.NewBadParameterError("filter", "bar"),
+		)
+		jerrs, httpStatus := jsonapi.ErrorToJSONAPIErrors(nil, errs.Wrap(err, "validation failed"))
+		require.Equal(t, http.StatusBadRequest, httpStatus)
+		require.Len(t, jerrs.Errors, 3)
+		expectedSources := []map[string]interface{}{
+			{"pointer": "/data/attributes/name"},
+			{"pointer": "/data/attributes/topology"},
+			{"parameter": "filter"},
+		}
+		for i, jerr := range jerrs.Errors {
+			require.NotNil(t, jerr.Code)
+			require.Equal(t, jsonapi.ErrorCodeBadParameter, *jerr.Code)
+			require.NotNil(t, jerr.Status)
+			require.Equal(t, strconv.Itoa(http.StatusBadRequest), *jerr.Status)
+			require.Equal(t, err.Errors[i].Error(), jerr.Detail)
+			require.Equal(t, expectedSources[i], jerr.Source)
+		}
+	})
+}
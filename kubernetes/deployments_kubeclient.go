@@ -2,25 +2,36 @@ package kubernetes
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	v1 "k8s.io/client-go/pkg/api/v1"
 	rest "k8s.io/client-go/rest"
 
 	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/goadesign/goa/client"
+	"github.com/goadesign/goa/middleware"
 	errs "github.com/pkg/errors"
 )
 
@@ -36,6 +47,24 @@ type KubeClientConfig struct {
 	// Timeout used for communicating with Kubernetes and OpenShift API servers,
 	// a value of zero indicates no timeout
 	Timeout time.Duration // TODO determine good timeout to set here, or possibly make configurable
+	// ID of the incoming WIT request on whose behalf the client is used (optional),
+	// it is forwarded to the OpenShift API server and included in logs and errors
+	RequestID string
+	// URL of an HTTP proxy to reach the API servers through (optional), if not set
+	// the proxy is taken from the environment
+	ProxyURL string
+	// PEM encoded certificates of the CAs to trust for the API servers (optional),
+	// if not set the system's trusted CAs are used
+	CABundle []byte
+	// Whether the certificates of the API servers are accepted without verification
+	InsecureSkipTLSVerify bool
+	// Number of times a request failing with a network error, a rate limit or a server
+	// error is retried with exponential backoff, zero disables retries. No retry is
+	// started that would exceed Timeout.
+	MaxRetries int
+	// Annotation of replication controllers holding the version of the deployment
+	// that created them, defaults to "openshift.io/deployment-config.latest-version"
+	DeploymentVersionAnnotation string
 	// Provides access to the Kubernetes REST API, uses default implementation if not set
 	KubeRESTAPIGetter
 	// Provides access to the metrics API, uses default implementation if not set
@@ -46,12 +75,12 @@ type KubeClientConfig struct {
 
 // KubeRESTAPIGetter has a method to access the KubeRESTAPI interface
 type KubeRESTAPIGetter interface {
-	GetKubeRESTAPI(config *KubeClientConfig) (KubeRESTAPI, error)
+	GetKubeRESTAPI(ctx context.Context, config *KubeClientConfig) (KubeRESTAPI, error)
 }
 
 // OpenShiftRESTAPIGetter has a method to access the OpenShiftRESTAPI interface
 type OpenShiftRESTAPIGetter interface {
-	GetOpenShiftRESTAPI(config *KubeClientConfig) (OpenShiftRESTAPI, error)
+	GetOpenShiftRESTAPI(ctx context.Context, config *KubeClientConfig) (OpenShiftRESTAPI, error)
 }
 
 // MetricsGetter has a method to access the Metrics interface
@@ -64,28 +93,180 @@ type KubeClientInterface interface {
 	GetSpace(spaceName string) (*app.SimpleSpace, error)
 	GetApplication(spaceName string, appName string) (*app.SimpleApp, error)
 	GetDeployment(spaceName string, appName string, envName string) (*app.SimpleDeployment, error)
-	ScaleDeployment(spaceName string, appName string, envName string, deployNumber int) (*int, error)
+	ScaleDeployment(spaceName string, appName string, envName string, replicas int) (*int, error)
+	RollbackDeployment(spaceName string, appName string, envName string, toVersion int) (*int, error)
+	GetDeploymentRolloutStatus(spaceName string, appName string, envName string) (*RolloutStatus, error)
+	GetDeploymentVersionHistory(spaceName string, appName string, envName string) ([]*DeploymentVersion, error)
+	WatchDeployment(ctx context.Context, spaceName string, appName string, envName string) (<-chan *DeploymentEvent, error)
 	GetDeploymentStats(spaceName string, appName string, envName string,
 		startTime time.Time) (*app.SimpleDeploymentStats, error)
+	GetDeploymentStatsForEnvironments(ctx context.Context, spaceName string, appName string, envNames []string,
+		startTime time.Time) (map[string]*app.SimpleDeploymentStats, map[string]error)
 	GetDeploymentStatSeries(spaceName string, appName string, envName string, startTime time.Time,
 		endTime time.Time, limit int) (*app.SimpleDeploymentStatSeries, error)
 	DeleteDeployment(spaceName string, appName string, envName string) error
 	GetEnvironments() ([]*app.SimpleEnvironment, error)
+	GetSpaceEnvironments(spaceName string) (map[string]string, error)
 	GetEnvironment(envName string) (*app.SimpleEnvironment, error)
+	GetEnvironmentQuota(spaceName string, envName string) (*EnvironmentQuota, error)
+	GetEnvironmentMetrics(spaceName string, envName string) (*EnvironmentMetrics, error)
+	GetEnvironmentMetricsRange(spaceName string, envName string, startTime time.Time, endTime time.Time,
+		limit int) (*EnvironmentMetricsRange, error)
+	GetPodRestartCounts(spaceName string, appName string, envName string) (*PodRestartCounts, error)
+	GetDeploymentPods(spaceName string, appName string, envName string, labelSelector string, offset int, limit int) (*DeploymentPods, error)
+	GetDeploymentImage(spaceName string, appName string, envName string) (map[string]*ContainerImage, error)
+	GetDeploymentPodLogs(spaceName string, appName string, envName string, podName string, follow bool) (io.ReadCloser, error)
+	GetBuildLogs(spaceName string, appName string, buildNumber int) (io.ReadCloser, error)
+	DescribeConfig() *KubeClientDescription
+	Ping(ctx context.Context) (time.Duration, error)
+	WithTimeout(ctx context.Context, timeout time.Duration) (KubeClientInterface, error)
 	Close()
 }
 
+// PodRestartCounts holds the number of container restarts for the pods of
+// an application's current deployment
+type PodRestartCounts struct {
+	// Sum of the restart counts of all containers, keyed by pod name
+	Pods map[string]int
+	// Sum of the restart counts of all pods
+	Total int
+}
+
+// DeploymentPods holds a page of the pods of an application's current deployment
+type DeploymentPods struct {
+	// Pods within the requested page, ordered by creation time and then by name
+	Pods []*v1.Pod
+	// Number of pods of the deployment across all pages
+	Total int
+}
+
+// RolloutPhase is the state of the latest rollout of a deployment
+type RolloutPhase string
+
+// Phases of a rollout, following the phases of OpenShift deployments
+const (
+	RolloutPending  RolloutPhase = "Pending"
+	RolloutRunning  RolloutPhase = "Running"
+	RolloutComplete RolloutPhase = "Complete"
+	RolloutFailed   RolloutPhase = "Failed"
+)
+
+// RolloutStatus holds the progress of the latest rollout of an application
+type RolloutStatus struct {
+	Phase RolloutPhase
+	// Version of the deployment being rolled out, zero if there was no rollout yet
+	Version int
+	// Number of pods of the rollout that are ready
+	ReadyReplicas int
+	// Number of pods the rollout aims for
+	DesiredReplicas int
+}
+
+// DeploymentVersion holds one version in the history of a deployment, which is
+// represented by an RC or ReplicaSet
+type DeploymentVersion struct {
+	// Version of the deployment, zero if the RC has no version annotation
+	Version int64
+	// Name of the RC or ReplicaSet
+	Name string
+	// When the version was rolled out
+	Created time.Time
+	// Number of pods of this version that exist, are ready, and are desired
+	Replicas        int
+	ReadyReplicas   int
+	DesiredReplicas int
+}
+
+// ContainerImage holds the image of a container of an application's current
+// deployment
+type ContainerImage struct {
+	// Image reference from the pod template
+	Image string
+	// Image reference without its tag and digest, e.g. registry:5000/project/image
+	Name string
+	// Tag of the image reference, empty if it has none
+	Tag string
+	// Digest of the image reference, or else the digest that the running pods
+	// resolved the reference to. Empty if neither is known yet.
+	Digest string
+}
+
+// EnvironmentQuota holds the usage and limits of the resource quotas of an
+// environment. Resources that no quota limits, or all of them if the environment
+// has no quota, are left at their zero value.
+type EnvironmentQuota struct {
+	// Limits of CPU cores
+	CPU QuotaUsage
+	// Limits of memory in bytes
+	Memory QuotaUsage
+	// Number of pods
+	Pods QuotaUsage
+}
+
+// EnvironmentMetrics holds the current resource usage summed over all pods of
+// an environment
+type EnvironmentMetrics struct {
+	// Usage of CPU cores
+	CPU float64
+	// Usage of memory in bytes
+	Memory float64
+	// Whether the metrics server reported the usage. If not, CPU and Memory are
+	// zero and must not be taken for the actual usage.
+	Available bool
+}
+
+// EnvironmentMetricsRange holds the CPU and memory usage of all pods in an
+// environment over a period of time
+type EnvironmentMetricsRange struct {
+	// Usage of CPU cores, one value per minute
+	CPU []*app.TimedNumberTuple
+	// Usage of memory in bytes, one value per minute
+	Memory []*app.TimedNumberTuple
+	// The period that the usage was requested for. It starts later than
+	// requested if the metrics server doesn't keep metrics that old.
+	Start time.Time
+	End   time.Time
+	// Whether the metrics server reported the usage. If not, CPU and Memory
+	// are empty.
+	Available bool
+}
+
+// QuotaUsage holds the usage and the hard limit of a resource subject to a quota
+type QuotaUsage struct {
+	Used float64
+	Hard float64
+	// Whether any quota limits this resource
+	Limited bool
+}
+
+// KubeClientDescription holds the parts of a KubeClientConfig that are safe to
+// show for diagnostic purposes
+type KubeClientDescription struct {
+	// URL to the Kubernetes cluster's API server
+	ClusterURL string
+	// Kubernetes namespace in the cluster of type 'user'
+	UserNamespace string
+	// Timeout used for communicating with Kubernetes and OpenShift API servers
+	Timeout time.Duration
+	// The bearer token with everything but its last 4 characters masked
+	RedactedBearerToken string
+}
+
 type kubeClient struct {
 	config *KubeClientConfig
 	envMap map[string]string
 	KubeRESTAPI
 	Metrics
 	OpenShiftRESTAPI
+	// Whether Metrics belongs to the client this one was derived from
+	sharedMetrics bool
 }
 
 // KubeRESTAPI collects methods that call out to the Kubernetes API server over the network
 type KubeRESTAPI interface {
 	corev1.CoreV1Interface
+	GetPodLogs(namespace string, name string, opts *v1.PodLogOptions) (io.ReadCloser, error)
+	WatchClient(ctx context.Context) (corev1.CoreV1Interface, error)
 }
 
 type kubeAPIClient struct {
@@ -96,23 +277,38 @@ type kubeAPIClient struct {
 // OpenShiftRESTAPI collects methods that call out to the OpenShift API server over the network
 type OpenShiftRESTAPI interface {
 	GetBuildConfigs(namespace string, labelSelector string) (map[string]interface{}, error)
+	GetBuildConfig(namespace string, name string) (map[string]interface{}, error)
+	GetBuildLog(namespace string, name string) (io.ReadCloser, error)
 	GetDeploymentConfig(namespace string, name string) (map[string]interface{}, error)
 	DeleteDeploymentConfig(namespace string, name string, opts *metaV1.DeleteOptions) error
 	GetDeploymentConfigScale(namespace string, name string) (map[string]interface{}, error)
 	SetDeploymentConfigScale(namespace string, name string, scale map[string]interface{}) error
+	RollbackDeploymentConfig(namespace string, name string, rollback map[string]interface{}) (map[string]interface{}, error)
+	UpdateDeploymentConfig(namespace string, name string, dc map[string]interface{}) error
+	GetKubeDeployment(namespace string, name string) (map[string]interface{}, error)
+	GetKubeDeploymentScale(namespace string, name string) (map[string]interface{}, error)
+	SetKubeDeploymentScale(namespace string, name string, scale map[string]interface{}) error
+	UpdateKubeDeployment(namespace string, name string, deploy map[string]interface{}) error
+	GetReplicaSets(namespace string) (map[string]interface{}, error)
 	GetRoutes(namespace string, labelSelector string) (map[string]interface{}, error)
 	DeleteRoute(namespace string, name string, opts *metaV1.DeleteOptions) error
 }
 
 type openShiftAPIClient struct {
+	// Requests are aborted once this context is cancelled
+	ctx        context.Context
 	config     *KubeClientConfig
 	httpClient *http.Client
 }
 
 type deployment struct {
+	// UID of the DeploymentConfig, or of the Kubernetes Deployment if there is no DC
 	dcUID      types.UID
 	appVersion string
-	current    *v1.ReplicationController
+	// Current RC of the DC, or current ReplicaSet of the Deployment converted to an RC
+	current *v1.ReplicationController
+	// Whether current was converted from a ReplicaSet
+	replicaSet bool
 }
 
 type route struct {
@@ -130,10 +326,14 @@ var _ KubeClientInterface = &kubeClient{}
 var _ KubeClientInterface = (*kubeClient)(nil)
 
 // Receiver for default implementation of KubeRESTAPIGetter and MetricsGetter
-type defaultGetter struct{}
+type defaultGetter struct {
+	// Transports shared between REST API clients, nil if each client uses its own
+	cache *transportCache
+}
 
-// NewKubeClient creates a KubeClientInterface given a configuration
-func NewKubeClient(config *KubeClientConfig) (KubeClientInterface, error) {
+// NewKubeClient creates a KubeClientInterface given a configuration. Requests to the
+// Kubernetes and OpenShift API servers are aborted once the given context is cancelled.
+func NewKubeClient(ctx context.Context, config *KubeClientConfig) (KubeClientInterface, error) {
 	// Use default implementation if no KubernetesGetter is specified
 	if config.KubeRESTAPIGetter == nil {
 		config.KubeRESTAPIGetter = &defaultGetter{}
@@ -142,11 +342,14 @@ func NewKubeClient(config *KubeClientConfig) (KubeClientInterface, error) {
 	if config.OpenShiftRESTAPIGetter == nil {
 		config.OpenShiftRESTAPIGetter = &defaultGetter{}
 	}
-	kubeAPI, err := config.GetKubeRESTAPI(config)
+	if len(config.DeploymentVersionAnnotation) == 0 {
+		config.DeploymentVersionAnnotation = deploymentVersionAnnotation
+	}
+	kubeAPI, err := config.GetKubeRESTAPI(ctx, config)
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
-	osAPI, err := config.GetOpenShiftRESTAPI(config)
+	osAPI, err := config.GetOpenShiftRESTAPI(ctx, config)
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
@@ -187,11 +390,26 @@ func NewKubeClient(config *KubeClientConfig) (KubeClientInterface, error) {
 	return kubeClient, nil
 }
 
-func (*defaultGetter) GetKubeRESTAPI(config *KubeClientConfig) (KubeRESTAPI, error) {
+func (g *defaultGetter) GetKubeRESTAPI(ctx context.Context, config *KubeClientConfig) (KubeRESTAPI, error) {
+	transport, err := g.getTransport(config)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
 	restConfig := &rest.Config{
 		Host:        config.ClusterURL,
 		BearerToken: config.BearerToken,
 		Timeout:     config.Timeout,
+		// The typed clients don't accept a context, so attach it to every request
+		WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+			if config.MaxRetries > 0 {
+				rt = newRetryRoundTripper(rt, config)
+			}
+			return &contextRoundTripper{
+				ctx:          ctx,
+				RoundTripper: rt,
+			}
+		},
+		Transport: transport,
 	}
 	coreV1Client, err := corev1.NewForConfig(restConfig)
 	if err != nil {
@@ -204,26 +422,215 @@ func (*defaultGetter) GetKubeRESTAPI(config *KubeClientConfig) (KubeRESTAPI, err
 	return client, nil
 }
 
-func (*defaultGetter) GetOpenShiftRESTAPI(config *KubeClientConfig) (OpenShiftRESTAPI, error) {
-	// Equivalent to http.DefaultClient with added timeout
+// GetPodLogs streams the logs of a pod. Unless the logs are followed, the
+// configured timeout applies to the whole request. Followed logs are streamed
+// without a timeout until the caller closes the stream.
+func (kc *kubeAPIClient) GetPodLogs(namespace string, name string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+	pods := kc.Pods(namespace)
+	if opts.Follow {
+		streamConfig := *kc.restConfig
+		streamConfig.Timeout = 0
+		streamClient, err := corev1.NewForConfig(&streamConfig)
+		if err != nil {
+			return nil, errs.WithStack(err)
+		}
+		pods = streamClient.Pods(namespace)
+	}
+	logs, err := pods.GetLogs(name, opts).Stream()
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	return logs, nil
+}
+
+// WatchClient returns a client for long-running watches. Its requests don't
+// time out and aren't retried, but abort once the given context is cancelled.
+func (kc *kubeAPIClient) WatchClient(ctx context.Context) (corev1.CoreV1Interface, error) {
+	watchConfig := *kc.restConfig
+	watchConfig.Timeout = 0
+	watchConfig.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		return &contextRoundTripper{
+			ctx:          ctx,
+			RoundTripper: rt,
+		}
+	}
+	watchClient, err := corev1.NewForConfig(&watchConfig)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	return &kubeAPIClient{
+		CoreV1Interface: watchClient,
+		restConfig:      &watchConfig,
+	}, nil
+}
+
+// contextRoundTripper makes requests abort once its context is cancelled
+type contextRoundTripper struct {
+	ctx context.Context
+	http.RoundTripper
+}
+
+func (rt *contextRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.RoundTripper.RoundTrip(req.WithContext(rt.ctx))
+	if err != nil && rt.ctx.Err() != nil {
+		return nil, rt.ctx.Err()
+	}
+	return resp, err
+}
+
+func (g *defaultGetter) GetOpenShiftRESTAPI(ctx context.Context, config *KubeClientConfig) (OpenShiftRESTAPI, error) {
+	transport, err := g.getTransport(config)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	// Equivalent to http.DefaultClient with added timeout, unless the transport is cached
 	httpClient := &http.Client{
 		Timeout: config.Timeout,
 	}
+	if transport != nil {
+		httpClient.Transport = transport
+	}
+	if config.MaxRetries > 0 {
+		httpClient.Transport = newRetryRoundTripper(httpClient.Transport, config)
+	}
 	client := &openShiftAPIClient{
+		ctx:        ctx,
 		config:     config,
 		httpClient: httpClient,
 	}
 	return client, nil
 }
 
+// getTransport returns the cached transport for the given configuration, or a new
+// one if the configuration needs a custom transport. If neither is the case, nil is
+// returned so that the default transport is used.
+func (g *defaultGetter) getTransport(config *KubeClientConfig) (http.RoundTripper, error) {
+	if g.cache != nil {
+		return g.cache.get(config)
+	} else if len(config.ProxyURL) > 0 || len(config.CABundle) > 0 || config.InsecureSkipTLSVerify {
+		return newHTTPTransport(config)
+	}
+	return nil, nil
+}
+
+// newHTTPTransport creates a transport that applies the proxy and TLS settings of the
+// given configuration, with the defaults of http.DefaultTransport otherwise
+func newHTTPTransport(config *KubeClientConfig) (*http.Transport, error) {
+	proxy := http.ProxyFromEnvironment
+	if len(config.ProxyURL) > 0 {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, errs.Wrapf(err, "invalid proxy URL %s", config.ProxyURL)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipTLSVerify,
+	}
+	if len(config.CABundle) > 0 {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(config.CABundle) {
+			return nil, errs.New("no valid PEM encoded certificates in CA bundle")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	transport := &http.Transport{
+		Proxy:                 proxy,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   10 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		MaxIdleConns:          100,
+	}
+	return transport, nil
+}
+
 func (*defaultGetter) GetMetrics(config *MetricsClientConfig) (Metrics, error) {
 	return NewMetricsClient(config)
 }
 
 // Close releases any resources held by this KubeClientInterface
 func (kc *kubeClient) Close() {
-	// Metrics client needs to be closed to stop Hawkular go-routine from spinning
-	kc.Metrics.Close()
+	// Metrics client needs to be closed to stop Hawkular go-routine from spinning,
+	// unless it is still used by the client this one was derived from
+	if !kc.sharedMetrics {
+		kc.Metrics.Close()
+	}
+}
+
+// WithTimeout returns a client that uses the given timeout instead of the configured
+// one, zero meaning no timeout, and binds its requests to the given context. This
+// client keeps its timeout, so that a single call can be given more or less time by
+// making it on the returned client. The timeout is honored by every method that calls
+// the Kubernetes or OpenShift API servers, but not by requests to the metrics API,
+// which has its own fixed timeout. Followed pod logs are never subject to a timeout.
+// The returned client shares the metrics client of this one, which is only released
+// by closing this client.
+func (kc *kubeClient) WithTimeout(ctx context.Context, timeout time.Duration) (KubeClientInterface, error) {
+	config := *kc.config
+	config.Timeout = timeout
+	kubeAPI, err := config.GetKubeRESTAPI(ctx, &config)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	osAPI, err := config.GetOpenShiftRESTAPI(ctx, &config)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	result := &kubeClient{
+		config:           &config,
+		envMap:           kc.envMap,
+		KubeRESTAPI:      kubeAPI,
+		Metrics:          kc.Metrics,
+		OpenShiftRESTAPI: osAPI,
+		sharedMetrics:    true,
+	}
+	return result, nil
+}
+
+// DescribeConfig returns the configuration used by this client for diagnostic
+// purposes. The bearer token is redacted and never returned in full.
+func (kc *kubeClient) DescribeConfig() *KubeClientDescription {
+	return &KubeClientDescription{
+		ClusterURL:          kc.config.ClusterURL,
+		UserNamespace:       kc.config.UserNamespace,
+		Timeout:             kc.config.Timeout,
+		RedactedBearerToken: redactToken(kc.config.BearerToken),
+	}
+}
+
+// Number of trailing characters of a token that are left visible when redacting it
+const visibleTokenChars = 4
+
+// redactToken masks all but the last few characters of the given token. Tokens
+// that are too short to hide anything are masked completely.
+func redactToken(token string) string {
+	if len(token) <= visibleTokenChars {
+		return strings.Repeat("*", len(token))
+	}
+	hidden := len(token) - visibleTokenChars
+	return strings.Repeat("*", hidden) + token[hidden:]
+}
+
+// Ping checks whether the cluster can be reached with the configured credentials by
+// retrieving the user's namespace, and returns the round-trip time of that request.
+// The request is bound to the given context and the configured timeout, and is never
+// retried, so that an unreachable cluster is reported promptly.
+func (kc *kubeClient) Ping(ctx context.Context) (time.Duration, error) {
+	pingConfig := *kc.config
+	pingConfig.MaxRetries = 0
+	kubeAPI, err := pingConfig.GetKubeRESTAPI(ctx, &pingConfig)
+	if err != nil {
+		return 0, errs.WithStack(err)
+	}
+
+	start := time.Now()
+	_, err = kubeAPI.Namespaces().Get(pingConfig.UserNamespace, metaV1.GetOptions{})
+	latency := time.Since(start)
+	if err != nil {
+		return latency, errs.Wrapf(err, "could not reach cluster %s", pingConfig.ClusterURL)
+	}
+	return latency, nil
 }
 
 // GetSpace returns a space matching the provided name, containing all applications that belong to it
@@ -283,14 +690,34 @@ func (kc *kubeClient) GetApplication(spaceName string, appName string) (*app.Sim
 }
 
 // ScaleDeployment adjusts the desired number of replicas for a specified application, returning the
-// previous number of desired replicas
-func (kc *kubeClient) ScaleDeployment(spaceName string, appName string, envName string, deployNumber int) (*int, error) {
+// previous number of desired replicas. The DeploymentConfig of the application is scaled, or its
+// Kubernetes Deployment if there is no DeploymentConfig. A NotFoundError is returned if neither exists.
+func (kc *kubeClient) ScaleDeployment(spaceName string, appName string, envName string, replicas int) (*int, error) {
+	if replicas < 0 {
+		return nil, errors.NewBadParameterError("replicas", replicas).Expected("a non-negative number")
+	}
 	envNS, err := kc.getEnvironmentNamespace(envName)
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
-	// Look up the Scale for the DeploymentConfig corresponding to the application name in the provided environment
-	scale, err := kc.GetDeploymentConfigScale(envNS, appName)
+	getScale, setScale := kc.GetDeploymentConfigScale, kc.SetDeploymentConfigScale
+	dc, err := kc.getDeploymentConfig(envNS, appName, spaceName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if dc == nil {
+		deploy, err := kc.getKubeDeployment(envNS, appName, spaceName)
+		if err != nil {
+			return nil, errs.WithStack(err)
+		} else if deploy == nil {
+			if err := kc.checkNamespaceExists(envNS); err != nil {
+				return nil, err
+			}
+			return nil, errors.NewNotFoundError("deployment", appName)
+		}
+		getScale, setScale = kc.GetKubeDeploymentScale, kc.SetKubeDeploymentScale
+	}
+	// Look up the Scale for the deployment corresponding to the application name in the provided environment
+	scale, err := getScale(envNS, appName)
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
@@ -306,18 +733,18 @@ func (kc *kubeClient) ScaleDeployment(spaceName string, appName string, envName
 		return nil, errs.New("invalid deployment config returned from endpoint: missing 'spec'")
 	}
 
-	replicas, pres := spec["replicas"]
+	oldReplicasVal, pres := spec["replicas"]
 	oldReplicas := 0 // replicas property may be missing from spec if set to 0
 	if pres {
-		oldReplicasFlt, ok := replicas.(float64)
+		oldReplicasFlt, ok := oldReplicasVal.(float64)
 		if !ok {
 			return nil, errs.New("invalid deployment config returned from endpoint: 'replicas' is not a number")
 		}
 		oldReplicas = int(oldReplicasFlt)
 	}
-	spec["replicas"] = deployNumber
+	spec["replicas"] = replicas
 
-	err = kc.SetDeploymentConfigScale(envNS, appName, scale)
+	err = setScale(envNS, appName, scale)
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
@@ -327,8 +754,8 @@ func (kc *kubeClient) ScaleDeployment(spaceName string, appName string, envName
 		"application_name":  appName,
 		"environment_name":  envName,
 		"old_replica_count": oldReplicas,
-		"new_replica_count": deployNumber,
-	}, "scaled deployment to %d replicas", deployNumber)
+		"new_replica_count": replicas,
+	}, "scaled deployment to %d replicas", replicas)
 
 	return &oldReplicas, nil
 }
@@ -343,6 +770,324 @@ func (oc *openShiftAPIClient) SetDeploymentConfigScale(namespace string, name st
 	return oc.sendResource(dcScaleURL, "PUT", scale)
 }
 
+func (oc *openShiftAPIClient) GetKubeDeploymentScale(namespace string, name string) (map[string]interface{}, error) {
+	deployScaleURL := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s/scale", namespace, name)
+	return oc.getResource(deployScaleURL, false)
+}
+
+func (oc *openShiftAPIClient) SetKubeDeploymentScale(namespace string, name string, scale map[string]interface{}) error {
+	deployScaleURL := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s/scale", namespace, name)
+	return oc.sendResource(deployScaleURL, "PUT", scale)
+}
+
+// RollbackDeployment rolls the deployment of an application back to the given version, or to the
+// version preceding the current one if toVersion is zero. DeploymentConfigs are rolled back through
+// the OpenShift rollback API, while Kubernetes Deployments get the pod template of the ReplicaSet with
+// the given revision re-applied. The version of the new deployment that performs the rollback is
+// returned. A NotFoundError is returned if the application or the version doesn't exist.
+func (kc *kubeClient) RollbackDeployment(spaceName string, appName string, envName string, toVersion int) (*int, error) {
+	if toVersion < 0 {
+		return nil, errors.NewBadParameterError("toVersion", toVersion).Expected("a non-negative number")
+	}
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	deploy, err := kc.getCurrentDeployment(spaceName, appName, envNS)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if deploy == nil || deploy.current == nil {
+		return nil, errors.NewNotFoundError("deployment", appName)
+	}
+
+	// Find the RC or ReplicaSet of the version to roll back to
+	rcs, annotation, err := kc.getDeploymentRCs(envNS, deploy)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	target, targetVersion, latestVersion, err := getRollbackTarget(rcs, deploy.current, annotation, int64(toVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	if deploy.replicaSet {
+		err = kc.rollbackKubeDeployment(envNS, appName, target)
+	} else {
+		err = kc.rollbackDeploymentConfig(envNS, appName, targetVersion)
+	}
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	// The rollback is performed by a new deployment following the latest one
+	newVersion := int(latestVersion + 1)
+	log.Info(nil, map[string]interface{}{
+		"space_name":       spaceName,
+		"application_name": appName,
+		"environment_name": envName,
+		"target_version":   targetVersion,
+		"new_version":      newVersion,
+	}, "rolled back deployment to version %d", targetVersion)
+
+	return &newVersion, nil
+}
+
+// getRollbackTarget returns the RC with the given version among the RCs of a deployment, or the one
+// preceding the current RC if toVersion is zero, along with its version and the latest version of all RCs.
+// RCs with an invalid version are skipped, like getMostRecentByDeploymentVersion does.
+func getRollbackTarget(rcs []v1.ReplicationController, current *v1.ReplicationController, annotation string,
+	toVersion int64) (target *v1.ReplicationController, targetVersion int64, latestVersion int64, err error) {
+	currentVersionPtr, err := parseDeploymentVersion(current, annotation)
+	if err != nil {
+		return nil, 0, 0, err
+	} else if currentVersionPtr == nil {
+		return nil, 0, 0, errs.Errorf("%s annotation missing from current deployment %s", annotation, current.Name)
+	}
+	currentVersion := *currentVersionPtr
+	if toVersion == currentVersion {
+		return nil, 0, 0, errors.NewBadParameterError("toVersion", toVersion).Expected("a version other than the current one")
+	}
+
+	latestVersion = currentVersion
+	for idx := range rcs {
+		rc := &rcs[idx]
+		versionPtr, err := parseDeploymentVersion(rc, annotation)
+		if err != nil {
+			log.Warn(nil, map[string]interface{}{
+				"rc_name":    rc.Name,
+				"annotation": annotation,
+				"err":        err,
+			}, "skipping RC with invalid deployment version")
+			continue
+		} else if versionPtr == nil {
+			continue
+		}
+		version := *versionPtr
+		if version > latestVersion {
+			latestVersion = version
+		}
+		if toVersion == 0 {
+			// Look for the newest version older than the current one
+			if version < currentVersion && (target == nil || version > targetVersion) {
+				target = rc
+				targetVersion = version
+			}
+		} else if version == toVersion {
+			target = rc
+			targetVersion = version
+		}
+	}
+
+	if target == nil {
+		if toVersion == 0 {
+			return nil, 0, 0, errors.NewBadParameterError("toVersion", toVersion).Expected("a previous version to exist")
+		}
+		return nil, 0, 0, errors.NewNotFoundError("deployment version", strconv.FormatInt(toVersion, 10))
+	}
+	return target, targetVersion, latestVersion, nil
+}
+
+// GetDeploymentRolloutStatus returns the phase and progress of the latest rollout of an
+// application. Unlike most other methods, this looks at the newest RC or ReplicaSet even if
+// it's not the current one, since a failed rollout leaves the previous one in place. A
+// NotFoundError is returned if the application doesn't exist.
+func (kc *kubeClient) GetDeploymentRolloutStatus(spaceName string, appName string, envName string) (*RolloutStatus, error) {
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	deploy, err := kc.getCurrentDeployment(spaceName, appName, envNS)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if deploy == nil {
+		return nil, errors.NewNotFoundError("deployment", appName)
+	}
+
+	rcs, annotation, err := kc.getDeploymentRCs(envNS, deploy)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	candidates := make(map[string]*v1.ReplicationController, len(rcs))
+	for idx := range rcs {
+		candidates[rcs[idx].Name] = &rcs[idx]
+	}
+	latest, err := getMostRecentWithReplicas(candidates, annotation)
+	if err != nil {
+		return nil, err
+	} else if latest == nil {
+		// The deployment exists, but nothing was rolled out yet
+		return &RolloutStatus{Phase: RolloutPending}, nil
+	}
+	return getRolloutStatus(latest, annotation)
+}
+
+// getDeploymentRCs returns all RCs of the given deployment, or its ReplicaSets converted to RCs,
+// along with the annotation holding their deployment version
+func (kc *kubeClient) getDeploymentRCs(namespace string, deploy *deployment) ([]v1.ReplicationController, string, error) {
+	if deploy.replicaSet {
+		rss, err := kc.getReplicaSets(namespace, deploy.dcUID)
+		return rss, replicaSetRevisionAnnotation, err
+	}
+	rcs, err := kc.getReplicationControllers(namespace, deploy.dcUID)
+	return rcs, kc.config.DeploymentVersionAnnotation, err
+}
+
+// GetDeploymentVersionHistory returns all versions of the deployment of an application that
+// still have an RC or ReplicaSet, newest first. RCs without a valid version are listed last
+// with version 0. A NotFoundError is returned if the application doesn't exist.
+func (kc *kubeClient) GetDeploymentVersionHistory(spaceName string, appName string, envName string) ([]*DeploymentVersion, error) {
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	deploy, err := kc.getCurrentDeployment(spaceName, appName, envNS)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if deploy == nil {
+		return nil, errors.NewNotFoundError("deployment", appName)
+	}
+	rcs, annotation, err := kc.getDeploymentRCs(envNS, deploy)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	result := make([]*DeploymentVersion, 0, len(rcs))
+	versions := make(map[*DeploymentVersion]*int64, len(rcs))
+	for idx := range rcs {
+		rc := &rcs[idx]
+		version, err := parseDeploymentVersion(rc, annotation)
+		if err != nil {
+			// Like getMostRecentByDeploymentVersion, don't let one broken RC hide
+			// the history. It is listed as an RC without a version.
+			log.Warn(nil, map[string]interface{}{
+				"rc_name":    rc.Name,
+				"annotation": annotation,
+				"err":        err,
+			}, "listing RC with invalid deployment version without a version")
+			version = nil
+		}
+		entry := &DeploymentVersion{
+			Name:          rc.Name,
+			Created:       rc.CreationTimestamp.Time,
+			Replicas:      int(rc.Status.Replicas),
+			ReadyReplicas: int(rc.Status.ReadyReplicas),
+		}
+		if version != nil {
+			entry.Version = *version
+		}
+		if rc.Spec.Replicas != nil {
+			entry.DesiredReplicas = int(*rc.Spec.Replicas)
+		}
+		versions[entry] = version
+		result = append(result, entry)
+	}
+	// Order like getMostRecentByDeploymentVersion: versioned RCs before those without a
+	// version, and RCs of the same version by creation time and name
+	sort.Slice(result, func(i, j int) bool {
+		version, otherVersion := versions[result[i]], versions[result[j]]
+		if (version == nil) != (otherVersion == nil) {
+			return version != nil
+		} else if version != nil && *version != *otherVersion {
+			return *version > *otherVersion
+		}
+		if !result[i].Created.Equal(result[j].Created) {
+			return result[i].Created.After(result[j].Created)
+		}
+		return result[i].Name > result[j].Name
+	})
+	return result, nil
+}
+
+// getRolloutStatus derives the status of a rollout from its RC. RCs of DeploymentConfigs carry
+// the phase of their rollout in an annotation. ReplicaSets don't, so their rollout is considered
+// complete once all desired pods are ready.
+func getRolloutStatus(latest *rcWithReplicas, annotation string) (*RolloutStatus, error) {
+	rc := latest.rc
+	result := &RolloutStatus{
+		ReadyReplicas:   latest.readyReplicas,
+		DesiredReplicas: latest.desiredReplicas,
+	}
+	if versionStr, pres := rc.Annotations[annotation]; pres {
+		version, err := strconv.Atoi(versionStr)
+		if err != nil {
+			return nil, errs.Wrapf(err, "%s annotation for %s is not a valid integer", annotation, rc.Name)
+		}
+		result.Version = version
+	}
+
+	phase, pres := rc.Annotations[deploymentPhaseAnnotation]
+	switch {
+	case !pres && result.ReadyReplicas >= result.DesiredReplicas:
+		result.Phase = RolloutComplete
+	case !pres:
+		result.Phase = RolloutRunning
+	case phase == "New" || phase == "Pending":
+		result.Phase = RolloutPending
+	case phase == "Running":
+		result.Phase = RolloutRunning
+	case phase == "Complete":
+		result.Phase = RolloutComplete
+	case phase == "Failed":
+		result.Phase = RolloutFailed
+	default:
+		return nil, errs.Errorf("unknown %s annotation %q for %s", deploymentPhaseAnnotation, phase, rc.Name)
+	}
+	return result, nil
+}
+
+func (kc *kubeClient) rollbackDeploymentConfig(namespace string, appName string, version int64) error {
+	rollback := map[string]interface{}{
+		"kind":       "DeploymentConfigRollback",
+		"apiVersion": "v1",
+		"name":       appName,
+		"spec": map[string]interface{}{
+			"revision":        version,
+			"includeTemplate": true,
+		},
+	}
+	// The rollback API only returns the rolled back DC, which still needs to be saved
+	// for OpenShift to deploy it
+	dc, err := kc.RollbackDeploymentConfig(namespace, appName, rollback)
+	if err != nil {
+		return errs.WithStack(err)
+	} else if dc == nil {
+		return errs.Errorf("no deployment config returned from rollback of %s", appName)
+	}
+	return kc.UpdateDeploymentConfig(namespace, appName, dc)
+}
+
+// Label added to pods by the Deployment controller to tell apart the pods of its ReplicaSets
+const podTemplateHashLabel = "pod-template-hash"
+
+func (kc *kubeClient) rollbackKubeDeployment(namespace string, appName string, target *v1.ReplicationController) error {
+	if target.Spec.Template == nil {
+		return errs.Errorf("no pod template for replica set %s in namespace %s", target.Name, namespace)
+	}
+	deploy, err := kc.GetKubeDeployment(namespace, appName)
+	if err != nil {
+		return errs.WithStack(err)
+	} else if deploy == nil {
+		return errors.NewNotFoundError("deployment", appName)
+	}
+	spec, ok := deploy["spec"].(map[string]interface{})
+	if !ok {
+		return errs.Errorf("spec missing from deployment %s in namespace %s", appName, namespace)
+	}
+
+	// Re-apply the pod template of the target ReplicaSet, like "kubectl rollout undo" does,
+	// the Deployment controller then scales the ReplicaSet back up
+	template := *target.Spec.Template
+	labels := make(map[string]string, len(template.Labels))
+	for key, value := range template.Labels {
+		if key != podTemplateHashLabel {
+			labels[key] = value
+		}
+	}
+	template.Labels = labels
+	spec["template"] = template
+	return kc.UpdateKubeDeployment(namespace, appName, deploy)
+}
+
 func (kc *kubeClient) getConsoleURL(envNS string) (*string, error) {
 	path := fmt.Sprintf("console/project/%s", envNS)
 	// Replace "api" prefix with "console" and append path
@@ -359,8 +1104,11 @@ func (kc *kubeClient) getLogURL(envNS string, deploy *deployment) (*string, erro
 	if err != nil {
 		return nil, err
 	}
-	rcName := deploy.current.Name
-	logURL := fmt.Sprintf("%s/browse/rc/%s?tab=logs", *consoleURL, rcName)
+	kind := "rc"
+	if deploy.replicaSet {
+		kind = "rs"
+	}
+	logURL := fmt.Sprintf("%s/browse/%s/%s?tab=logs", *consoleURL, kind, deploy.current.Name)
 	return &logURL, nil
 }
 
@@ -447,6 +1195,212 @@ func (kc *kubeClient) GetDeployment(spaceName string, appName string, envName st
 	return result, nil
 }
 
+// GetPodRestartCounts returns the number of container restarts for each pod of
+// the most recent replication controller of an application, as well as the
+// total over all pods. If there are no pods, the counts are zero.
+func (kc *kubeClient) GetPodRestartCounts(spaceName string, appName string, envName string) (*PodRestartCounts, error) {
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	result := &PodRestartCounts{
+		Pods: map[string]int{},
+	}
+	// Get the UID for the current deployment of the app
+	deploy, err := kc.getCurrentDeployment(spaceName, appName, envNS)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if deploy == nil || deploy.current == nil {
+		return result, nil
+	}
+
+	// Get all pods created by this deployment
+	pods, err := kc.getPods(envNS, deploy.current.UID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	for _, pod := range pods {
+		restarts := 0
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts += int(status.RestartCount)
+		}
+		result.Pods[pod.Name] = restarts
+		result.Total += restarts
+	}
+	return result, nil
+}
+
+// GetDeploymentImage returns the container images of the current deployment
+// of an application, keyed by container name. The image references are taken
+// from the pod template of the most recent replication controller and split into
+// name, tag and digest. References without a digest take it from the container
+// statuses of the pods.
+func (kc *kubeClient) GetDeploymentImage(spaceName string, appName string, envName string) (map[string]*ContainerImage, error) {
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	// Get the UID for the current deployment of the app
+	deploy, err := kc.getCurrentDeployment(spaceName, appName, envNS)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if deploy == nil || deploy.current == nil {
+		return nil, nil
+	}
+	result := map[string]*ContainerImage{}
+	if deploy.current.Spec.Template != nil {
+		for _, container := range deploy.current.Spec.Template.Spec.Containers {
+			name, tag, digest := parseImageReference(container.Image)
+			result[container.Name] = &ContainerImage{
+				Image:  container.Image,
+				Name:   name,
+				Tag:    tag,
+				Digest: digest,
+			}
+		}
+	}
+
+	// Resolve digests from the pods created by this deployment
+	pods, err := kc.getPods(envNS, deploy.current.UID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			image, ok := result[status.Name]
+			if !ok || len(image.Digest) > 0 {
+				continue
+			}
+			// e.g. docker-pullable://registry/project/image@sha256:0123...
+			if idx := strings.LastIndex(status.ImageID, "@"); idx >= 0 {
+				image.Digest = status.ImageID[idx+1:]
+			}
+		}
+	}
+	return result, nil
+}
+
+// parseImageReference splits an image reference such as registry:5000/project/image:tag@sha256:0123...
+// into the image name, tag and digest. The tag and digest are empty if the reference lacks them.
+func parseImageReference(ref string) (name string, tag string, digest string) {
+	name = ref
+	if idx := strings.LastIndex(name, "@"); idx >= 0 {
+		name, digest = name[:idx], name[idx+1:]
+	}
+	// A colon before the last slash separates the registry's port, not a tag
+	if idx := strings.LastIndex(name, ":"); idx > strings.LastIndex(name, "/") {
+		name, tag = name[:idx], name[idx+1:]
+	}
+	return name, tag, digest
+}
+
+// GetDeploymentPods returns at most limit pods of the current deployment of an
+// application, skipping the first offset pods. Pods are ordered by creation time
+// and then by name, so that paging through them is stable across calls. A
+// non-empty label selector (e.g. "tier=backend") narrows the pods to those of a
+// single component of the application; it is passed on to the Kubernetes API.
+func (kc *kubeClient) GetDeploymentPods(spaceName string, appName string, envName string, labelSelector string,
+	offset int, limit int) (*DeploymentPods, error) {
+	if offset < 0 {
+		return nil, errors.NewBadParameterError("offset", offset).Expected("a non-negative number")
+	} else if limit <= 0 {
+		return nil, errors.NewBadParameterError("limit", limit).Expected("a positive number")
+	}
+	if _, err := labels.Parse(labelSelector); err != nil {
+		return nil, errors.NewBadParameterError("labelSelector", labelSelector).Expected("a valid label selector")
+	}
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	result := &DeploymentPods{
+		Pods: []*v1.Pod{},
+	}
+	// Get the UID for the current deployment of the app
+	deploy, err := kc.getCurrentDeployment(spaceName, appName, envNS)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if deploy == nil || deploy.current == nil {
+		return result, nil
+	}
+
+	// Get all pods created by this deployment that match the selector
+	pods, err := kc.getPodsBySelector(envNS, deploy.current.UID, labelSelector)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	sort.Sort(podsByCreation(pods))
+	result.Total = len(pods)
+	if offset < len(pods) {
+		end := len(pods)
+		if limit < end-offset {
+			end = offset + limit
+		}
+		result.Pods = pods[offset:end]
+	}
+	return result, nil
+}
+
+// podsByCreation sorts pods by their creation time, using their names to order
+// pods created at the same time
+type podsByCreation []*v1.Pod
+
+func (p podsByCreation) Len() int {
+	return len(p)
+}
+
+func (p podsByCreation) Less(i, j int) bool {
+	created, otherCreated := p[i].CreationTimestamp.Time, p[j].CreationTimestamp.Time
+	if !created.Equal(otherCreated) {
+		return created.Before(otherCreated)
+	}
+	return p[i].Name < p[j].Name
+}
+
+func (p podsByCreation) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// GetDeploymentPodLogs returns a stream of the logs of a pod belonging to the
+// current deployment of an application. If follow is true, the stream stays open
+// for new log output until it is closed by the caller. A NotFoundError is
+// returned if the pod is not part of the current deployment.
+func (kc *kubeClient) GetDeploymentPodLogs(spaceName string, appName string, envName string, podName string,
+	follow bool) (io.ReadCloser, error) {
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	// Get the UID for the current deployment of the app
+	deploy, err := kc.getCurrentDeployment(spaceName, appName, envNS)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if deploy == nil || deploy.current == nil {
+		return nil, errors.NewNotFoundError("deployment", appName)
+	}
+
+	// Only allow access to pods created by this deployment
+	pods, err := kc.getPods(envNS, deploy.current.UID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	found := false
+	for _, pod := range pods {
+		if pod.Name == podName {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.NewNotFoundError("pod", podName)
+	}
+
+	opts := &v1.PodLogOptions{
+		Follow: follow,
+	}
+	return kc.GetPodLogs(envNS, podName, opts)
+}
+
 // GetDeploymentStats returns performance metrics of an application for a period of 1 minute
 // beyond the specified start time, which are then aggregated into a single data point.
 func (kc *kubeClient) GetDeploymentStats(spaceName string, appName string, envName string,
@@ -500,6 +1454,75 @@ func (kc *kubeClient) GetDeploymentStats(spaceName string, appName string, envNa
 	return result, nil
 }
 
+// Maximum number of environments whose deployment statistics are fetched concurrently
+const maxConcurrentEnvStatRequests = 4
+
+// GetDeploymentStatsForEnvironments returns the same statistics as GetDeploymentStats for each of
+// the given environments, or for all environments if none are given, keyed by environment name.
+// Environments without a deployment of the application are omitted. The environments are queried
+// concurrently and a failure in one of them doesn't affect the others, its error is returned in the
+// second map instead. Environments that haven't been queried yet when ctx is cancelled fail with
+// the error of ctx.
+func (kc *kubeClient) GetDeploymentStatsForEnvironments(ctx context.Context, spaceName string, appName string,
+	envNames []string, startTime time.Time) (map[string]*app.SimpleDeploymentStats, map[string]error) {
+	if len(envNames) == 0 {
+		for envName := range kc.envMap {
+			envNames = append(envNames, envName)
+		}
+	}
+
+	type envStats struct {
+		envName string
+		stats   *app.SimpleDeploymentStats
+		err     error
+	}
+	envNameCh := make(chan string)
+	resultCh := make(chan envStats, len(envNames))
+	workers := maxConcurrentEnvStatRequests
+	if len(envNames) < workers {
+		workers = len(envNames)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for envName := range envNameCh {
+				// Skip remaining environments once the caller is no longer interested
+				if err := ctx.Err(); err != nil {
+					resultCh <- envStats{envName: envName, err: err}
+					continue
+				}
+				stats, err := kc.GetDeploymentStats(spaceName, appName, envName, startTime)
+				resultCh <- envStats{envName: envName, stats: stats, err: err}
+			}
+		}()
+	}
+	for _, envName := range envNames {
+		envNameCh <- envName
+	}
+	close(envNameCh)
+	wg.Wait()
+	close(resultCh)
+
+	statsByEnv := make(map[string]*app.SimpleDeploymentStats)
+	errsByEnv := make(map[string]error)
+	for result := range resultCh {
+		if result.err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"err":              result.err,
+				"space_name":       spaceName,
+				"application_name": appName,
+				"environment_name": result.envName,
+			}, "could not get deployment statistics")
+			errsByEnv[result.envName] = result.err
+		} else if result.stats != nil {
+			statsByEnv[result.envName] = result.stats
+		}
+	}
+	return statsByEnv, errsByEnv
+}
+
 // GetDeploymentStatSeries returns performance metrics of an application as a time series bounded by
 // the provided time range in startTime and endTime. If there are more data points than the
 // limit argument, only the newest datapoints within that limit are returned.
@@ -529,90 +1552,276 @@ func (kc *kubeClient) GetDeploymentStatSeries(spaceName string, appName string,
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
-	memoryMetrics, err := kc.GetMemoryMetricsRange(pods, envNS, startTime, endTime, limit)
+	memoryMetrics, err := kc.GetMemoryMetricsRange(pods, envNS, startTime, endTime, limit)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	netTxMetrics, err := kc.GetNetworkSentMetricsRange(pods, envNS, startTime, endTime, limit)
+	if err != nil {
+		return nil, err
+	}
+	netRxMetrics, err := kc.GetNetworkRecvMetricsRange(pods, envNS, startTime, endTime, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the earliest and latest timestamps
+	minTime, maxTime := getTimestampEndpoints(cpuMetrics, memoryMetrics)
+	result := &app.SimpleDeploymentStatSeries{
+		Cores:  cpuMetrics,
+		Memory: memoryMetrics,
+		NetTx:  netTxMetrics,
+		NetRx:  netRxMetrics,
+		Start:  minTime,
+		End:    maxTime,
+	}
+
+	return result, nil
+}
+
+func (kc *kubeClient) DeleteDeployment(spaceName string, appName string, envName string) error {
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	// Delete routes
+	err = kc.deleteRoutes(appName, envNS)
+	if err != nil {
+		return err
+	}
+	// Delete services
+	err = kc.deleteServices(appName, envNS)
+	if err != nil {
+		return err
+	}
+	// Delete DC (will also delete RCs and pods)
+	err = kc.deleteDeploymentConfig(spaceName, appName, envNS)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetEnvironments retrieves information on all environments in the cluster
+// for the current user
+func (kc *kubeClient) GetEnvironments() ([]*app.SimpleEnvironment, error) {
+	envs := []*app.SimpleEnvironment{}
+	for envName := range kc.envMap {
+		env, err := kc.GetEnvironment(envName)
+		if err != nil {
+			return nil, errs.WithStack(err)
+		}
+		envs = append(envs, env)
+	}
+	return envs, nil
+}
+
+// GetSpaceEnvironments returns the namespaces of the environments that applications of the
+// space with the provided name can be deployed to, keyed by environment name. Environments are
+// defined per user, so all spaces share them. Environments whose namespace doesn't exist or
+// can't be accessed with the bearer token are left out instead of failing the whole call.
+func (kc *kubeClient) GetSpaceEnvironments(spaceName string) (map[string]string, error) {
+	result := make(map[string]string, len(kc.envMap))
+	for envName, envNS := range kc.envMap {
+		_, err := kc.Namespaces().Get(envNS, metaV1.GetOptions{})
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) || apierrors.IsNotFound(err) {
+			log.Warn(nil, map[string]interface{}{
+				"space_name":       spaceName,
+				"environment_name": envName,
+				"namespace":        envNS,
+				"err":              err,
+			}, "skipping environment with inaccessible namespace")
+			continue
+		} else if err != nil {
+			return nil, errs.Wrapf(err, "failed to get namespace %s of environment %s", envNS, envName)
+		}
+		result[envName] = envNS
+	}
+	return result, nil
+}
+
+// GetEnvironment returns information on an environment with the provided name
+func (kc *kubeClient) GetEnvironment(envName string) (*app.SimpleEnvironment, error) {
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	envStats, err := kc.getResourceQuota(envNS)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	env := &app.SimpleEnvironment{
+		Type: "environment",
+		Attributes: &app.SimpleEnvironmentAttributes{
+			Name:  &envName,
+			Quota: envStats,
+		},
+	}
+	return env, nil
+}
+
+// GetEnvironmentQuota returns how much of the resources limited by the quotas of
+// the environment with the provided name are used. If several quotas limit the same
+// resource, the most restrictive one is reported.
+func (kc *kubeClient) GetEnvironmentQuota(spaceName string, envName string) (*EnvironmentQuota, error) {
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	quotas, err := kc.ResourceQuotas(envNS).List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	result := &EnvironmentQuota{}
+	usages := map[v1.ResourceName]*QuotaUsage{
+		v1.ResourceLimitsCPU:    &result.CPU,
+		v1.ResourceLimitsMemory: &result.Memory,
+		v1.ResourcePods:         &result.Pods,
+	}
+	for _, quota := range quotas.Items {
+		for resourceName, usage := range usages {
+			hardQuantity, pres := quota.Status.Hard[resourceName]
+			if !pres {
+				continue
+			}
+			hard, err := quantityToFloat64(hardQuantity)
+			if err != nil {
+				return nil, errs.WithStack(err)
+			}
+			if usage.Limited && hard >= usage.Hard {
+				continue
+			}
+			used, err := quantityToFloat64(quota.Status.Used[resourceName])
+			if err != nil {
+				return nil, errs.WithStack(err)
+			}
+			*usage = QuotaUsage{
+				Used:    used,
+				Hard:    hard,
+				Limited: true,
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetEnvironmentMetrics returns the CPU and memory usage of all pods in the environment with
+// the provided name during the last minute. If the metrics server is unavailable or has no
+// data for the pods, zero usage is returned along with Available set to false instead of
+// failing, since the usage is merely informational.
+func (kc *kubeClient) GetEnvironmentMetrics(spaceName string, envName string) (*EnvironmentMetrics, error) {
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	podList, err := kc.Pods(envNS).List(metaV1.ListOptions{})
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
-	netTxMetrics, err := kc.GetNetworkSentMetricsRange(pods, envNS, startTime, endTime, limit)
-	if err != nil {
-		return nil, err
+	pods := make([]*v1.Pod, len(podList.Items))
+	for idx := range podList.Items {
+		pods[idx] = &podList.Items[idx]
 	}
-	netRxMetrics, err := kc.GetNetworkRecvMetricsRange(pods, envNS, startTime, endTime, limit)
-	if err != nil {
-		return nil, err
+	if len(pods) == 0 {
+		// Nothing is running, so there is no usage to ask the metrics server for
+		return &EnvironmentMetrics{Available: true}, nil
 	}
 
-	// Get the earliest and latest timestamps
-	minTime, maxTime := getTimestampEndpoints(cpuMetrics, memoryMetrics)
-	result := &app.SimpleDeploymentStatSeries{
-		Cores:  cpuMetrics,
-		Memory: memoryMetrics,
-		NetTx:  netTxMetrics,
-		NetRx:  netRxMetrics,
-		Start:  minTime,
-		End:    maxTime,
+	result, err := kc.getPodsUsage(pods, envNS, time.Now().Add(-1*time.Minute))
+	if err != nil {
+		log.Warn(nil, map[string]interface{}{
+			"space_name":       spaceName,
+			"environment_name": envName,
+			"err":              err,
+		}, "metrics of environment are unavailable")
+		return &EnvironmentMetrics{}, nil
 	}
-
 	return result, nil
 }
 
-func (kc *kubeClient) DeleteDeployment(spaceName string, appName string, envName string) error {
+// metricsRetention is how long the metrics server keeps metrics
+const metricsRetention = 7 * 24 * time.Hour
+
+// GetEnvironmentMetricsRange returns the CPU and memory usage of all pods in the environment
+// with the provided name between the given start and end time. A start time beyond the
+// retention of the metrics server is moved forward, and the returned range tells which
+// period was actually queried. A non-negative limit only returns that many of the most recent
+// values. Like GetEnvironmentMetrics, an unavailable metrics server is not treated as an error.
+func (kc *kubeClient) GetEnvironmentMetricsRange(spaceName string, envName string, startTime time.Time,
+	endTime time.Time, limit int) (*EnvironmentMetricsRange, error) {
+	if oldest := time.Now().Add(-metricsRetention); startTime.Before(oldest) {
+		startTime = oldest
+	}
+	if !startTime.Before(endTime) {
+		return nil, errors.NewBadParameterError("endTime", endTime).Expected("a time after the start time and within the last " + metricsRetention.String())
+	}
 	envNS, err := kc.getEnvironmentNamespace(envName)
 	if err != nil {
-		return errs.WithStack(err)
+		return nil, errs.WithStack(err)
 	}
-	// Delete routes
-	err = kc.deleteRoutes(appName, envNS)
+	podList, err := kc.Pods(envNS).List(metaV1.ListOptions{})
 	if err != nil {
-		return err
+		return nil, errs.WithStack(err)
 	}
-	// Delete services
-	err = kc.deleteServices(appName, envNS)
-	if err != nil {
-		return err
+	pods := make([]*v1.Pod, len(podList.Items))
+	for idx := range podList.Items {
+		pods[idx] = &podList.Items[idx]
 	}
-	// Delete DC (will also delete RCs and pods)
-	err = kc.deleteDeploymentConfig(spaceName, appName, envNS)
-	if err != nil {
-		return err
+	result := &EnvironmentMetricsRange{
+		CPU:    []*app.TimedNumberTuple{},
+		Memory: []*app.TimedNumberTuple{},
+		Start:  startTime,
+		End:    endTime,
+	}
+	if len(pods) == 0 {
+		// Nothing is running, so there is no usage to ask the metrics server for
+		result.Available = true
+		return result, nil
 	}
-	return nil
-}
 
-// GetEnvironments retrieves information on all environments in the cluster
-// for the current user
-func (kc *kubeClient) GetEnvironments() ([]*app.SimpleEnvironment, error) {
-	envs := []*app.SimpleEnvironment{}
-	for envName := range kc.envMap {
-		env, err := kc.GetEnvironment(envName)
-		if err != nil {
-			return nil, errs.WithStack(err)
+	cpuUsage, err := kc.GetCPUMetricsRange(pods, envNS, startTime, endTime, limit)
+	if err == nil {
+		var memoryUsage []*app.TimedNumberTuple
+		memoryUsage, err = kc.GetMemoryMetricsRange(pods, envNS, startTime, endTime, limit)
+		if err == nil {
+			result.CPU = cpuUsage
+			result.Memory = memoryUsage
+			result.Available = true
+			return result, nil
 		}
-		envs = append(envs, env)
 	}
-	return envs, nil
+	log.Warn(nil, map[string]interface{}{
+		"space_name":       spaceName,
+		"environment_name": envName,
+		"err":              err,
+	}, "metrics of environment are unavailable")
+	return result, nil
 }
 
-// GetEnvironment returns information on an environment with the provided name
-func (kc *kubeClient) GetEnvironment(envName string) (*app.SimpleEnvironment, error) {
-	envNS, err := kc.getEnvironmentNamespace(envName)
+// getPodsUsage returns the CPU and memory usage summed over the given pods, or an error if
+// the metrics server doesn't report both of them
+func (kc *kubeClient) getPodsUsage(pods []*v1.Pod, namespace string, startTime time.Time) (*EnvironmentMetrics, error) {
+	cpuUsage, err := kc.GetCPUMetrics(pods, namespace, startTime)
 	if err != nil {
 		return nil, errs.WithStack(err)
+	} else if cpuUsage == nil || cpuUsage.Value == nil {
+		return nil, errs.New("no CPU metrics reported")
 	}
-
-	envStats, err := kc.getResourceQuota(envNS)
+	memoryUsage, err := kc.GetMemoryMetrics(pods, namespace, startTime)
 	if err != nil {
 		return nil, errs.WithStack(err)
+	} else if memoryUsage == nil || memoryUsage.Value == nil {
+		return nil, errs.New("no memory metrics reported")
 	}
-
-	env := &app.SimpleEnvironment{
-		Type: "environment",
-		Attributes: &app.SimpleEnvironmentAttributes{
-			Name:  &envName,
-			Quota: envStats,
-		},
-	}
-	return env, nil
+	return &EnvironmentMetrics{
+		CPU:       *cpuUsage.Value,
+		Memory:    *memoryUsage.Value,
+		Available: true,
+	}, nil
 }
 
 func getMetricsURLFromAPIURL(apiURLStr string) (string, error) {
@@ -707,6 +1916,64 @@ func (oc *openShiftAPIClient) GetBuildConfigs(namespace string, labelSelector st
 	return oc.getResource(bcURL, false)
 }
 
+func (oc *openShiftAPIClient) GetBuildConfig(namespace string, name string) (map[string]interface{}, error) {
+	bcURL := fmt.Sprintf("/oapi/v1/namespaces/%s/buildconfigs/%s", namespace, name)
+	return oc.getResource(bcURL, true)
+}
+
+// GetBuildLog streams the log of a build, or returns nil if the build does not exist
+func (oc *openShiftAPIClient) GetBuildLog(namespace string, name string) (io.ReadCloser, error) {
+	logURL := fmt.Sprintf("/oapi/v1/namespaces/%s/builds/%s/log", namespace, name)
+	return oc.getResourceStream(logURL)
+}
+
+// GetBuildLogs returns a stream of the logs of a build of an application within the
+// provided space. Builds are numbered starting from 1, and a buildNumber of 0 selects
+// the most recent build. A NotFoundError is returned if the application or build
+// does not exist.
+func (kc *kubeClient) GetBuildLogs(spaceName string, appName string, buildNumber int) (io.ReadCloser, error) {
+	if buildNumber < 0 {
+		return nil, errors.NewBadParameterError("buildNumber", buildNumber).Expected("a non-negative number")
+	}
+	// Builds are run within the user namespace from the BuildConfig of the application
+	namespace := kc.config.UserNamespace
+	bc, err := kc.GetBuildConfig(namespace, appName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if bc == nil {
+		return nil, errors.NewNotFoundError("build config", appName)
+	}
+	metadata, ok := bc["metadata"].(map[string]interface{})
+	if !ok {
+		return nil, errs.New("'metadata' object missing from build config")
+	}
+	// Check that the BuildConfig belongs to the expected space
+	labels, _ := metadata["labels"].(map[string]interface{})
+	if space, _ := labels["space"].(string); space != spaceName {
+		return nil, errors.NewNotFoundError("build config", appName)
+	}
+
+	if buildNumber == 0 {
+		status, _ := bc["status"].(map[string]interface{})
+		// Absent until the first build is started
+		lastVersion, _ := status["lastVersion"].(float64)
+		if lastVersion < 1 {
+			return nil, errors.NewNotFoundError("build", appName)
+		}
+		buildNumber = int(lastVersion)
+	}
+
+	// Builds are named after their BuildConfig and number, e.g. myApp-4
+	buildName := fmt.Sprintf("%s-%d", appName, buildNumber)
+	logs, err := kc.GetBuildLog(namespace, buildName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if logs == nil {
+		return nil, errors.NewNotFoundError("build", buildName)
+	}
+	return logs, nil
+}
+
 func getEnvironmentsFromConfigMap(kube KubeRESTAPI, userNamespace string) (map[string]string, error) {
 	// fabric8 creates a ConfigMap in the user namespace with information on environments
 	const envConfigMap string = "fabric8-environments"
@@ -747,74 +2014,120 @@ func getEnvironmentsFromConfigMap(kube KubeRESTAPI, userNamespace string) (map[s
 func (kc *kubeClient) getEnvironmentNamespace(envName string) (string, error) {
 	envNS, pres := kc.envMap[envName]
 	if !pres {
-		return "", errs.Errorf("unknown environment: %s", envName)
+		return "", errors.NewNotFoundError("environment", envName)
 	}
 	return envNS, nil
 }
 
-// Derived from: https://github.com/fabric8-services/fabric8-tenant/blob/master/openshift/kube_token.go
+// checkNamespaceExists returns a NotFoundError for the namespace with the provided name if
+// it doesn't exist. Looking up resources in a namespace that was deleted out-of-band
+// mostly yields nothing rather than failing, so this tells such a namespace apart from a
+// missing resource. Any other failure to get the namespace is not conclusive and ignored.
+func (kc *kubeClient) checkNamespaceExists(namespace string) error {
+	_, err := kc.Namespaces().Get(namespace, metaV1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return errors.NewNotFoundError("namespace", namespace)
+	} else if err != nil {
+		log.Warn(nil, map[string]interface{}{
+			"namespace": namespace,
+			"err":       err,
+		}, "unable to check whether namespace exists")
+	}
+	return nil
+}
+
+// requestContext returns the context of this client holding the ID of the WIT
+// request on whose behalf it is used, so that log entries can be correlated with it
+func (oc *openShiftAPIClient) requestContext() context.Context {
+	return client.SetContextRequestID(oc.ctx, oc.config.RequestID)
+}
+
+// withRequestID annotates the given error with the ID of the WIT request on
+// whose behalf this client is used, if any
+func (oc *openShiftAPIClient) withRequestID(err error) error {
+	if oc.config.RequestID == "" {
+		return errs.WithStack(err)
+	}
+	return errs.Wrapf(err, "request %s", oc.config.RequestID)
+}
+
 func (oc *openShiftAPIClient) sendResource(url string, method string, reqBody interface{}) error {
+	_, err := oc.sendResourceForResponse(url, method, reqBody)
+	return err
+}
+
+// sendResourceForResponse is like sendResource, but also returns the response body
+// Derived from: https://github.com/fabric8-services/fabric8-tenant/blob/master/openshift/kube_token.go
+func (oc *openShiftAPIClient) sendResourceForResponse(url string, method string, reqBody interface{}) ([]byte, error) {
+	ctx := oc.requestContext()
 	fullURL := strings.TrimSuffix(oc.config.ClusterURL, "/") + url
 
 	marshalled, err := json.Marshal(reqBody)
 	if err != nil {
-		log.Error(nil, map[string]interface{}{
+		log.Error(ctx, map[string]interface{}{
 			"err":          err,
 			"url":          fullURL,
 			"request_body": reqBody,
 		}, "could not marshall %s request", method)
-		return errs.WithStack(err)
+		return nil, oc.withRequestID(err)
 	}
 
 	req, err := http.NewRequest(method, fullURL, bytes.NewBuffer(marshalled))
 	if err != nil {
-		log.Error(nil, map[string]interface{}{
+		log.Error(ctx, map[string]interface{}{
 			"err":          err,
 			"url":          fullURL,
 			"request_body": reqBody,
 		}, "could not create %s request", method)
-		return errs.WithStack(err)
+		return nil, oc.withRequestID(err)
 	}
+	req = req.WithContext(ctx)
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Authorization", "Bearer "+oc.config.BearerToken)
+	if oc.config.RequestID != "" {
+		req.Header.Set(middleware.RequestIDHeader, oc.config.RequestID)
+	}
 
 	resp, err := oc.httpClient.Do(req)
 	if err != nil {
-		log.Error(nil, map[string]interface{}{
+		log.Error(ctx, map[string]interface{}{
 			"err":          err,
 			"url":          fullURL,
 			"request_body": reqBody,
 		}, "could not perform %s request", method)
-		return errs.WithStack(err)
+		if ctx.Err() != nil {
+			return nil, oc.withRequestID(ctx.Err())
+		}
+		return nil, oc.withRequestID(err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		log.Error(nil, map[string]interface{}{
+		log.Error(ctx, map[string]interface{}{
 			"err":           err,
 			"url":           fullURL,
 			"request_body":  reqBody,
 			"response_body": respBody,
 		}, "could not read response from %s request", method)
-		return errs.WithStack(err)
+		return nil, oc.withRequestID(err)
 	}
 	defer resp.Body.Close()
 
 	status := resp.StatusCode
-	if status != http.StatusOK {
-		log.Error(nil, map[string]interface{}{
+	if status != http.StatusOK && status != http.StatusCreated {
+		log.Error(ctx, map[string]interface{}{
 			"err":           err,
 			"url":           fullURL,
 			"request_body":  reqBody,
 			"response_body": respBody,
 			"http_status":   status,
 		}, "failed to %s request due to HTTP error", method)
-		return errs.Errorf("failed to %s url %s: status code %d", method, fullURL, status)
+		return nil, oc.withRequestID(errs.Errorf("failed to %s url %s: status code %d", method, fullURL, status))
 	}
-	return nil
+	return respBody, nil
 }
 
 func (kc *kubeClient) getDeploymentConfig(namespace string, appName string, space string) (*deployment, error) {
@@ -824,20 +2137,36 @@ func (kc *kubeClient) getDeploymentConfig(namespace string, appName string, spac
 	} else if result == nil {
 		return nil, nil
 	}
+	return parseDeployment(result, "DeploymentConfig", namespace, appName, space)
+}
+
+func (kc *kubeClient) getKubeDeployment(namespace string, appName string, space string) (*deployment, error) {
+	result, err := kc.GetKubeDeployment(namespace, appName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if result == nil {
+		return nil, nil
+	}
+	return parseDeployment(result, "Deployment", namespace, appName, space)
+}
 
-	// Parse deployment config from result
+// parseDeployment reads the UID and application version from a DeploymentConfig
+// or Kubernetes Deployment of the given kind, and checks that it belongs to the
+// expected space
+func parseDeployment(result map[string]interface{}, expectKind string, namespace string, appName string,
+	space string) (*deployment, error) {
 	kind, ok := result["kind"].(string)
-	if !ok || kind != "DeploymentConfig" {
-		return nil, errs.New("no deployment config returned from endpoint")
+	if !ok || kind != expectKind {
+		return nil, errs.Errorf("no %s returned from endpoint", expectKind)
 	}
 	metadata, ok := result["metadata"].(map[string]interface{})
 	if !ok {
-		return nil, errs.Errorf("metadata missing from deployment config for application %s configuration %+v", appName, result)
+		return nil, errs.Errorf("metadata missing from %s for application %s configuration %+v", kind, appName, result)
 	}
 	// Check the space label is what we expect
 	labels, ok := metadata["labels"].(map[string]interface{})
 	if !ok {
-		return nil, errs.Errorf("labels missing from deployment config for application %s: %+v", appName, metadata)
+		return nil, errs.Errorf("labels missing from %s for application %s: %+v", kind, appName, metadata)
 	}
 	/* FIXME Not all projects will have the space label defined due to the requirement that
 	 * fabric8-maven-plugin is called from the project's POM and not that of its parent.
@@ -853,19 +2182,20 @@ func (kc *kubeClient) getDeploymentConfig(namespace string, appName string, spac
 			"namespace": namespace,
 			"app_name":  appName,
 			"space":     space,
-		}, "space label missing from deployment config")
+			"kind":      kind,
+		}, "space label missing from deployment")
 	} else if spaceLabel != space {
-		return nil, errs.Errorf("deployment config %s is part of space %s, expected space %s", appName, spaceLabel, space)
+		return nil, errs.Errorf("%s %s is part of space %s, expected space %s", kind, appName, spaceLabel, space)
 	}
-	// Get UID from deployment config
+	// Get UID from deployment
 	uid, ok := metadata["uid"].(string)
 	if !ok || len(uid) == 0 {
-		return nil, errs.Errorf("malformed metadata in deployment config for application %s: %+v", appName, metadata)
+		return nil, errs.Errorf("malformed metadata in %s for application %s: %+v", kind, appName, metadata)
 	}
 	// Read application version from label
 	version := labels["version"].(string)
 	if !ok || len(version) == 0 {
-		return nil, errs.Errorf("version missing from deployment config for application %s: %+v", appName, metadata)
+		return nil, errs.Errorf("version missing from %s for application %s: %+v", kind, appName, metadata)
 	}
 
 	dc := &deployment{
@@ -880,6 +2210,19 @@ func (oc *openShiftAPIClient) GetDeploymentConfig(namespace string, name string)
 	return oc.getResource(dcURL, true)
 }
 
+// GetKubeDeployment returns the apps/v1 Deployment with the given name, which is
+// served by the Kubernetes API server rather than the OpenShift one
+func (oc *openShiftAPIClient) GetKubeDeployment(namespace string, name string) (map[string]interface{}, error) {
+	deployURL := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s", namespace, name)
+	return oc.getResource(deployURL, true)
+}
+
+// GetReplicaSets returns all apps/v1 ReplicaSets in the given namespace
+func (oc *openShiftAPIClient) GetReplicaSets(namespace string) (map[string]interface{}, error) {
+	rsURL := fmt.Sprintf("/apis/apps/v1/namespaces/%s/replicasets", namespace)
+	return oc.getResource(rsURL, false)
+}
+
 func (kc *kubeClient) deleteDeploymentConfig(spaceName string, appName string, namespace string) error {
 	// Check that the deployment config exists and belongs to the expected space
 	dc, err := kc.getDeploymentConfig(namespace, appName, spaceName)
@@ -901,6 +2244,31 @@ func (kc *kubeClient) deleteDeploymentConfig(spaceName string, appName string, n
 	return nil
 }
 
+func (oc *openShiftAPIClient) RollbackDeploymentConfig(namespace string, name string,
+	rollback map[string]interface{}) (map[string]interface{}, error) {
+	rollbackURL := fmt.Sprintf("/oapi/v1/namespaces/%s/deploymentconfigs/%s/rollback", namespace, name)
+	respBody, err := oc.sendResourceForResponse(rollbackURL, "POST", rollback)
+	if err != nil {
+		return nil, err
+	}
+	var dc map[string]interface{}
+	err = json.Unmarshal(respBody, &dc)
+	if err != nil {
+		return nil, oc.withRequestID(err)
+	}
+	return dc, nil
+}
+
+func (oc *openShiftAPIClient) UpdateDeploymentConfig(namespace string, name string, dc map[string]interface{}) error {
+	dcURL := fmt.Sprintf("/oapi/v1/namespaces/%s/deploymentconfigs/%s", namespace, name)
+	return oc.sendResource(dcURL, "PUT", dc)
+}
+
+func (oc *openShiftAPIClient) UpdateKubeDeployment(namespace string, name string, deploy map[string]interface{}) error {
+	deployURL := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s", namespace, name)
+	return oc.sendResource(deployURL, "PUT", deploy)
+}
+
 func (oc *openShiftAPIClient) DeleteDeploymentConfig(namespace string, name string, opts *metaV1.DeleteOptions) error {
 	dcURL := fmt.Sprintf("/oapi/v1/namespaces/%s/deploymentconfigs/%s", namespace, name)
 	// API states this should return a Status object, but it returns the DC instead,
@@ -910,6 +2278,7 @@ func (oc *openShiftAPIClient) DeleteDeploymentConfig(namespace string, name stri
 
 const deploymentPhaseAnnotation string = "openshift.io/deployment.phase"
 const deploymentVersionAnnotation string = "openshift.io/deployment-config.latest-version"
+const replicaSetRevisionAnnotation string = "deployment.kubernetes.io/revision"
 
 func (kc *kubeClient) getCurrentDeployment(space string, appName string, namespace string) (*deployment, error) {
 	// Look up DeploymentConfig corresponding to the application name in the provided environment
@@ -917,7 +2286,8 @@ func (kc *kubeClient) getCurrentDeployment(space string, appName string, namespa
 	if err != nil {
 		return nil, errs.WithStack(err)
 	} else if result == nil {
-		return nil, nil
+		// Fall back to a plain Kubernetes Deployment
+		return kc.getCurrentKubeDeployment(space, appName, namespace)
 	}
 	// Find the current deployment for the DC we just found. This should correspond to the deployment
 	// shown in the OpenShift web console's overview page
@@ -949,7 +2319,39 @@ func (kc *kubeClient) getCurrentDeployment(space string, appName string, namespa
 		candidates[active.Name] = active
 	}
 	// For final comparison use deployment version annotation instead of creation timestamp
-	current, err := getMostRecentByDeploymentVersion(candidates)
+	current, err := getMostRecentByDeploymentVersion(candidates, kc.config.DeploymentVersionAnnotation)
+	if err != nil {
+		return nil, err
+	}
+	result.current = current
+	return result, nil
+}
+
+func (kc *kubeClient) getCurrentKubeDeployment(space string, appName string, namespace string) (*deployment, error) {
+	result, err := kc.getKubeDeployment(namespace, appName, space)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if result == nil {
+		// Neither a DeploymentConfig nor a Deployment exists, possibly because the
+		// whole environment namespace is gone
+		return nil, kc.checkNamespaceExists(namespace)
+	}
+	result.replicaSet = true
+	rss, err := kc.getReplicaSets(namespace, result.dcUID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if len(rss) == 0 {
+		return result, nil
+	}
+
+	// The Deployment controller bumps the revision of the ReplicaSet it rolls out,
+	// so the current ReplicaSet is the one with the highest revision, even if the
+	// Deployment is scaled down
+	candidates := make(map[string]*v1.ReplicationController)
+	for idx := range rss {
+		candidates[rss[idx].Name] = &rss[idx]
+	}
+	current, err := getMostRecentByRevision(candidates)
 	if err != nil {
 		return nil, err
 	}
@@ -971,19 +2373,61 @@ func isReplicationControllerVisible(rc *v1.ReplicationController) bool {
 	return visible
 }
 
-func getMostRecentByDeploymentVersion(rcs map[string]*v1.ReplicationController) (*v1.ReplicationController, error) {
+// getMostRecentByRevision is the counterpart of getMostRecentByDeploymentVersion
+// for ReplicaSets of Kubernetes Deployments, which carry a revision annotation
+func getMostRecentByRevision(rss map[string]*v1.ReplicationController) (*v1.ReplicationController, error) {
+	return getMostRecentByDeploymentVersion(rss, replicaSetRevisionAnnotation)
+}
+
+// rcWithReplicas holds an RC along with the number of its pods, so that a summary
+// of its status needs no further requests
+type rcWithReplicas struct {
+	rc *v1.ReplicationController
+	// Number of pods of the RC that exist, from Status.Replicas
+	replicas int
+	// Number of pods of the RC that are ready, from Status.ReadyReplicas
+	readyReplicas int
+	// Number of pods the RC aims for, from Spec.Replicas
+	desiredReplicas int
+}
+
+// getMostRecentWithReplicas returns the RC with the highest deployment version as
+// determined by getMostRecentByDeploymentVersion along with its replica counts, or
+// nil if there are no RCs
+func getMostRecentWithReplicas(rcs map[string]*v1.ReplicationController, annotation string) (*rcWithReplicas, error) {
+	rc, err := getMostRecentByDeploymentVersion(rcs, annotation)
+	if err != nil || rc == nil {
+		return nil, err
+	}
+	result := &rcWithReplicas{
+		rc:            rc,
+		replicas:      int(rc.Status.Replicas),
+		readyReplicas: int(rc.Status.ReadyReplicas),
+	}
+	if rc.Spec.Replicas != nil {
+		result.desiredReplicas = int(*rc.Spec.Replicas)
+	}
+	return result, nil
+}
+
+// getMostRecentByDeploymentVersion returns the RC with the highest deployment
+// version, which is read from the given annotation. RCs whose version is not a
+// valid integer are skipped, unless no RC has a valid version.
+func getMostRecentByDeploymentVersion(rcs map[string]*v1.ReplicationController, annotation string) (*v1.ReplicationController, error) {
 	var result *v1.ReplicationController
 	var newestVersion *int64
+	var parseErr error
 
 	for _, rc := range rcs {
-		var version *int64
-		versionStr, pres := rc.Annotations[deploymentVersionAnnotation]
-		if pres {
-			versionNum, err := strconv.ParseInt(versionStr, 10, 64)
-			if err != nil {
-				return nil, errs.Wrapf(err, "deployment version for %s is not a valid integer", rc.Name)
-			}
-			version = &versionNum
+		version, err := parseDeploymentVersion(rc, annotation)
+		if err != nil {
+			log.Warn(nil, map[string]interface{}{
+				"rc_name":    rc.Name,
+				"annotation": annotation,
+				"err":        err,
+			}, "skipping RC with invalid deployment version")
+			parseErr = err
+			continue
 		}
 
 		// Take first RC unconditionally
@@ -1005,16 +2449,44 @@ func getMostRecentByDeploymentVersion(rcs map[string]*v1.ReplicationController)
 			}
 		} else if version != nil {
 			// Both current RC and newest RC have versions, so compare as integers
-			if *version > *newestVersion {
+			if *version > *newestVersion || (*version == *newestVersion && isNewerRC(rc, result)) {
 				result = rc
 				newestVersion = version
 			}
 		}
 	}
 
+	if result == nil && parseErr != nil {
+		return nil, parseErr
+	}
 	return result, nil
 }
 
+// parseDeploymentVersion returns the deployment version of the RC, which is read from the
+// given annotation, or nil if the RC doesn't have the annotation
+func parseDeploymentVersion(rc *v1.ReplicationController, annotation string) (*int64, error) {
+	versionStr, pres := rc.Annotations[annotation]
+	if !pres {
+		return nil, nil
+	}
+	version, err := strconv.ParseInt(versionStr, 10, 64)
+	if err != nil {
+		return nil, errs.Wrapf(err, "%s annotation for %s is not a valid integer", annotation, rc.Name)
+	}
+	return &version, nil
+}
+
+// isNewerRC breaks ties between two RCs with the same deployment version so
+// that the result doesn't depend on the iteration order of a map. The RC that
+// was created later is newer; if both were created at the same time the RC
+// with the lexicographically greater name is considered newer.
+func isNewerRC(rc, other *v1.ReplicationController) bool {
+	if !rc.CreationTimestamp.Time.Equal(other.CreationTimestamp.Time) {
+		return other.CreationTimestamp.Time.Before(rc.CreationTimestamp.Time)
+	}
+	return rc.Name > other.Name
+}
+
 func (kc *kubeClient) getReplicationControllers(namespace string, dcUID types.UID) ([]v1.ReplicationController, error) {
 	rcs, err := kc.ReplicationControllers(namespace).List(metaV1.ListOptions{})
 	if err != nil {
@@ -1041,10 +2513,83 @@ func (kc *kubeClient) getReplicationControllers(namespace string, dcUID types.UI
 	return rcsForDc, nil
 }
 
+// replicaSet holds the parts of an apps/v1 ReplicaSet that are also present in
+// a ReplicationController
+type replicaSet struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Replicas *int32              `json:"replicas,omitempty"`
+		Template *v1.PodTemplateSpec `json:"template,omitempty"`
+	} `json:"spec,omitempty"`
+	Status struct {
+		Replicas      int32 `json:"replicas"`
+		ReadyReplicas int32 `json:"readyReplicas"`
+	} `json:"status,omitempty"`
+}
+
+// getReplicaSets returns the ReplicaSets created by the Deployment with the given
+// UID. Since client-go has no typed apps/v1 API, the ReplicaSets are converted to
+// ReplicationControllers, which carry the same information that we need.
+func (kc *kubeClient) getReplicaSets(namespace string, deployUID types.UID) ([]v1.ReplicationController, error) {
+	result, err := kc.GetReplicaSets(namespace)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	kind, ok := result["kind"].(string)
+	if !ok || kind != "ReplicaSetList" {
+		return nil, errs.New("no replica set list returned from endpoint")
+	}
+	// Decode the generic JSON object into our partial ReplicaSet type
+	marshalled, err := json.Marshal(result)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	var list struct {
+		Items []replicaSet `json:"items"`
+	}
+	err = json.Unmarshal(marshalled, &list)
+	if err != nil {
+		return nil, errs.Wrapf(err, "malformed replica set list in namespace %s", namespace)
+	}
+
+	rcsForDeploy := []v1.ReplicationController{}
+	for _, rs := range list.Items {
+		// Use OwnerReferences to map RS to Deployment that created it
+		match := false
+		for _, ref := range rs.OwnerReferences {
+			if ref.UID == deployUID && ref.Controller != nil && *ref.Controller {
+				match = true
+				break
+			}
+		}
+		if match {
+			rc := v1.ReplicationController{
+				ObjectMeta: rs.ObjectMeta,
+				Spec: v1.ReplicationControllerSpec{
+					Replicas: rs.Spec.Replicas,
+					Template: rs.Spec.Template,
+				},
+				Status: v1.ReplicationControllerStatus{
+					Replicas:      rs.Status.Replicas,
+					ReadyReplicas: rs.Status.ReadyReplicas,
+				},
+			}
+			rcsForDeploy = append(rcsForDeploy, rc)
+		}
+	}
+
+	return rcsForDeploy, nil
+}
+
 func (kc *kubeClient) getResourceQuota(namespace string) (*app.EnvStats, error) {
 	const computeResources string = "compute-resources"
 	quota, err := kc.ResourceQuotas(namespace).Get(computeResources, metaV1.GetOptions{})
-	if err != nil {
+	if apierrors.IsNotFound(err) {
+		if nsErr := kc.checkNamespaceExists(namespace); nsErr != nil {
+			return nil, nsErr
+		}
+		return nil, errs.WithStack(err)
+	} else if err != nil {
 		return nil, errs.WithStack(err)
 	} else if quota == nil {
 		return nil, errs.Errorf("no resource quota with name: %s", computeResources)
@@ -1105,7 +2650,15 @@ func quantityToFloat64(q resource.Quantity) (float64, error) {
 }
 
 func (kc *kubeClient) getPods(namespace string, uid types.UID) ([]*v1.Pod, error) {
-	pods, err := kc.Pods(namespace).List(metaV1.ListOptions{})
+	return kc.getPodsBySelector(namespace, uid, "")
+}
+
+// getPodsBySelector returns the pods owned by the controller with the given
+// UID that match the given label selector. An empty selector matches all pods.
+func (kc *kubeClient) getPodsBySelector(namespace string, uid types.UID, labelSelector string) ([]*v1.Pod, error) {
+	pods, err := kc.Pods(namespace).List(metaV1.ListOptions{
+		LabelSelector: labelSelector,
+	})
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
@@ -1727,27 +3280,84 @@ func (oc *openShiftAPIClient) DeleteRoute(namespace string, name string, opts *m
 }
 
 // Derived from: https://github.com/fabric8-services/fabric8-tenant/blob/master/openshift/kube_token.go
+// getResourceStream is like getResource for resources that are not JSON objects. The
+// caller must close the returned stream. If the resource does not exist, nil is returned.
+func (oc *openShiftAPIClient) getResourceStream(url string) (io.ReadCloser, error) {
+	ctx := oc.requestContext()
+	fullURL := strings.TrimSuffix(oc.config.ClusterURL, "/") + url
+	req, err := http.NewRequest("GET", fullURL, nil)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err": err,
+			"url": fullURL,
+		}, "error creating HTTP GET request")
+		return nil, oc.withRequestID(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+oc.config.BearerToken)
+	if oc.config.RequestID != "" {
+		req.Header.Set(middleware.RequestIDHeader, oc.config.RequestID)
+	}
+
+	resp, err := oc.httpClient.Do(req)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err": err,
+			"url": fullURL,
+		}, "error during HTTP request")
+		if ctx.Err() != nil {
+			return nil, oc.withRequestID(ctx.Err())
+		}
+		return nil, oc.withRequestID(err)
+	}
+
+	status := resp.StatusCode
+	if status == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, nil
+	} else if status != http.StatusOK {
+		defer resp.Body.Close()
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(resp.Body)
+		log.Error(ctx, map[string]interface{}{
+			"url":           fullURL,
+			"response_body": buf,
+			"http_status":   status,
+		}, "error returned from HTTP request")
+		return nil, oc.withRequestID(errs.Errorf("failed to GET url %s due to status code %d", fullURL, status))
+	}
+	return resp.Body, nil
+}
+
 func (oc *openShiftAPIClient) getResource(url string, allowMissing bool) (map[string]interface{}, error) {
+	ctx := oc.requestContext()
 	var body []byte
 	fullURL := strings.TrimSuffix(oc.config.ClusterURL, "/") + url
 	req, err := http.NewRequest("GET", fullURL, bytes.NewReader(body))
 	if err != nil {
-		log.Error(nil, map[string]interface{}{
+		log.Error(ctx, map[string]interface{}{
 			"err": err,
 			"url": fullURL,
 		}, "error creating HTTP GET request")
-		return nil, errs.WithStack(err)
+		return nil, oc.withRequestID(err)
 	}
+	req = req.WithContext(ctx)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Authorization", "Bearer "+oc.config.BearerToken)
+	if oc.config.RequestID != "" {
+		req.Header.Set(middleware.RequestIDHeader, oc.config.RequestID)
+	}
 
 	resp, err := oc.httpClient.Do(req)
 	if err != nil {
-		log.Error(nil, map[string]interface{}{
+		log.Error(ctx, map[string]interface{}{
 			"err": err,
 			"url": fullURL,
 		}, "error during HTTP request")
-		return nil, errs.WithStack(err)
+		if ctx.Err() != nil {
+			return nil, oc.withRequestID(ctx.Err())
+		}
+		return nil, oc.withRequestID(err)
 	}
 
 	defer resp.Body.Close()
@@ -1760,24 +3370,24 @@ func (oc *openShiftAPIClient) getResource(url string, allowMissing bool) (map[st
 	if status == http.StatusNotFound && allowMissing {
 		return nil, nil
 	} else if status != http.StatusOK {
-		log.Error(nil, map[string]interface{}{
+		log.Error(ctx, map[string]interface{}{
 			"err":           err,
 			"url":           fullURL,
 			"response_body": buf,
 			"http_status":   status,
 		}, "error returned from HTTP request")
-		return nil, errs.Errorf("failed to GET url %s due to status code %d", fullURL, status)
+		return nil, oc.withRequestID(errs.Errorf("failed to GET url %s due to status code %d", fullURL, status))
 	}
 	var respType map[string]interface{}
 	err = json.Unmarshal(b, &respType)
 	if err != nil {
-		log.Error(nil, map[string]interface{}{
+		log.Error(ctx, map[string]interface{}{
 			"err":           err,
 			"url":           fullURL,
 			"response_body": buf,
 			"http_status":   status,
 		}, "error unmarshalling JSON response")
-		return nil, errs.WithStack(err)
+		return nil, oc.withRequestID(err)
 	}
 	return respType, nil
 }
@@ -1,19 +1,27 @@
 package kubernetes_test
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"io/ioutil"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/kubernetes"
 	errs "github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	v1 "k8s.io/client-go/pkg/api/v1"
 )
@@ -33,14 +41,22 @@ type testKube struct {
 	podHolder              *testPod
 	svcHolder              *testService
 	svcDelHolder           []*testDeleteByName
+	logsHolder             *testPodLogs
+	nsHolder               *testNamespace
 }
 
 type testFixture struct {
 	cmInput      *configMapInput
 	rqInput      *resourceQuotaInput
 	bcInput      string                // BC json file
+	bcByName     map[string]string     // BC name -> BC JSON file
+	buildLogs    map[string]string     // build name -> build log
 	scaleInput   deploymentConfigInput // app name -> namespace -> DC scale json file
 	metricsInput *metricsInput
+	nsErr        error                   // Returned when getting a namespace
+	nsErrs       map[string]error        // Returned when getting the namespace with the given name
+	rcWatches    chan *watch.FakeWatcher // Receives the watchers of RCs
+	podWatches   chan *watch.FakeWatcher // Receives the watchers of pods
 	kube         *testKube
 	os           *testOpenShift
 	metrics      *testMetrics
@@ -49,11 +65,13 @@ type testFixture struct {
 
 // Collects input data necessary to retrieve a deployment
 type deploymentInput struct {
-	dcInput    deploymentConfigInput // app name -> namespace -> DC json file
-	rcInput    map[string]string     // namespace -> RC JSON file
-	podInput   map[string]string     // namespace -> pod JSON file
-	svcInput   map[string]string     // namespace -> service JSON file
-	routeInput map[string]string     // namespace -> route JSON file
+	dcInput     deploymentConfigInput // app name -> namespace -> DC json file
+	rcInput     map[string]string     // namespace -> RC JSON file
+	deployInput deploymentConfigInput // app name -> namespace -> Deployment JSON file
+	rsInput     map[string]string     // namespace -> ReplicaSet JSON file
+	podInput    map[string]string     // namespace -> pod JSON file
+	svcInput    map[string]string     // namespace -> service JSON file
+	routeInput  map[string]string     // namespace -> route JSON file
 }
 
 var defaultDeploymentInput = deploymentInput{
@@ -74,7 +92,7 @@ func getDefaultKubeClient(fixture *testFixture, t *testing.T) kubernetes.KubeCli
 		OpenShiftRESTAPIGetter: fixture,
 	}
 
-	kc, err := kubernetes.NewKubeClient(config)
+	kc, err := kubernetes.NewKubeClient(context.Background(), config)
 	require.NoError(t, err)
 	return kc
 }
@@ -126,6 +144,39 @@ func (cm *testConfigMap) Get(name string, options metav1.GetOptions) (*v1.Config
 	return result, nil
 }
 
+// Namespace fakes
+
+type testNamespace struct {
+	corev1.NamespaceInterface
+	err  error
+	errs map[string]error
+	name string
+}
+
+func (tk *testKube) Namespaces() corev1.NamespaceInterface {
+	result := &testNamespace{
+		err:  tk.fixture.nsErr,
+		errs: tk.fixture.nsErrs,
+	}
+	tk.nsHolder = result
+	return result
+}
+
+func (ns *testNamespace) Get(name string, options metav1.GetOptions) (*v1.Namespace, error) {
+	ns.name = name
+	if ns.err != nil {
+		return nil, ns.err
+	} else if err := ns.errs[name]; err != nil {
+		return nil, err
+	}
+	result := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	return result, nil
+}
+
 // Resource Quota fakes
 
 type resourceQuotaInput struct {
@@ -134,6 +185,8 @@ type resourceQuotaInput struct {
 	hard       map[v1.ResourceName]float64
 	used       map[v1.ResourceName]float64
 	shouldFail bool
+	// Further quotas returned when listing quotas
+	others []*resourceQuotaInput
 }
 
 var defaultResourceQuotaInput *resourceQuotaInput = &resourceQuotaInput{
@@ -194,6 +247,22 @@ func (rq *testResourceQuota) Get(name string, options metav1.GetOptions) (*v1.Re
 	return result, nil
 }
 
+func (rq *testResourceQuota) List(options metav1.ListOptions) (*v1.ResourceQuotaList, error) {
+	if rq.input.shouldFail {
+		return nil, errs.New("Test failure")
+	}
+	result := &v1.ResourceQuotaList{}
+	for _, input := range append([]*resourceQuotaInput{rq.input}, rq.input.others...) {
+		quota, err := (&testResourceQuota{input: input}).Get(input.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		} else if quota != nil {
+			result.Items = append(result.Items, *quota)
+		}
+	}
+	return result, nil
+}
+
 func stringToQuantityMap(input map[v1.ResourceName]float64) (v1.ResourceList, error) {
 	result := make(map[v1.ResourceName]resource.Quantity)
 	for k, v := range input {
@@ -217,6 +286,7 @@ type testReplicationController struct {
 	corev1.ReplicationControllerInterface
 	inputFile string
 	namespace string
+	watches   chan *watch.FakeWatcher
 }
 
 func (tk *testKube) ReplicationControllers(ns string) corev1.ReplicationControllerInterface {
@@ -224,6 +294,7 @@ func (tk *testKube) ReplicationControllers(ns string) corev1.ReplicationControll
 	result := &testReplicationController{
 		inputFile: input,
 		namespace: ns,
+		watches:   tk.fixture.rcWatches,
 	}
 	tk.rcHolder = result
 	return result
@@ -239,6 +310,20 @@ func (rc *testReplicationController) List(options metav1.ListOptions) (*v1.Repli
 	return &result, err
 }
 
+func (rc *testReplicationController) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	return newTestWatch(rc.watches)
+}
+
+// newTestWatch creates a fake watcher and hands it to the test, if it watches
+func newTestWatch(watches chan *watch.FakeWatcher) (watch.Interface, error) {
+	if watches == nil {
+		return nil, errs.New("watch not supported by test")
+	}
+	watcher := watch.NewFake()
+	watches <- watcher
+	return watcher, nil
+}
+
 // Pod fakes
 
 var defaultPodInput = map[string]string{
@@ -249,6 +334,8 @@ type testPod struct {
 	corev1.PodInterface
 	inputFile string
 	namespace string
+	options   metav1.ListOptions
+	watches   chan *watch.FakeWatcher
 }
 
 func (tk *testKube) Pods(ns string) corev1.PodInterface {
@@ -256,22 +343,65 @@ func (tk *testKube) Pods(ns string) corev1.PodInterface {
 	result := &testPod{
 		inputFile: input,
 		namespace: ns,
+		watches:   tk.fixture.podWatches,
 	}
 	tk.podHolder = result
 	return result
 }
 
 func (pod *testPod) List(options metav1.ListOptions) (*v1.PodList, error) {
+	pod.options = options
 	var result v1.PodList
 	if len(pod.inputFile) == 0 {
 		// No matching pods
 		return &result, nil
 	}
 	err := readJSON(pod.inputFile, &result)
-	return &result, err
+	if err != nil {
+		return nil, err
+	}
+	// Filter by label selector like the API server does
+	selector, err := labels.Parse(options.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	matching := []v1.Pod{}
+	for _, item := range result.Items {
+		if selector.Matches(labels.Set(item.Labels)) {
+			matching = append(matching, item)
+		}
+	}
+	result.Items = matching
+	return &result, nil
+}
+
+func (pod *testPod) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	pod.options = options
+	return newTestWatch(pod.watches)
+}
+
+// Pod log fakes
+
+type testPodLogs struct {
+	namespace string
+	name      string
+	opts      *v1.PodLogOptions
+}
+
+func (tk *testKube) GetPodLogs(namespace string, name string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+	tk.logsHolder = &testPodLogs{
+		namespace: namespace,
+		name:      name,
+		opts:      opts,
+	}
+	return ioutil.NopCloser(strings.NewReader("logs of " + name)), nil
+}
+
+func (tk *testKube) WatchClient(ctx context.Context) (corev1.CoreV1Interface, error) {
+	return tk, nil
 }
 
-func (fixture *testFixture) GetKubeRESTAPI(config *kubernetes.KubeClientConfig) (kubernetes.KubeRESTAPI, error) {
+func (fixture *testFixture) GetKubeRESTAPI(ctx context.Context, config *kubernetes.KubeClientConfig) (kubernetes.KubeRESTAPI, error) {
 	mock := &testKube{
 		fixture: fixture,
 	}
@@ -338,6 +468,7 @@ type metricsInput struct {
 	memory []*app.TimedNumberTuple
 	netTx  []*app.TimedNumberTuple
 	netRx  []*app.TimedNumberTuple
+	err    error // Returned instead of any metrics if set
 }
 
 var defaultMetricsInput = &metricsInput{
@@ -428,6 +559,12 @@ func (tm *testMetrics) getOneMetric(metrics []*app.TimedNumberTuple, pods []*v1.
 		namespace: namespace,
 		startTime: startTime,
 	}
+	if tm.fixture.metricsInput.err != nil {
+		return nil, tm.fixture.metricsInput.err
+	} else if len(metrics) == 0 {
+		// No data in the requested bucket
+		return nil, nil
+	}
 	return metrics[0], nil
 }
 
@@ -444,17 +581,25 @@ func (tm *testMetrics) getManyMetrics(metrics []*app.TimedNumberTuple, pods []*v
 		endTime:   endTime,
 		limit:     limit,
 	}
+	if tm.fixture.metricsInput.err != nil {
+		return nil, tm.fixture.metricsInput.err
+	}
 	return metrics, nil
 }
 
 // OpenShift API fakes
 
 type testOpenShift struct {
-	fixture        *testFixture
-	scaleHolder    *testScale
-	routeHolder    *testGetResult
-	delDCHolder    *testDeleteByName
-	delRouteHolder []*testDeleteByName
+	fixture          *testFixture
+	scaleHolder      *testScale
+	kubeScaleHolder  *testScale
+	rollbackHolder   map[string]interface{}
+	updateDCHolder   map[string]interface{}
+	updateKubeHolder map[string]interface{}
+	routeHolder      *testGetResult
+	delDCHolder      *testDeleteByName
+	delRouteHolder   []*testDeleteByName
+	buildLogHolder   *testBuildLog
 }
 
 type testScale struct {
@@ -468,13 +613,18 @@ type testGetResult struct {
 	labelSelector string
 }
 
+type testBuildLog struct {
+	namespace string
+	name      string
+}
+
 type testDeleteByName struct {
 	namespace string
 	name      string
 	opts      *metav1.DeleteOptions
 }
 
-func (fixture *testFixture) GetOpenShiftRESTAPI(config *kubernetes.KubeClientConfig) (kubernetes.OpenShiftRESTAPI, error) {
+func (fixture *testFixture) GetOpenShiftRESTAPI(ctx context.Context, config *kubernetes.KubeClientConfig) (kubernetes.OpenShiftRESTAPI, error) {
 	oapi := &testOpenShift{
 		fixture: fixture,
 	}
@@ -493,6 +643,30 @@ func (to *testOpenShift) GetBuildConfigs(namespace string, labelSelector string)
 	return result, err
 }
 
+func (to *testOpenShift) GetBuildConfig(namespace string, name string) (map[string]interface{}, error) {
+	input, pres := to.fixture.bcByName[name]
+	if !pres {
+		// No matching BC
+		return nil, nil
+	}
+	var result map[string]interface{}
+	err := readJSON(input, &result)
+	return result, err
+}
+
+func (to *testOpenShift) GetBuildLog(namespace string, name string) (io.ReadCloser, error) {
+	to.buildLogHolder = &testBuildLog{
+		namespace: namespace,
+		name:      name,
+	}
+	logs, pres := to.fixture.buildLogs[name]
+	if !pres {
+		// No matching build
+		return nil, nil
+	}
+	return ioutil.NopCloser(strings.NewReader(logs)), nil
+}
+
 type deploymentConfigInput map[string]map[string]string
 
 var defaultDeploymentConfigInput = deploymentConfigInput{
@@ -559,6 +733,68 @@ func (to *testOpenShift) SetDeploymentConfigScale(namespace string, name string,
 	return nil
 }
 
+func (to *testOpenShift) GetKubeDeployment(namespace string, name string) (map[string]interface{}, error) {
+	input := to.fixture.deployInput.getInput(name, namespace)
+	if input == nil {
+		// No matching Deployment
+		return nil, nil
+	}
+	var result map[string]interface{}
+	err := readJSON(*input, &result)
+	return result, err
+}
+
+func (to *testOpenShift) RollbackDeploymentConfig(namespace string, name string,
+	rollback map[string]interface{}) (map[string]interface{}, error) {
+	to.rollbackHolder = rollback
+	// Return the DC as is, since its contents are not inspected
+	return to.GetDeploymentConfig(namespace, name)
+}
+
+func (to *testOpenShift) UpdateDeploymentConfig(namespace string, name string, dc map[string]interface{}) error {
+	to.updateDCHolder = dc
+	return nil
+}
+
+func (to *testOpenShift) UpdateKubeDeployment(namespace string, name string, deploy map[string]interface{}) error {
+	to.updateKubeHolder = deploy
+	return nil
+}
+
+func (to *testOpenShift) GetKubeDeploymentScale(namespace string, name string) (map[string]interface{}, error) {
+	input := to.fixture.scaleInput.getInput(name, namespace)
+	if input == nil {
+		// No matching Deployment scale
+		return nil, nil
+	}
+	var result map[string]interface{}
+	err := readJSON(*input, &result)
+	return result, err
+}
+
+func (to *testOpenShift) SetKubeDeploymentScale(namespace string, name string, scale map[string]interface{}) error {
+	to.kubeScaleHolder = &testScale{
+		namespace:   namespace,
+		dcName:      name,
+		scaleOutput: scale,
+	}
+	return nil
+}
+
+func (to *testOpenShift) GetReplicaSets(namespace string) (map[string]interface{}, error) {
+	result := map[string]interface{}{
+		"kind":  "ReplicaSetList",
+		"items": []interface{}{},
+	}
+	input := to.fixture.rsInput[namespace]
+	if len(input) == 0 {
+		// No matching ReplicaSets
+		return result, nil
+	}
+	err := readJSON(input, &result)
+	return result, err
+}
+
 var defaultRouteInput = map[string]string{
 	"my-run": "routes-two.json",
 }
@@ -624,7 +860,7 @@ func TestGetMetrics(t *testing.T) {
 				KubeRESTAPIGetter: fixture,
 				MetricsGetter:     fixture,
 			}
-			_, err := kubernetes.NewKubeClient(config)
+			_, err := kubernetes.NewKubeClient(context.Background(), config)
 			if testCase.shouldSucceed {
 				require.NoError(t, err, "Unexpected error")
 
@@ -648,6 +884,56 @@ func TestClose(t *testing.T) {
 	require.True(t, fixture.metrics.closed, "Metrics client not closed")
 }
 
+func TestDescribeConfig(t *testing.T) {
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
+
+	desc := kc.DescribeConfig()
+	require.NotNil(t, desc)
+	require.Equal(t, "http://api.myCluster", desc.ClusterURL)
+	require.Equal(t, "myNamespace", desc.UserNamespace)
+	require.Equal(t, time.Duration(0), desc.Timeout)
+	require.Equal(t, "***oken", desc.RedactedBearerToken)
+	require.NotContains(t, desc.RedactedBearerToken, "myToken")
+}
+
+func TestPing(t *testing.T) {
+	testCases := []struct {
+		testName   string
+		nsErr      error
+		shouldFail bool
+	}{
+		{
+			testName: "Basic",
+		},
+		{
+			testName:   "Unreachable",
+			nsErr:      errs.New("connection refused"),
+			shouldFail: true,
+		},
+	}
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.nsErr = testCase.nsErr
+
+			latency, err := kc.Ping(context.Background())
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				require.Contains(t, err.Error(), "http://api.myCluster", "Cluster URL is missing from error")
+			} else {
+				require.NoError(t, err, "Unexpected error occurred")
+			}
+			require.True(t, latency >= 0, "Latency should not be negative")
+			nsHolder := fixture.kube.nsHolder
+			require.NotNil(t, nsHolder, "Namespace was never retrieved")
+			require.Equal(t, "myNamespace", nsHolder.name, "Wrong namespace retrieved")
+		})
+	}
+}
+
 func TestConfigMapEnvironments(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -711,7 +997,7 @@ func TestConfigMapEnvironments(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			fixture.cmInput = testCase.input
-			_, err := kubernetes.NewKubeClient(config)
+			_, err := kubernetes.NewKubeClient(context.Background(), config)
 			if testCase.shouldFail {
 				require.Error(t, err, "Expected an error")
 			} else {
@@ -727,6 +1013,39 @@ func TestConfigMapEnvironments(t *testing.T) {
 	}
 }
 
+func TestMissingEnvironmentNamespace(t *testing.T) {
+	namespaces := schema.GroupResource{Resource: "namespaces"}
+	fixture := &testFixture{
+		nsErrs: map[string]error{
+			"my-run": apierrors.NewNotFound(namespaces, "my-run"),
+		},
+	}
+	kc := getDefaultKubeClient(fixture, t)
+
+	requireNamespaceNotFound := func(t *testing.T, err error) {
+		require.Error(t, err, "Expected an error")
+		notFound, cause := errors.IsNotFoundError(err)
+		require.True(t, notFound, "Expected a NotFoundError, got: %v", err)
+		require.Equal(t, "my-run", cause.(errors.NotFoundError).ID, "Error should name the missing namespace")
+	}
+
+	t.Run("Get Deployment", func(t *testing.T) {
+		_, err := kc.GetDeployment("mySpace", "myApp", "run")
+		requireNamespaceNotFound(t, err)
+	})
+
+	t.Run("Scale Deployment", func(t *testing.T) {
+		_, err := kc.ScaleDeployment("mySpace", "myApp", "run", 1)
+		requireNamespaceNotFound(t, err)
+	})
+
+	t.Run("Other Namespace", func(t *testing.T) {
+		deploy, err := kc.GetDeployment("mySpace", "myApp", "stage")
+		require.NoError(t, err, "Unexpected error occurred")
+		require.Nil(t, deploy, "No deployment expected")
+	})
+}
+
 func TestGetEnvironment(t *testing.T) {
 	testCases := []struct {
 		testName   string
@@ -808,41 +1127,354 @@ func TestGetEnvironment(t *testing.T) {
 	}
 }
 
-type spaceTestData struct {
-	testName    string
-	spaceName   string
-	shouldFail  bool
-	bcJson      string
-	appTestData map[string]*appTestData // Keys are app names
-	deploymentInput
-}
+func TestGetSpaceEnvironments(t *testing.T) {
+	namespaces := schema.GroupResource{Resource: "namespaces"}
+	testCases := []struct {
+		testName   string
+		nsErrs     map[string]error
+		expectEnvs map[string]string
+		shouldFail bool
+	}{
+		{
+			testName: "All Accessible",
+			expectEnvs: map[string]string{
+				"run":   "my-run",
+				"stage": "my-stage",
+			},
+		},
+		{
+			testName: "Forbidden Namespace",
+			nsErrs: map[string]error{
+				"my-stage": apierrors.NewForbidden(namespaces, "my-stage", errs.New("access denied")),
+			},
+			expectEnvs: map[string]string{
+				"run": "my-run",
+			},
+		},
+		{
+			testName: "Missing Namespace",
+			nsErrs: map[string]error{
+				"my-run": apierrors.NewNotFound(namespaces, "my-run"),
+			},
+			expectEnvs: map[string]string{
+				"stage": "my-stage",
+			},
+		},
+		{
+			testName: "Connection Failure",
+			nsErrs: map[string]error{
+				"my-run": errs.New("connection refused"),
+			},
+			shouldFail: true,
+		},
+	}
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
 
-var defaultSpaceTestData = &spaceTestData{
-	testName:        "Basic",
-	spaceName:       "mySpace",
-	bcJson:          "buildconfigs-one.json",
-	appTestData:     map[string]*appTestData{"myApp": defaultAppTestData},
-	deploymentInput: defaultDeploymentInput,
-}
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.nsErrs = testCase.nsErrs
 
-type appTestData struct {
-	testName       string
-	spaceName      string
-	appName        string
-	shouldFail     bool
-	deployTestData map[string]*deployTestData // Keys are environment names
-	deploymentInput
+			envs, err := kc.GetSpaceEnvironments("mySpace")
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				return
+			}
+			require.NoError(t, err, "Unexpected error occurred")
+			require.Equal(t, testCase.expectEnvs, envs, "Wrong environments")
+		})
+	}
 }
 
-var defaultAppTestData = &appTestData{
-	testName:        "Basic",
-	spaceName:       "mySpace",
-	appName:         "myApp",
-	deployTestData:  map[string]*deployTestData{"run": defaultDeployTestData},
-	deploymentInput: defaultDeploymentInput,
-}
+func TestGetEnvironmentQuota(t *testing.T) {
+	testCases := []struct {
+		testName     string
+		envName      string
+		input        *resourceQuotaInput
+		expectCPU    kubernetes.QuotaUsage
+		expectMemory kubernetes.QuotaUsage
+		expectPods   kubernetes.QuotaUsage
+		shouldFail   bool
+	}{
+		{
+			testName: "Basic",
+			envName:  "run",
+			input: &resourceQuotaInput{
+				name: "compute-resources",
+				hard: map[v1.ResourceName]float64{
+					v1.ResourceLimitsCPU:    0.7,
+					v1.ResourceLimitsMemory: 1024,
+				},
+				used: map[v1.ResourceName]float64{
+					v1.ResourceLimitsCPU:    0.4,
+					v1.ResourceLimitsMemory: 512,
+				},
+				others: []*resourceQuotaInput{
+					{
+						name: "object-counts",
+						hard: map[v1.ResourceName]float64{
+							v1.ResourcePods: 10,
+						},
+						used: map[v1.ResourceName]float64{
+							v1.ResourcePods: 3,
+						},
+					},
+				},
+			},
+			expectCPU:    kubernetes.QuotaUsage{Used: 0.4, Hard: 0.7, Limited: true},
+			expectMemory: kubernetes.QuotaUsage{Used: 512, Hard: 1024, Limited: true},
+			expectPods:   kubernetes.QuotaUsage{Used: 3, Hard: 10, Limited: true},
+		},
+		{
+			testName: "Most Restrictive Quota",
+			envName:  "run",
+			input: &resourceQuotaInput{
+				name: "compute-resources",
+				hard: map[v1.ResourceName]float64{
+					v1.ResourceLimitsCPU: 2,
+				},
+				used: map[v1.ResourceName]float64{
+					v1.ResourceLimitsCPU: 0.5,
+				},
+				others: []*resourceQuotaInput{
+					{
+						name: "compute-resources-timebound",
+						hard: map[v1.ResourceName]float64{
+							v1.ResourceLimitsCPU: 1,
+						},
+						used: map[v1.ResourceName]float64{
+							v1.ResourceLimitsCPU: 0.25,
+						},
+					},
+				},
+			},
+			expectCPU: kubernetes.QuotaUsage{Used: 0.25, Hard: 1, Limited: true},
+		},
+		{
+			testName: "No Quota",
+			envName:  "run",
+			input:    &resourceQuotaInput{},
+		},
+		{
+			testName:   "Bad Environment",
+			envName:    "doesNotExist",
+			input:      defaultResourceQuotaInput,
+			shouldFail: true,
+		},
+		{
+			testName: "List Fails",
+			envName:  "run",
+			input: &resourceQuotaInput{
+				shouldFail: true,
+			},
+			shouldFail: true,
+		},
+	}
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
 
-type deployTestData struct {
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.rqInput = testCase.input
+
+			quota, err := kc.GetEnvironmentQuota("mySpace", testCase.envName)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				return
+			}
+			require.NoError(t, err, "Unexpected error occurred")
+			require.NotNil(t, quota, "Quota should not be nil")
+			require.Equal(t, "my-run", fixture.kube.quotaHolder.namespace, "Quotas retrieved from wrong namespace")
+			requireQuotaUsage(t, testCase.expectCPU, quota.CPU, "CPU")
+			requireQuotaUsage(t, testCase.expectMemory, quota.Memory, "memory")
+			requireQuotaUsage(t, testCase.expectPods, quota.Pods, "pods")
+		})
+	}
+}
+
+func TestGetEnvironmentMetrics(t *testing.T) {
+	testCases := []struct {
+		testName     string
+		envName      string
+		podInput     map[string]string
+		metricsInput *metricsInput
+		expect       *kubernetes.EnvironmentMetrics
+		shouldFail   bool
+	}{
+		{
+			testName:     "Basic",
+			envName:      "run",
+			podInput:     defaultPodInput,
+			metricsInput: defaultMetricsInput,
+			expect: &kubernetes.EnvironmentMetrics{
+				CPU:       1.2,
+				Memory:    1200,
+				Available: true,
+			},
+		},
+		{
+			testName:     "No Pods",
+			envName:      "stage",
+			podInput:     defaultPodInput,
+			metricsInput: defaultMetricsInput,
+			expect: &kubernetes.EnvironmentMetrics{
+				Available: true,
+			},
+		},
+		{
+			testName: "Metrics Unavailable",
+			envName:  "run",
+			podInput: defaultPodInput,
+			metricsInput: &metricsInput{
+				err: errs.New("metrics server unavailable"),
+			},
+			expect: &kubernetes.EnvironmentMetrics{},
+		},
+		{
+			testName: "No Memory Metrics",
+			envName:  "run",
+			podInput: defaultPodInput,
+			metricsInput: &metricsInput{
+				cpu: defaultMetricsInput.cpu,
+			},
+			expect: &kubernetes.EnvironmentMetrics{},
+		},
+		{
+			testName:     "Bad Environment",
+			envName:      "doesNotExist",
+			podInput:     defaultPodInput,
+			metricsInput: defaultMetricsInput,
+			shouldFail:   true,
+		},
+	}
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.podInput = testCase.podInput
+			fixture.metricsInput = testCase.metricsInput
+			fixture.metrics.cpuParams = nil
+
+			metrics, err := kc.GetEnvironmentMetrics("mySpace", testCase.envName)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				return
+			}
+			require.NoError(t, err, "Unexpected error occurred")
+			require.Equal(t, testCase.expect, metrics, "Wrong environment metrics")
+			if cpuParams := fixture.metrics.cpuParams; cpuParams != nil {
+				// All pods of the environment are queried at once
+				require.Len(t, cpuParams.pods, 2, "Wrong number of pods")
+				require.Equal(t, "my-run", cpuParams.namespace, "Metrics retrieved from wrong namespace")
+			} else {
+				require.Empty(t, testCase.podInput[fixture.kube.podHolder.namespace], "Metrics API not called")
+			}
+		})
+	}
+}
+
+func TestGetEnvironmentMetricsRange(t *testing.T) {
+	fixture := &testFixture{
+		podInput:     defaultPodInput,
+		metricsInput: defaultMetricsInput,
+	}
+	kc := getDefaultKubeClient(fixture, t)
+	endTime := time.Now()
+
+	t.Run("Basic", func(t *testing.T) {
+		startTime := endTime.Add(-1 * time.Hour)
+		metrics, err := kc.GetEnvironmentMetricsRange("mySpace", "run", startTime, endTime, 10)
+		require.NoError(t, err, "Unexpected error occurred")
+		require.True(t, metrics.Available, "Metrics should be available")
+		require.Equal(t, defaultMetricsInput.cpu, metrics.CPU, "Wrong CPU metrics")
+		require.Equal(t, defaultMetricsInput.memory, metrics.Memory, "Wrong memory metrics")
+		require.Equal(t, startTime, metrics.Start, "Wrong start time")
+		require.Equal(t, endTime, metrics.End, "Wrong end time")
+		cpuParams := fixture.metrics.cpuParams
+		require.Len(t, cpuParams.pods, 2, "Wrong number of pods")
+		require.Equal(t, "my-run", cpuParams.namespace, "Metrics retrieved from wrong namespace")
+		require.Equal(t, startTime, cpuParams.startTime, "Wrong start time requested")
+		require.Equal(t, endTime, cpuParams.endTime, "Wrong end time requested")
+		require.Equal(t, 10, cpuParams.limit, "Wrong limit requested")
+	})
+
+	t.Run("Start Beyond Retention", func(t *testing.T) {
+		startTime := endTime.Add(-30 * 24 * time.Hour)
+		metrics, err := kc.GetEnvironmentMetricsRange("mySpace", "run", startTime, endTime, -1)
+		require.NoError(t, err, "Unexpected error occurred")
+		require.True(t, metrics.Start.After(startTime), "Start time should have been clamped")
+		require.True(t, metrics.Start.After(endTime.Add(-7*24*time.Hour-time.Minute)), "Start time clamped too far")
+		require.Equal(t, metrics.Start, fixture.metrics.cpuParams.startTime, "Clamped start time not requested")
+	})
+
+	t.Run("End Before Start", func(t *testing.T) {
+		_, err := kc.GetEnvironmentMetricsRange("mySpace", "run", endTime, endTime.Add(-1*time.Hour), -1)
+		require.Error(t, err, "Expected an error")
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err), "Wrong error type")
+	})
+
+	t.Run("No Pods", func(t *testing.T) {
+		metrics, err := kc.GetEnvironmentMetricsRange("mySpace", "stage", endTime.Add(-1*time.Hour), endTime, -1)
+		require.NoError(t, err, "Unexpected error occurred")
+		require.True(t, metrics.Available, "Metrics should be available")
+		require.Empty(t, metrics.CPU, "No CPU metrics expected")
+	})
+
+	t.Run("Metrics Unavailable", func(t *testing.T) {
+		fixture.metricsInput = &metricsInput{
+			err: errs.New("metrics server unavailable"),
+		}
+		defer func() { fixture.metricsInput = defaultMetricsInput }()
+		metrics, err := kc.GetEnvironmentMetricsRange("mySpace", "run", endTime.Add(-1*time.Hour), endTime, -1)
+		require.NoError(t, err, "Unexpected error occurred")
+		require.False(t, metrics.Available, "Metrics should be unavailable")
+		require.Empty(t, metrics.CPU, "No CPU metrics expected")
+		require.Empty(t, metrics.Memory, "No memory metrics expected")
+	})
+}
+
+func requireQuotaUsage(t *testing.T, expected kubernetes.QuotaUsage, actual kubernetes.QuotaUsage, resourceName string) {
+	require.Equal(t, expected.Limited, actual.Limited, "Wrong limited flag for %s", resourceName)
+	require.InDelta(t, expected.Hard, actual.Hard, fltEpsilon, "Incorrect %s quota", resourceName)
+	require.InDelta(t, expected.Used, actual.Used, fltEpsilon, "Incorrect %s usage", resourceName)
+}
+
+type spaceTestData struct {
+	testName    string
+	spaceName   string
+	shouldFail  bool
+	bcJson      string
+	appTestData map[string]*appTestData // Keys are app names
+	deploymentInput
+}
+
+var defaultSpaceTestData = &spaceTestData{
+	testName:        "Basic",
+	spaceName:       "mySpace",
+	bcJson:          "buildconfigs-one.json",
+	appTestData:     map[string]*appTestData{"myApp": defaultAppTestData},
+	deploymentInput: defaultDeploymentInput,
+}
+
+type appTestData struct {
+	testName       string
+	spaceName      string
+	appName        string
+	shouldFail     bool
+	deployTestData map[string]*deployTestData // Keys are environment names
+	deploymentInput
+}
+
+var defaultAppTestData = &appTestData{
+	testName:        "Basic",
+	spaceName:       "mySpace",
+	appName:         "myApp",
+	deployTestData:  map[string]*deployTestData{"run": defaultDeployTestData},
+	deploymentInput: defaultDeploymentInput,
+}
+
+type deployTestData struct {
 	testName                string
 	spaceName               string
 	appName                 string
@@ -1241,6 +1873,43 @@ func TestGetDeployment(t *testing.T) {
 				routeInput: defaultRouteInput,
 			},
 		},
+		{
+			// Verifies that a Kubernetes Deployment is used if there is no
+			// deployment config, and that its ReplicaSet with the highest
+			// revision is considered current
+			testName:      "Kubernetes Deployment",
+			spaceName:     "mySpace",
+			appName:       "myApp",
+			envName:       "run",
+			envNS:         "my-run",
+			expectVersion: "1.0.2",
+			expectPodStatus: [][]string{
+				{"Running", "2"},
+			},
+			expectPodsTotal:         2,
+			expectPodsQuotaCpucores: 0.976,
+			expectPodsQuotaMemory:   524288000,
+			expectConsoleURL:        "http://console.myCluster/console/project/my-run",
+			expectLogURL:            "http://console.myCluster/console/project/my-run/browse/rs/myApp-7d9c8b5f6?tab=logs",
+			expectAppURL:            "http://myApp-my-run.example.com",
+			deploymentInput: deploymentInput{
+				deployInput: deploymentConfigInput{
+					"myApp": {
+						"my-run": "deployment-one.json",
+					},
+				},
+				rsInput: map[string]string{
+					// List containing the current ReplicaSet with revision 2
+					// followed by a scaled-down one with revision 1
+					"my-run": "replicasets.json",
+				},
+				podInput: map[string]string{
+					"my-run": "pods-replicaset.json",
+				},
+				svcInput:   defaultServiceInput,
+				routeInput: defaultRouteInput,
+			},
+		},
 		{
 			// Tests handling of a deployment config with missing space label
 			// FIXME When our workaround is no longer needed, we should expect
@@ -1313,44 +1982,63 @@ func TestGetDeployment(t *testing.T) {
 	}
 }
 
-func TestScaleDeployment(t *testing.T) {
+func TestGetPodRestartCounts(t *testing.T) {
 	testCases := []struct {
-		testName    string
-		spaceName   string
-		appName     string
-		envName     string
-		expectedNS  string
-		dcInput     deploymentConfigInput
-		scaleInput  deploymentConfigInput
-		newReplicas int
-		oldReplicas int
-		shouldFail  bool
+		testName        string
+		envName         string
+		deploymentInput deploymentInput
+		expectPods      map[string]int
+		expectTotal     int
+		shouldFail      bool
 	}{
 		{
-			testName:    "Basic",
-			spaceName:   "mySpace",
-			appName:     "myApp",
-			envName:     "run",
-			expectedNS:  "my-run",
-			dcInput:     defaultDeploymentConfigInput,
-			scaleInput:  defaultDeploymentScaleInput,
-			newReplicas: 3,
-			oldReplicas: 2,
+			testName: "Basic",
+			envName:  "run",
+			deploymentInput: deploymentInput{
+				dcInput: defaultDeploymentConfigInput,
+				rcInput: defaultReplicationControllerInput,
+				podInput: map[string]string{
+					"my-run": "pods-restarts.json",
+				},
+				svcInput:   defaultServiceInput,
+				routeInput: defaultRouteInput,
+			},
+			expectPods: map[string]int{
+				"myApp-1-nfs9w": 3,
+				"myApp-1-sdmzq": 1,
+			},
+			expectTotal: 4,
 		},
 		{
-			testName:   "Zero Replicas",
-			spaceName:  "mySpace",
-			appName:    "myApp",
-			envName:    "run",
-			expectedNS: "my-run",
-			dcInput:    defaultDeploymentConfigInput,
-			scaleInput: deploymentConfigInput{
-				"myApp": {
-					"my-run": "deployment-scale-zero.json",
+			testName:        "No Restarts",
+			envName:         "run",
+			deploymentInput: defaultDeploymentInput,
+			expectPods: map[string]int{
+				"myApp-1-nfs9w": 0,
+				"myApp-1-sdmzq": 0,
+			},
+			expectTotal: 0,
+		},
+		{
+			testName: "No Pods",
+			envName:  "run",
+			deploymentInput: deploymentInput{
+				dcInput: defaultDeploymentConfigInput,
+				rcInput: map[string]string{
+					"my-run": "replicationcontroller-scaled-down.json",
 				},
+				podInput:   defaultPodInput,
+				svcInput:   defaultServiceInput,
+				routeInput: defaultRouteInput,
 			},
-			newReplicas: 1,
-			oldReplicas: 0,
+			expectPods:  map[string]int{},
+			expectTotal: 0,
+		},
+		{
+			testName:        "Bad Environment",
+			envName:         "doesNotExist",
+			deploymentInput: defaultDeploymentInput,
+			shouldFail:      true,
 		},
 	}
 
@@ -1359,111 +2047,1088 @@ func TestScaleDeployment(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.testName, func(t *testing.T) {
-			fixture.dcInput = testCase.dcInput
-			fixture.scaleInput = testCase.scaleInput
+			fixture.deploymentInput = testCase.deploymentInput
 
-			old, err := kc.ScaleDeployment(testCase.spaceName, testCase.appName, testCase.envName, testCase.newReplicas)
+			counts, err := kc.GetPodRestartCounts("mySpace", "myApp", testCase.envName)
 			if testCase.shouldFail {
 				require.Error(t, err, "Expected an error")
 			} else {
 				require.NoError(t, err, "Unexpected error occurred")
-				require.NotNil(t, old, "Previous replicas are nil")
-				require.Equal(t, testCase.oldReplicas, *old, "Wrong number of previous replicas")
-				scaleHolder := fixture.os.scaleHolder
-				require.NotNil(t, scaleHolder, "No scale results available")
-				require.Equal(t, testCase.expectedNS, scaleHolder.namespace, "Wrong namespace")
-				require.Equal(t, testCase.appName, scaleHolder.dcName, "Wrong deployment config name")
-				// Check spec/replicas modified correctly
-				spec, ok := scaleHolder.scaleOutput["spec"].(map[string]interface{})
-				require.True(t, ok, "Spec property is missing or invalid")
-				newReplicas, ok := spec["replicas"].(int)
-				require.True(t, ok, "Replicas property is missing or invalid")
-				require.Equal(t, testCase.newReplicas, newReplicas, "Wrong modified number of replicas")
+				require.NotNil(t, counts, "Restart counts are nil")
+				require.Equal(t, testCase.expectPods, counts.Pods, "Incorrect restart counts per pod")
+				require.Equal(t, testCase.expectTotal, counts.Total, "Incorrect total restart count")
 			}
 		})
 	}
 }
 
-func TestDeleteDeployment(t *testing.T) {
-	// DeleteOptions do not change
-	policy := metav1.DeletePropagationForeground
-	expectOpts := &metav1.DeleteOptions{
-		PropagationPolicy: &policy,
-	}
-	expectServices := []string{"myApp", "myOtherApp"} // Test impl doesn't check labels
-	expectRoutes := expectServices                    // Our routes use the same names as their services
+func TestGetDeploymentPods(t *testing.T) {
 	testCases := []struct {
-		testName       string
-		spaceName      string
-		appName        string
-		envName        string
-		expectNS       string
-		expectSelector string
-		expectServices []string
-		expectRoutes   []string
-		shouldFail     bool
-		deploymentInput
+		testName        string
+		envName         string
+		labelSelector   string
+		offset          int
+		limit           int
+		deploymentInput deploymentInput
+		expectPods      []string
+		expectTotal     int
+		shouldFail      bool
+		badParameter    bool
 	}{
 		{
-			testName:        "Basic",
-			spaceName:       "mySpace",
-			appName:         "myApp",
+			testName:        "All Pods",
 			envName:         "run",
-			expectNS:        "my-run",
-			expectSelector:  "app%3DmyApp",
-			expectServices:  expectServices,
-			expectRoutes:    expectRoutes,
+			limit:           10,
 			deploymentInput: defaultDeploymentInput,
+			// Ordered by creation time rather than as listed
+			expectPods:  []string{"myApp-1-sdmzq", "myApp-1-nfs9w"},
+			expectTotal: 2,
 		},
 		{
-			testName:        "Bad Environment",
-			spaceName:       "mySpace",
-			appName:         "myApp",
-			envName:         "doesNotExist",
-			expectNS:        "my-run",
-			expectSelector:  "app%3DmyApp",
-			expectServices:  expectServices,
-			expectRoutes:    expectRoutes,
+			testName:        "First Page",
+			envName:         "run",
+			limit:           1,
 			deploymentInput: defaultDeploymentInput,
-			shouldFail:      true,
+			expectPods:      []string{"myApp-1-sdmzq"},
+			expectTotal:     2,
 		},
 		{
-			testName:        "Wrong Space",
-			spaceName:       "otherSpace",
-			appName:         "myApp",
+			testName:        "Second Page",
 			envName:         "run",
-			expectNS:        "my-run",
-			expectSelector:  "app%3DmyApp",
-			expectServices:  expectServices,
-			expectRoutes:    expectRoutes,
+			offset:          1,
+			limit:           1,
 			deploymentInput: defaultDeploymentInput,
-			shouldFail:      true,
+			expectPods:      []string{"myApp-1-nfs9w"},
+			expectTotal:     2,
 		},
 		{
-			testName:       "No Routes",
-			spaceName:      "mySpace",
-			appName:        "myApp",
-			envName:        "run",
-			expectNS:       "my-run",
-			expectSelector: "app%3DmyApp",
-			expectServices: expectServices,
-			expectRoutes:   []string{},
+			testName:        "Offset Past End",
+			envName:         "run",
+			offset:          5,
+			limit:           1,
+			deploymentInput: defaultDeploymentInput,
+			expectPods:      []string{},
+			expectTotal:     2,
+		},
+		{
+			testName: "No Pods",
+			envName:  "run",
+			limit:    10,
 			deploymentInput: deploymentInput{
-				dcInput:  defaultDeploymentConfigInput,
-				rcInput:  defaultReplicationControllerInput,
-				podInput: defaultPodInput,
-				svcInput: defaultServiceInput,
-				routeInput: map[string]string{
-					"my-run": "routes-zero.json",
+				dcInput: defaultDeploymentConfigInput,
+				rcInput: map[string]string{
+					"my-run": "replicationcontroller-scaled-down.json",
 				},
+				podInput:   defaultPodInput,
+				svcInput:   defaultServiceInput,
+				routeInput: defaultRouteInput,
 			},
+			expectPods: []string{},
 		},
 		{
-			testName:       "No Services",
-			spaceName:      "mySpace",
-			appName:        "myApp",
-			envName:        "run",
-			expectNS:       "my-run",
+			testName:        "Matching Label Selector",
+			envName:         "run",
+			labelSelector:   "app=myApp,deploymentconfig in (myApp)",
+			limit:           10,
+			deploymentInput: defaultDeploymentInput,
+			expectPods:      []string{"myApp-1-sdmzq", "myApp-1-nfs9w"},
+			expectTotal:     2,
+		},
+		{
+			testName:        "Label Selector Of Other Component",
+			envName:         "run",
+			labelSelector:   "tier=backend",
+			limit:           10,
+			deploymentInput: defaultDeploymentInput,
+			expectPods:      []string{},
+		},
+		{
+			testName:        "Malformed Label Selector",
+			envName:         "run",
+			labelSelector:   "tier in (backend",
+			limit:           10,
+			deploymentInput: defaultDeploymentInput,
+			shouldFail:      true,
+			badParameter:    true,
+		},
+		{
+			testName:        "Negative Offset",
+			envName:         "run",
+			offset:          -1,
+			limit:           1,
+			deploymentInput: defaultDeploymentInput,
+			shouldFail:      true,
+		},
+		{
+			testName:        "Zero Limit",
+			envName:         "run",
+			deploymentInput: defaultDeploymentInput,
+			shouldFail:      true,
+		},
+		{
+			testName:        "Bad Environment",
+			envName:         "doesNotExist",
+			limit:           10,
+			deploymentInput: defaultDeploymentInput,
+			shouldFail:      true,
+		},
+	}
+
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.deploymentInput = testCase.deploymentInput
+
+			page, err := kc.GetDeploymentPods("mySpace", "myApp", testCase.envName, testCase.labelSelector,
+				testCase.offset, testCase.limit)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				if testCase.badParameter {
+					require.IsType(t, errors.BadParameterError{}, errs.Cause(err), "Expected a bad parameter error")
+				}
+			} else {
+				require.NoError(t, err, "Unexpected error occurred")
+				require.Equal(t, testCase.labelSelector, fixture.kube.podHolder.options.LabelSelector,
+					"Label selector not passed to the Kubernetes API")
+				require.NotNil(t, page, "Page of pods is nil")
+				podNames := []string{}
+				for _, pod := range page.Pods {
+					podNames = append(podNames, pod.Name)
+				}
+				require.Equal(t, testCase.expectPods, podNames, "Incorrect pods in page")
+				require.Equal(t, testCase.expectTotal, page.Total, "Incorrect total number of pods")
+			}
+		})
+	}
+}
+
+func TestGetDeploymentImage(t *testing.T) {
+	const image = "127.0.0.1:5000/my-run/myApp@sha256:98ea6e4f216f2fb4b69fff9b3a44842c38686ca685f3f55dc48c5d3fb1107be4"
+	const name = "127.0.0.1:5000/my-run/myApp"
+	const digest = "sha256:98ea6e4f216f2fb4b69fff9b3a44842c38686ca685f3f55dc48c5d3fb1107be4"
+	testCases := []struct {
+		testName        string
+		envName         string
+		deploymentInput deploymentInput
+		expectImages    map[string]*kubernetes.ContainerImage
+		shouldFail      bool
+	}{
+		{
+			testName:        "Basic",
+			envName:         "run",
+			deploymentInput: defaultDeploymentInput,
+			expectImages: map[string]*kubernetes.ContainerImage{
+				"myApp": {Image: image, Name: name, Digest: digest},
+			},
+		},
+		{
+			testName: "No Pods",
+			envName:  "run",
+			deploymentInput: deploymentInput{
+				dcInput: defaultDeploymentConfigInput,
+				rcInput: map[string]string{
+					"my-run": "replicationcontroller-scaled-down.json",
+				},
+				podInput:   defaultPodInput,
+				svcInput:   defaultServiceInput,
+				routeInput: defaultRouteInput,
+			},
+			expectImages: map[string]*kubernetes.ContainerImage{
+				"myApp": {Image: image, Name: name, Digest: digest},
+			},
+		},
+		{
+			testName:        "Bad Environment",
+			envName:         "doesNotExist",
+			deploymentInput: defaultDeploymentInput,
+			shouldFail:      true,
+		},
+	}
+
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.deploymentInput = testCase.deploymentInput
+
+			images, err := kc.GetDeploymentImage("mySpace", "myApp", testCase.envName)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+			} else {
+				require.NoError(t, err, "Unexpected error occurred")
+				require.Equal(t, testCase.expectImages, images, "Incorrect container images")
+			}
+		})
+	}
+}
+
+func TestGetDeploymentPodLogs(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		appName        string
+		envName        string
+		podName        string
+		follow         bool
+		shouldFail     bool
+		expectNotFound bool
+	}{
+		{
+			testName: "Basic",
+			appName:  "myApp",
+			envName:  "run",
+			podName:  "myApp-1-nfs9w",
+		},
+		{
+			testName: "Follow",
+			appName:  "myApp",
+			envName:  "run",
+			podName:  "myApp-1-sdmzq",
+			follow:   true,
+		},
+		{
+			testName:       "Pod Not In Deployment",
+			appName:        "myApp",
+			envName:        "run",
+			podName:        "myOtherApp-1-abcde",
+			shouldFail:     true,
+			expectNotFound: true,
+		},
+		{
+			testName:       "Unknown Application",
+			appName:        "doesNotExist",
+			envName:        "run",
+			podName:        "myApp-1-nfs9w",
+			shouldFail:     true,
+			expectNotFound: true,
+		},
+		{
+			testName:       "Unknown Environment",
+			appName:        "myApp",
+			envName:        "doesNotExist",
+			podName:        "myApp-1-nfs9w",
+			shouldFail:     true,
+			expectNotFound: true,
+		},
+	}
+
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.deploymentInput = defaultDeploymentInput
+			fixture.kube.logsHolder = nil
+
+			logs, err := kc.GetDeploymentPodLogs("mySpace", testCase.appName, testCase.envName, testCase.podName, testCase.follow)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				if testCase.expectNotFound {
+					notFound, _ := errors.IsNotFoundError(err)
+					require.True(t, notFound, "Expected a not found error, got %v", err)
+				}
+				require.Nil(t, fixture.kube.logsHolder, "Logs should not be requested")
+			} else {
+				require.NoError(t, err, "Unexpected error occurred")
+				require.NotNil(t, logs, "Log stream is nil")
+				defer logs.Close()
+				content, err := ioutil.ReadAll(logs)
+				require.NoError(t, err, "Error reading logs")
+				require.Equal(t, "logs of "+testCase.podName, string(content), "Wrong logs returned")
+				logsHolder := fixture.kube.logsHolder
+				require.NotNil(t, logsHolder, "Logs were not requested")
+				require.Equal(t, "my-run", logsHolder.namespace, "Wrong namespace")
+				require.Equal(t, testCase.podName, logsHolder.name, "Wrong pod name")
+				require.NotNil(t, logsHolder.opts, "Log options are nil")
+				require.Equal(t, testCase.follow, logsHolder.opts.Follow, "Wrong follow option")
+			}
+		})
+	}
+}
+
+func TestGetBuildLogs(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		spaceName      string
+		appName        string
+		buildNumber    int
+		expectBuild    string
+		shouldFail     bool
+		expectNotFound bool
+		expectBadParam bool
+	}{
+		{
+			testName:    "Latest Build",
+			spaceName:   "mySpace",
+			appName:     "myApp",
+			expectBuild: "myApp-4",
+		},
+		{
+			testName:    "Previous Build",
+			spaceName:   "mySpace",
+			appName:     "myApp",
+			buildNumber: 2,
+			expectBuild: "myApp-2",
+		},
+		{
+			testName:       "Build Does Not Exist",
+			spaceName:      "mySpace",
+			appName:        "myApp",
+			buildNumber:    7,
+			expectBuild:    "myApp-7",
+			shouldFail:     true,
+			expectNotFound: true,
+		},
+		{
+			testName:       "No Builds Yet",
+			spaceName:      "mySpace",
+			appName:        "myOtherApp",
+			shouldFail:     true,
+			expectNotFound: true,
+		},
+		{
+			testName:       "Unknown Application",
+			spaceName:      "mySpace",
+			appName:        "doesNotExist",
+			shouldFail:     true,
+			expectNotFound: true,
+		},
+		{
+			testName:       "Wrong Space",
+			spaceName:      "otherSpace",
+			appName:        "myApp",
+			shouldFail:     true,
+			expectNotFound: true,
+		},
+		{
+			testName:       "Negative Build Number",
+			spaceName:      "mySpace",
+			appName:        "myApp",
+			buildNumber:    -1,
+			shouldFail:     true,
+			expectBadParam: true,
+		},
+	}
+
+	fixture := &testFixture{
+		bcByName: map[string]string{
+			"myApp":      "buildconfig-one.json",
+			"myOtherApp": "buildconfig-nobuilds.json",
+		},
+		buildLogs: map[string]string{
+			"myApp-2": "logs of build 2",
+			"myApp-4": "logs of build 4",
+		},
+	}
+	kc := getDefaultKubeClient(fixture, t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.os.buildLogHolder = nil
+
+			logs, err := kc.GetBuildLogs(testCase.spaceName, testCase.appName, testCase.buildNumber)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				if testCase.expectNotFound {
+					notFound, _ := errors.IsNotFoundError(err)
+					require.True(t, notFound, "Expected a not found error, got %v", err)
+				}
+				if testCase.expectBadParam {
+					badParam, _ := errors.IsBadParameterError(err)
+					require.True(t, badParam, "Expected a bad parameter error, got %v", err)
+				}
+			} else {
+				require.NoError(t, err, "Unexpected error occurred")
+				require.NotNil(t, logs, "Log stream is nil")
+				defer logs.Close()
+				content, err := ioutil.ReadAll(logs)
+				require.NoError(t, err, "Error reading logs")
+				require.Equal(t, fixture.buildLogs[testCase.expectBuild], string(content), "Wrong logs returned")
+			}
+
+			logHolder := fixture.os.buildLogHolder
+			if len(testCase.expectBuild) == 0 {
+				require.Nil(t, logHolder, "Logs should not be requested")
+			} else {
+				require.NotNil(t, logHolder, "Logs were not requested")
+				require.Equal(t, "myNamespace", logHolder.namespace, "Wrong namespace")
+				require.Equal(t, testCase.expectBuild, logHolder.name, "Wrong build name")
+			}
+		})
+	}
+}
+
+func TestScaleDeployment(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		spaceName      string
+		appName        string
+		envName        string
+		expectedNS     string
+		dcInput        deploymentConfigInput
+		deployInput    deploymentConfigInput
+		scaleInput     deploymentConfigInput
+		newReplicas    int
+		oldReplicas    int
+		expectKube     bool
+		shouldFail     bool
+		expectNotFound bool
+		expectBadParam bool
+	}{
+		{
+			testName:    "Basic",
+			spaceName:   "mySpace",
+			appName:     "myApp",
+			envName:     "run",
+			expectedNS:  "my-run",
+			dcInput:     defaultDeploymentConfigInput,
+			scaleInput:  defaultDeploymentScaleInput,
+			newReplicas: 3,
+			oldReplicas: 2,
+		},
+		{
+			testName:   "Zero Replicas",
+			spaceName:  "mySpace",
+			appName:    "myApp",
+			envName:    "run",
+			expectedNS: "my-run",
+			dcInput:    defaultDeploymentConfigInput,
+			scaleInput: deploymentConfigInput{
+				"myApp": {
+					"my-run": "deployment-scale-zero.json",
+				},
+			},
+			newReplicas: 1,
+			oldReplicas: 0,
+		},
+		{
+			testName:   "Kubernetes Deployment",
+			spaceName:  "mySpace",
+			appName:    "myApp",
+			envName:    "run",
+			expectedNS: "my-run",
+			deployInput: deploymentConfigInput{
+				"myApp": {
+					"my-run": "deployment-one.json",
+				},
+			},
+			scaleInput:  defaultDeploymentScaleInput,
+			newReplicas: 0,
+			oldReplicas: 2,
+			expectKube:  true,
+		},
+		{
+			testName:       "Negative Replicas",
+			spaceName:      "mySpace",
+			appName:        "myApp",
+			envName:        "run",
+			dcInput:        defaultDeploymentConfigInput,
+			scaleInput:     defaultDeploymentScaleInput,
+			newReplicas:    -1,
+			shouldFail:     true,
+			expectBadParam: true,
+		},
+		{
+			testName:       "Unknown Environment",
+			spaceName:      "mySpace",
+			appName:        "myApp",
+			envName:        "doesNotExist",
+			dcInput:        defaultDeploymentConfigInput,
+			scaleInput:     defaultDeploymentScaleInput,
+			newReplicas:    1,
+			shouldFail:     true,
+			expectNotFound: true,
+		},
+		{
+			testName:       "Unknown Application",
+			spaceName:      "mySpace",
+			appName:        "doesNotExist",
+			envName:        "run",
+			dcInput:        defaultDeploymentConfigInput,
+			scaleInput:     defaultDeploymentScaleInput,
+			newReplicas:    1,
+			shouldFail:     true,
+			expectNotFound: true,
+		},
+	}
+
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.dcInput = testCase.dcInput
+			fixture.deployInput = testCase.deployInput
+			fixture.scaleInput = testCase.scaleInput
+			fixture.os.scaleHolder = nil
+			fixture.os.kubeScaleHolder = nil
+
+			old, err := kc.ScaleDeployment(testCase.spaceName, testCase.appName, testCase.envName, testCase.newReplicas)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				if testCase.expectNotFound {
+					notFound, _ := errors.IsNotFoundError(err)
+					require.True(t, notFound, "Expected a not found error, got %v", err)
+				}
+				if testCase.expectBadParam {
+					badParam, _ := errors.IsBadParameterError(err)
+					require.True(t, badParam, "Expected a bad parameter error, got %v", err)
+				}
+				require.Nil(t, fixture.os.scaleHolder, "Deployment config should not be scaled")
+				require.Nil(t, fixture.os.kubeScaleHolder, "Deployment should not be scaled")
+			} else {
+				require.NoError(t, err, "Unexpected error occurred")
+				require.NotNil(t, old, "Previous replicas are nil")
+				require.Equal(t, testCase.oldReplicas, *old, "Wrong number of previous replicas")
+				scaleHolder := fixture.os.scaleHolder
+				if testCase.expectKube {
+					require.Nil(t, scaleHolder, "Deployment config should not be scaled")
+					scaleHolder = fixture.os.kubeScaleHolder
+				} else {
+					require.Nil(t, fixture.os.kubeScaleHolder, "Deployment should not be scaled")
+				}
+				require.NotNil(t, scaleHolder, "No scale results available")
+				require.Equal(t, testCase.expectedNS, scaleHolder.namespace, "Wrong namespace")
+				require.Equal(t, testCase.appName, scaleHolder.dcName, "Wrong deployment config name")
+				// Check spec/replicas modified correctly
+				spec, ok := scaleHolder.scaleOutput["spec"].(map[string]interface{})
+				require.True(t, ok, "Spec property is missing or invalid")
+				newReplicas, ok := spec["replicas"].(int)
+				require.True(t, ok, "Replicas property is missing or invalid")
+				require.Equal(t, testCase.newReplicas, newReplicas, "Wrong modified number of replicas")
+			}
+		})
+	}
+}
+
+func TestRollbackDeployment(t *testing.T) {
+	scaledDownInput := deploymentInput{
+		dcInput: defaultDeploymentConfigInput,
+		// Versions 1 and 2 are complete, 2 is current, 3 failed
+		rcInput: map[string]string{
+			"my-run": "replicationcontroller-scaled-down.json",
+		},
+	}
+	// Version 1 is current, version 3 failed and myApp-2 has a malformed version
+	invalidVersionInput := deploymentInput{
+		dcInput: defaultDeploymentConfigInput,
+		rcInput: map[string]string{
+			"my-run": "replicationcontroller-invalid-version.json",
+		},
+	}
+	kubeInput := deploymentInput{
+		deployInput: deploymentConfigInput{
+			"myApp": {
+				"my-run": "deployment-one.json",
+			},
+		},
+		// Revision 2 is current
+		rsInput: map[string]string{
+			"my-run": "replicasets.json",
+		},
+	}
+	testCases := []struct {
+		testName         string
+		appName          string
+		toVersion        int
+		expectTarget     int64
+		expectNewVersion int
+		expectKube       bool
+		shouldFail       bool
+		expectNotFound   bool
+		expectBadParam   bool
+		deploymentInput
+	}{
+		{
+			testName:         "Previous Version",
+			appName:          "myApp",
+			toVersion:        0,
+			expectTarget:     1,
+			expectNewVersion: 4,
+			deploymentInput:  scaledDownInput,
+		},
+		{
+			testName:         "Failed Version",
+			appName:          "myApp",
+			toVersion:        3,
+			expectTarget:     3,
+			expectNewVersion: 4,
+			deploymentInput:  scaledDownInput,
+		},
+		{
+			testName:         "Kubernetes Deployment",
+			appName:          "myApp",
+			toVersion:        0,
+			expectTarget:     1,
+			expectNewVersion: 3,
+			expectKube:       true,
+			deploymentInput:  kubeInput,
+		},
+		{
+			// The RC with the malformed version is skipped
+			testName:         "Invalid Version",
+			appName:          "myApp",
+			toVersion:        3,
+			expectTarget:     3,
+			expectNewVersion: 4,
+			deploymentInput:  invalidVersionInput,
+		},
+		{
+			testName:        "No Previous Version Besides Invalid Version",
+			appName:         "myApp",
+			toVersion:       0,
+			deploymentInput: invalidVersionInput,
+			shouldFail:      true,
+			expectBadParam:  true,
+		},
+		{
+			testName:        "Current Version",
+			appName:         "myApp",
+			toVersion:       2,
+			deploymentInput: scaledDownInput,
+			shouldFail:      true,
+			expectBadParam:  true,
+		},
+		{
+			testName:        "Negative Version",
+			appName:         "myApp",
+			toVersion:       -1,
+			deploymentInput: scaledDownInput,
+			shouldFail:      true,
+			expectBadParam:  true,
+		},
+		{
+			// The only RC is the current one
+			testName:        "No Previous Version",
+			appName:         "myApp",
+			toVersion:       0,
+			deploymentInput: defaultDeploymentInput,
+			shouldFail:      true,
+			expectBadParam:  true,
+		},
+		{
+			testName:        "Unknown Version",
+			appName:         "myApp",
+			toVersion:       7,
+			deploymentInput: scaledDownInput,
+			shouldFail:      true,
+			expectNotFound:  true,
+		},
+		{
+			testName:        "Unknown Application",
+			appName:         "doesNotExist",
+			toVersion:       0,
+			deploymentInput: scaledDownInput,
+			shouldFail:      true,
+			expectNotFound:  true,
+		},
+	}
+
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.deploymentInput = testCase.deploymentInput
+			fixture.os.rollbackHolder = nil
+			fixture.os.updateDCHolder = nil
+			fixture.os.updateKubeHolder = nil
+
+			newVersion, err := kc.RollbackDeployment("mySpace", testCase.appName, "run", testCase.toVersion)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				if testCase.expectNotFound {
+					notFound, _ := errors.IsNotFoundError(err)
+					require.True(t, notFound, "Expected a not found error, got %v", err)
+				}
+				if testCase.expectBadParam {
+					badParam, _ := errors.IsBadParameterError(err)
+					require.True(t, badParam, "Expected a bad parameter error, got %v", err)
+				}
+				require.Nil(t, fixture.os.updateDCHolder, "Deployment config should not be updated")
+				require.Nil(t, fixture.os.updateKubeHolder, "Deployment should not be updated")
+				return
+			}
+			require.NoError(t, err, "Unexpected error occurred")
+			require.NotNil(t, newVersion, "New version is nil")
+			require.Equal(t, testCase.expectNewVersion, *newVersion, "Wrong new version")
+			if testCase.expectKube {
+				require.Nil(t, fixture.os.rollbackHolder, "Deployment config should not be rolled back")
+				deploy := fixture.os.updateKubeHolder
+				require.NotNil(t, deploy, "Deployment was not updated")
+				spec, ok := deploy["spec"].(map[string]interface{})
+				require.True(t, ok, "Spec property is missing or invalid")
+				template, ok := spec["template"].(v1.PodTemplateSpec)
+				require.True(t, ok, "Template property is missing or invalid")
+				require.Equal(t, "1.0.2", template.Labels["version"], "Wrong pod template applied")
+				require.NotContains(t, template.Labels, "pod-template-hash", "Pod template hash should be removed")
+			} else {
+				require.Nil(t, fixture.os.updateKubeHolder, "Deployment should not be updated")
+				rollback := fixture.os.rollbackHolder
+				require.NotNil(t, rollback, "Deployment config was not rolled back")
+				spec, ok := rollback["spec"].(map[string]interface{})
+				require.True(t, ok, "Spec property is missing or invalid")
+				require.Equal(t, testCase.expectTarget, spec["revision"], "Wrong version rolled back to")
+				require.NotNil(t, fixture.os.updateDCHolder, "Deployment config was not updated")
+			}
+		})
+	}
+}
+
+func TestGetDeploymentRolloutStatus(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		appName        string
+		expectStatus   *kubernetes.RolloutStatus
+		shouldFail     bool
+		expectNotFound bool
+		deploymentInput
+	}{
+		{
+			testName: "Complete",
+			appName:  "myApp",
+			expectStatus: &kubernetes.RolloutStatus{
+				Phase:           kubernetes.RolloutComplete,
+				Version:         1,
+				ReadyReplicas:   2,
+				DesiredReplicas: 2,
+			},
+			deploymentInput: defaultDeploymentInput,
+		},
+		{
+			testName: "Running",
+			appName:  "myApp",
+			expectStatus: &kubernetes.RolloutStatus{
+				Phase:           kubernetes.RolloutRunning,
+				Version:         2,
+				ReadyReplicas:   1,
+				DesiredReplicas: 2,
+			},
+			deploymentInput: deploymentInput{
+				dcInput: defaultDeploymentConfigInput,
+				// Version 1 is complete, version 2 is being rolled out
+				rcInput: map[string]string{
+					"my-run": "replicationcontroller-rollout-running.json",
+				},
+			},
+		},
+		{
+			// The failed version 3 isn't the current deployment, but it's the
+			// latest rollout
+			testName: "Failed",
+			appName:  "myApp",
+			expectStatus: &kubernetes.RolloutStatus{
+				Phase:           kubernetes.RolloutFailed,
+				Version:         3,
+				ReadyReplicas:   0,
+				DesiredReplicas: 1,
+			},
+			deploymentInput: deploymentInput{
+				dcInput: defaultDeploymentConfigInput,
+				// Versions 1 and 2 are complete, 2 is current, 3 failed
+				rcInput: map[string]string{
+					"my-run": "replicationcontroller-scaled-down.json",
+				},
+			},
+		},
+		{
+			testName: "No Rollout Yet",
+			appName:  "myApp",
+			expectStatus: &kubernetes.RolloutStatus{
+				Phase: kubernetes.RolloutPending,
+			},
+			deploymentInput: deploymentInput{
+				dcInput: defaultDeploymentConfigInput,
+			},
+		},
+		{
+			// ReplicaSets have no phase, revision 2 has all pods ready
+			testName: "Kubernetes Deployment",
+			appName:  "myApp",
+			expectStatus: &kubernetes.RolloutStatus{
+				Phase:           kubernetes.RolloutComplete,
+				Version:         2,
+				ReadyReplicas:   2,
+				DesiredReplicas: 2,
+			},
+			deploymentInput: deploymentInput{
+				deployInput: deploymentConfigInput{
+					"myApp": {
+						"my-run": "deployment-one.json",
+					},
+				},
+				rsInput: map[string]string{
+					"my-run": "replicasets.json",
+				},
+			},
+		},
+		{
+			testName:        "Unknown Application",
+			appName:         "doesNotExist",
+			deploymentInput: defaultDeploymentInput,
+			shouldFail:      true,
+			expectNotFound:  true,
+		},
+	}
+
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.deploymentInput = testCase.deploymentInput
+
+			status, err := kc.GetDeploymentRolloutStatus("mySpace", testCase.appName, "run")
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				if testCase.expectNotFound {
+					notFound, _ := errors.IsNotFoundError(err)
+					require.True(t, notFound, "Expected a not found error, got %v", err)
+				}
+				return
+			}
+			require.NoError(t, err, "Unexpected error occurred")
+			require.Equal(t, testCase.expectStatus, status, "Wrong rollout status")
+		})
+	}
+}
+
+func TestGetDeploymentVersionHistory(t *testing.T) {
+	created := func(value string) time.Time {
+		result, err := time.Parse(time.RFC3339, value)
+		require.NoError(t, err)
+		return result
+	}
+	testCases := []struct {
+		testName       string
+		appName        string
+		expectHistory  []*kubernetes.DeploymentVersion
+		shouldFail     bool
+		expectNotFound bool
+		deploymentInput
+	}{
+		{
+			// Ordered by version, not by creation time
+			testName: "Basic",
+			appName:  "myApp",
+			expectHistory: []*kubernetes.DeploymentVersion{
+				{
+					Version:         3,
+					Name:            "myApp-3",
+					Created:         created("2018-01-23T16:33:03Z"),
+					DesiredReplicas: 1,
+				},
+				{
+					Version: 2,
+					Name:    "myApp-2",
+					Created: created("2018-01-25T16:33:03Z"),
+				},
+				{
+					Version:         1,
+					Name:            "myApp-1",
+					Created:         created("2018-01-24T16:33:03Z"),
+					Replicas:        2,
+					ReadyReplicas:   2,
+					DesiredReplicas: 2,
+				},
+			},
+			deploymentInput: deploymentInput{
+				dcInput: defaultDeploymentConfigInput,
+				rcInput: map[string]string{
+					"my-run": "replicationcontroller-scaled-down.json",
+				},
+			},
+		},
+		{
+			// An RC with a malformed version is listed without a version after the others
+			testName: "Invalid Version",
+			appName:  "myApp",
+			expectHistory: []*kubernetes.DeploymentVersion{
+				{
+					Version:         3,
+					Name:            "myApp-3",
+					Created:         created("2018-01-23T16:33:03Z"),
+					DesiredReplicas: 1,
+				},
+				{
+					Version:         1,
+					Name:            "myApp-1",
+					Created:         created("2018-01-24T16:33:03Z"),
+					Replicas:        2,
+					ReadyReplicas:   2,
+					DesiredReplicas: 2,
+				},
+				{
+					Name:    "myApp-2",
+					Created: created("2018-01-25T16:33:03Z"),
+				},
+			},
+			deploymentInput: deploymentInput{
+				dcInput: defaultDeploymentConfigInput,
+				rcInput: map[string]string{
+					"my-run": "replicationcontroller-invalid-version.json",
+				},
+			},
+		},
+		{
+			testName:      "No Rollout Yet",
+			appName:       "myApp",
+			expectHistory: []*kubernetes.DeploymentVersion{},
+			deploymentInput: deploymentInput{
+				dcInput: defaultDeploymentConfigInput,
+			},
+		},
+		{
+			testName: "Kubernetes Deployment",
+			appName:  "myApp",
+			expectHistory: []*kubernetes.DeploymentVersion{
+				{
+					Version:         2,
+					Name:            "myApp-7d9c8b5f6",
+					Created:         created("2018-01-25T17:10:00Z"),
+					Replicas:        2,
+					ReadyReplicas:   2,
+					DesiredReplicas: 2,
+				},
+				{
+					Version: 1,
+					Name:    "myApp-5c6d4b8f9",
+					Created: created("2018-01-25T16:33:03Z"),
+				},
+			},
+			deploymentInput: deploymentInput{
+				deployInput: deploymentConfigInput{
+					"myApp": {
+						"my-run": "deployment-one.json",
+					},
+				},
+				rsInput: map[string]string{
+					"my-run": "replicasets.json",
+				},
+			},
+		},
+		{
+			testName:        "Unknown Application",
+			appName:         "doesNotExist",
+			deploymentInput: defaultDeploymentInput,
+			shouldFail:      true,
+			expectNotFound:  true,
+		},
+	}
+
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.deploymentInput = testCase.deploymentInput
+
+			history, err := kc.GetDeploymentVersionHistory("mySpace", testCase.appName, "run")
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				if testCase.expectNotFound {
+					notFound, _ := errors.IsNotFoundError(err)
+					require.True(t, notFound, "Expected a not found error, got %v", err)
+				}
+				return
+			}
+			require.NoError(t, err, "Unexpected error occurred")
+			require.Len(t, history, len(testCase.expectHistory), "Wrong number of versions")
+			for idx, expected := range testCase.expectHistory {
+				actual := history[idx]
+				require.Equal(t, expected.Version, actual.Version, "Wrong version at %d", idx)
+				require.Equal(t, expected.Name, actual.Name, "Wrong name at %d", idx)
+				require.True(t, expected.Created.Equal(actual.Created), "Wrong creation time at %d", idx)
+				require.Equal(t, expected.Replicas, actual.Replicas, "Wrong replicas at %d", idx)
+				require.Equal(t, expected.ReadyReplicas, actual.ReadyReplicas, "Wrong ready replicas at %d", idx)
+				require.Equal(t, expected.DesiredReplicas, actual.DesiredReplicas, "Wrong desired replicas at %d", idx)
+			}
+		})
+	}
+}
+
+func TestDeleteDeployment(t *testing.T) {
+	// DeleteOptions do not change
+	policy := metav1.DeletePropagationForeground
+	expectOpts := &metav1.DeleteOptions{
+		PropagationPolicy: &policy,
+	}
+	expectServices := []string{"myApp", "myOtherApp"} // Test impl doesn't check labels
+	expectRoutes := expectServices                    // Our routes use the same names as their services
+	testCases := []struct {
+		testName       string
+		spaceName      string
+		appName        string
+		envName        string
+		expectNS       string
+		expectSelector string
+		expectServices []string
+		expectRoutes   []string
+		shouldFail     bool
+		deploymentInput
+	}{
+		{
+			testName:        "Basic",
+			spaceName:       "mySpace",
+			appName:         "myApp",
+			envName:         "run",
+			expectNS:        "my-run",
+			expectSelector:  "app%3DmyApp",
+			expectServices:  expectServices,
+			expectRoutes:    expectRoutes,
+			deploymentInput: defaultDeploymentInput,
+		},
+		{
+			testName:        "Bad Environment",
+			spaceName:       "mySpace",
+			appName:         "myApp",
+			envName:         "doesNotExist",
+			expectNS:        "my-run",
+			expectSelector:  "app%3DmyApp",
+			expectServices:  expectServices,
+			expectRoutes:    expectRoutes,
+			deploymentInput: defaultDeploymentInput,
+			shouldFail:      true,
+		},
+		{
+			testName:        "Wrong Space",
+			spaceName:       "otherSpace",
+			appName:         "myApp",
+			envName:         "run",
+			expectNS:        "my-run",
+			expectSelector:  "app%3DmyApp",
+			expectServices:  expectServices,
+			expectRoutes:    expectRoutes,
+			deploymentInput: defaultDeploymentInput,
+			shouldFail:      true,
+		},
+		{
+			testName:       "No Routes",
+			spaceName:      "mySpace",
+			appName:        "myApp",
+			envName:        "run",
+			expectNS:       "my-run",
+			expectSelector: "app%3DmyApp",
+			expectServices: expectServices,
+			expectRoutes:   []string{},
+			deploymentInput: deploymentInput{
+				dcInput:  defaultDeploymentConfigInput,
+				rcInput:  defaultReplicationControllerInput,
+				podInput: defaultPodInput,
+				svcInput: defaultServiceInput,
+				routeInput: map[string]string{
+					"my-run": "routes-zero.json",
+				},
+			},
+		},
+		{
+			testName:       "No Services",
+			spaceName:      "mySpace",
+			appName:        "myApp",
+			envName:        "run",
+			expectNS:       "my-run",
 			expectSelector: "app%3DmyApp",
 			expectServices: []string{},
 			expectRoutes:   expectRoutes,
@@ -1608,6 +3273,67 @@ func TestGetDeploymentStats(t *testing.T) {
 	}
 }
 
+func TestGetDeploymentStatsForEnvironments(t *testing.T) {
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		testName     string
+		ctx          context.Context
+		envNames     []string
+		expectStats  []string
+		expectErrors []string
+	}{
+		{
+			// Only the run environment has a deployment of the application
+			testName:    "All Environments",
+			ctx:         context.Background(),
+			expectStats: []string{"run"},
+		},
+		{
+			testName:     "Partial Failure",
+			ctx:          context.Background(),
+			envNames:     []string{"run", "stage", "doesNotExist"},
+			expectStats:  []string{"run"},
+			expectErrors: []string{"doesNotExist"},
+		},
+		{
+			testName:     "Cancelled",
+			ctx:          cancelledCtx,
+			envNames:     []string{"run", "stage"},
+			expectErrors: []string{"run", "stage"},
+		},
+	}
+
+	fixture := &testFixture{}
+	kc := getDefaultKubeClient(fixture, t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fixture.deploymentInput = defaultDeploymentInput
+			fixture.metricsInput = defaultMetricsInput
+
+			statsByEnv, errsByEnv := kc.GetDeploymentStatsForEnvironments(testCase.ctx, "mySpace", "myApp",
+				testCase.envNames, convertToTime(1517867603000))
+			require.Len(t, statsByEnv, len(testCase.expectStats), "Wrong number of environments with statistics")
+			for _, envName := range testCase.expectStats {
+				stats := statsByEnv[envName]
+				require.NotNil(t, stats, "No statistics for environment %s", envName)
+				require.NotNil(t, stats.Attributes, "Stat attributes are nil")
+				require.Equal(t, defaultMetricsInput.cpu[0], stats.Attributes.Cores, "Incorrect CPU metrics returned")
+				require.Equal(t, defaultMetricsInput.memory[0], stats.Attributes.Memory, "Incorrect memory metrics returned")
+			}
+			require.Len(t, errsByEnv, len(testCase.expectErrors), "Wrong number of failed environments")
+			for _, envName := range testCase.expectErrors {
+				require.Error(t, errsByEnv[envName], "No error for environment %s", envName)
+				if testCase.ctx.Err() != nil {
+					require.Equal(t, context.Canceled, errsByEnv[envName], "Expected context to be cancelled")
+				}
+			}
+		})
+	}
+}
+
 func TestGetDeploymentStatSeries(t *testing.T) {
 	testCases := []*deployStatsTestData{
 		defaultDeployStatsTestData,
@@ -1,12 +1,20 @@
 package kubernetes
 
 import (
+	"context"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	errs "github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/pkg/api/v1"
 )
@@ -15,6 +23,7 @@ func TestGetMostRecentByDeploymentVersion(t *testing.T) {
 	testCases := []struct {
 		testName       string
 		rcs            map[string]*v1.ReplicationController
+		annotation     string
 		expectedRCName string
 		shouldFail     bool
 	}{
@@ -31,11 +40,31 @@ func TestGetMostRecentByDeploymentVersion(t *testing.T) {
 			rcs:      map[string]*v1.ReplicationController{},
 		},
 		{
+			// RCs with an invalid version are skipped
 			testName: "Version Not Number",
 			rcs: map[string]*v1.ReplicationController{
 				"world": createRC("world", "1"),
 				"hello": createRC("hello", "Not a number"),
 			},
+			expectedRCName: "world",
+		},
+		{
+			testName: "One Version Not Number Among Several",
+			rcs: map[string]*v1.ReplicationController{
+				"a": createRC("a", "1"),
+				"b": createRC("b", "3"),
+				"c": createRC("c", "Not a number"),
+				"d": createRC("d", "2"),
+				"e": createRC("e", ""),
+			},
+			expectedRCName: "b",
+		},
+		{
+			testName: "All Versions Not Number",
+			rcs: map[string]*v1.ReplicationController{
+				"world": createRC("world", "Not a number"),
+				"hello": createRC("hello", "Also not a number"),
+			},
 			shouldFail: true,
 		},
 		{
@@ -54,6 +83,22 @@ func TestGetMostRecentByDeploymentVersion(t *testing.T) {
 			},
 			expectedRCName: "world",
 		},
+		{
+			testName: "Same Version Created At Different Times",
+			rcs: map[string]*v1.ReplicationController{
+				"world": createRCCreatedAt("world", "2", time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)),
+				"hello": createRCCreatedAt("hello", "2", time.Date(2018, 1, 1, 13, 0, 0, 0, time.UTC)),
+			},
+			expectedRCName: "hello",
+		},
+		{
+			testName: "Same Version Created At Same Time",
+			rcs: map[string]*v1.ReplicationController{
+				"hello": createRC("hello", "2"),
+				"world": createRC("world", "2"),
+			},
+			expectedRCName: "world",
+		},
 		{
 			testName: "Both Without Version",
 			rcs: map[string]*v1.ReplicationController{
@@ -62,11 +107,43 @@ func TestGetMostRecentByDeploymentVersion(t *testing.T) {
 			},
 			expectedRCName: "world",
 		},
+		{
+			testName: "Custom Annotation",
+			rcs: map[string]*v1.ReplicationController{
+				"world": createRCWithAnnotation("world", "example.com/version", "1"),
+				"hello": createRCWithAnnotation("hello", "example.com/version", "2"),
+			},
+			annotation:     "example.com/version",
+			expectedRCName: "hello",
+		},
+		{
+			// The default annotation must not be considered when a custom one is used
+			testName: "Custom Annotation Ignores Default",
+			rcs: map[string]*v1.ReplicationController{
+				"world": createRCWithAnnotation("world", "example.com/version", "1"),
+				"hello": createRC("hello", "2"),
+			},
+			annotation:     "example.com/version",
+			expectedRCName: "world",
+		},
+		{
+			testName: "Custom Annotation Not Number",
+			rcs: map[string]*v1.ReplicationController{
+				"world": createRCWithAnnotation("world", "example.com/version", "1"),
+				"hello": createRCWithAnnotation("hello", "example.com/version", "Not a number"),
+			},
+			annotation:     "example.com/version",
+			expectedRCName: "world",
+		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.testName, func(t *testing.T) {
-			result, err := getMostRecentByDeploymentVersion(testCase.rcs)
+			annotation := testCase.annotation
+			if len(annotation) == 0 {
+				annotation = deploymentVersionAnnotation
+			}
+			result, err := getMostRecentByDeploymentVersion(testCase.rcs, annotation)
 			if testCase.shouldFail {
 				require.Error(t, err, "Expected an error")
 			} else {
@@ -82,10 +159,144 @@ func TestGetMostRecentByDeploymentVersion(t *testing.T) {
 	}
 }
 
+func TestGetMostRecentWithReplicas(t *testing.T) {
+	desired := int32(3)
+	older := createRC("world", "1")
+	older.Status.Replicas = 1
+	newer := createRC("hello", "2")
+	newer.Spec.Replicas = &desired
+	newer.Status.Replicas = 2
+	newer.Status.ReadyReplicas = 1
+	noDesired := createRC("hello", "2")
+	noDesired.Status.Replicas = 2
+
+	testCases := []struct {
+		testName   string
+		rcs        map[string]*v1.ReplicationController
+		expected   *rcWithReplicas
+		shouldFail bool
+	}{
+		{
+			testName: "Basic",
+			rcs: map[string]*v1.ReplicationController{
+				"world": older,
+				"hello": newer,
+			},
+			expected: &rcWithReplicas{
+				rc:              newer,
+				replicas:        2,
+				readyReplicas:   1,
+				desiredReplicas: 3,
+			},
+		},
+		{
+			testName: "Desired Replicas Not Set",
+			rcs: map[string]*v1.ReplicationController{
+				"world": older,
+				"hello": noDesired,
+			},
+			expected: &rcWithReplicas{
+				rc:       noDesired,
+				replicas: 2,
+			},
+		},
+		{
+			testName: "Empty",
+			rcs:      map[string]*v1.ReplicationController{},
+		},
+		{
+			testName: "Version Not Number",
+			rcs: map[string]*v1.ReplicationController{
+				"hello": createRC("hello", "Not a number"),
+			},
+			shouldFail: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			result, err := getMostRecentWithReplicas(testCase.rcs, deploymentVersionAnnotation)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+			} else {
+				require.NoError(t, err, "Unexpected error occurred")
+				require.Equal(t, testCase.expected, result, "Wrong result")
+			}
+		})
+	}
+}
+
+func TestGetMostRecentByRevision(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		rss            map[string]*v1.ReplicationController
+		expectedRSName string
+		shouldFail     bool
+	}{
+		{
+			testName: "Basic",
+			rss: map[string]*v1.ReplicationController{
+				"world": createRS("world", "1"),
+				"hello": createRS("hello", "2"),
+			},
+			expectedRSName: "hello",
+		},
+		{
+			testName: "Multi-Digit Revision",
+			rss: map[string]*v1.ReplicationController{
+				"world": createRS("world", "10"),
+				"hello": createRS("hello", "9"),
+			},
+			expectedRSName: "world",
+		},
+		{
+			testName: "Revision Not Number",
+			rss: map[string]*v1.ReplicationController{
+				"world": createRS("world", "1"),
+				"hello": createRS("hello", "Not a number"),
+			},
+			expectedRSName: "world",
+		},
+		{
+			testName: "All Revisions Not Number",
+			rss: map[string]*v1.ReplicationController{
+				"hello": createRS("hello", "Not a number"),
+			},
+			shouldFail: true,
+		},
+		{
+			// Deployment versions of DeploymentConfigs are not considered
+			testName: "Deployment Version Ignored",
+			rss: map[string]*v1.ReplicationController{
+				"world": createRS("world", "1"),
+				"hello": createRC("hello", "2"),
+			},
+			expectedRSName: "world",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			result, err := getMostRecentByRevision(testCase.rss)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+			} else {
+				require.NoError(t, err, "Unexpected error occurred")
+				require.NotNil(t, result, "Expected result to not be nil")
+				require.Equal(t, testCase.expectedRSName, result.Name)
+			}
+		})
+	}
+}
+
 func createRC(name string, version string) *v1.ReplicationController {
+	return createRCWithAnnotation(name, "openshift.io/deployment-config.latest-version", version)
+}
+
+func createRCWithAnnotation(name string, annotation string, version string) *v1.ReplicationController {
 	annotations := make(map[string]string)
 	if len(version) > 0 {
-		annotations["openshift.io/deployment-config.latest-version"] = version
+		annotations[annotation] = version
 	}
 	return &v1.ReplicationController{
 		ObjectMeta: metav1.ObjectMeta{
@@ -95,10 +306,103 @@ func createRC(name string, version string) *v1.ReplicationController {
 	}
 }
 
+func createRCCreatedAt(name string, version string, created time.Time) *v1.ReplicationController {
+	rc := createRC(name, version)
+	rc.CreationTimestamp = metav1.NewTime(created)
+	return rc
+}
+
+func createRS(name string, revision string) *v1.ReplicationController {
+	return &v1.ReplicationController{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				"deployment.kubernetes.io/revision": revision,
+			},
+		},
+	}
+}
+
+func TestPodsByCreation(t *testing.T) {
+	created := time.Date(2018, time.January, 25, 16, 33, 6, 0, time.UTC)
+	pods := []*v1.Pod{
+		createPod("myApp-1-c", created.Add(time.Minute)),
+		createPod("myApp-1-b", created),
+		createPod("myApp-1-d", created.Add(-time.Minute)),
+		createPod("myApp-1-a", created),
+	}
+	sort.Sort(podsByCreation(pods))
+
+	names := []string{}
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	// Pods created at the same time are ordered by name
+	require.Equal(t, []string{"myApp-1-d", "myApp-1-a", "myApp-1-b", "myApp-1-c"}, names, "Pods sorted incorrectly")
+}
+
+func createPod(name string, created time.Time) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}
+}
+
+func TestRedactToken(t *testing.T) {
+	testCases := []struct {
+		testName string
+		token    string
+		expected string
+	}{
+		{testName: "Empty", token: "", expected: ""},
+		{testName: "Shorter Than Visible Part", token: "abc", expected: "***"},
+		{testName: "Same Length As Visible Part", token: "abcd", expected: "****"},
+		{testName: "Long Token", token: "abcdefgh", expected: "****efgh"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			require.Equal(t, testCase.expected, redactToken(testCase.token))
+		})
+	}
+}
+
+func TestParseImageReference(t *testing.T) {
+	const digest = "sha256:98ea6e4f216f2fb4b69fff9b3a44842c38686ca685f3f55dc48c5d3fb1107be4"
+	testCases := []struct {
+		testName     string
+		ref          string
+		expectName   string
+		expectTag    string
+		expectDigest string
+	}{
+		{testName: "Name Only", ref: "myApp", expectName: "myApp"},
+		{testName: "Tag", ref: "project/myApp:1.0.1", expectName: "project/myApp", expectTag: "1.0.1"},
+		{testName: "Registry Port", ref: "127.0.0.1:5000/project/myApp", expectName: "127.0.0.1:5000/project/myApp"},
+		{testName: "Registry Port And Tag", ref: "127.0.0.1:5000/project/myApp:latest",
+			expectName: "127.0.0.1:5000/project/myApp", expectTag: "latest"},
+		{testName: "Digest", ref: "127.0.0.1:5000/project/myApp@" + digest,
+			expectName: "127.0.0.1:5000/project/myApp", expectDigest: digest},
+		{testName: "Tag And Digest", ref: "project/myApp:1.0.1@" + digest,
+			expectName: "project/myApp", expectTag: "1.0.1", expectDigest: digest},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			name, tag, digest := parseImageReference(testCase.ref)
+			require.Equal(t, testCase.expectName, name, "Wrong image name")
+			require.Equal(t, testCase.expectTag, tag, "Wrong tag")
+			require.Equal(t, testCase.expectDigest, digest, "Wrong digest")
+		})
+	}
+}
+
 func TestGetKubeRESTAPI(t *testing.T) {
 	config := getKubeConfigWithTimeout()
 	getter := &defaultGetter{}
-	restAPI, err := getter.GetKubeRESTAPI(config)
+	restAPI, err := getter.GetKubeRESTAPI(context.Background(), config)
 	require.NoError(t, err, "Error occurred getting Kubernetes REST API")
 
 	// Get config from underlying kubeAPIClient struct
@@ -111,10 +415,186 @@ func TestGetKubeRESTAPI(t *testing.T) {
 	require.Equal(t, config.Timeout, restConfig.Timeout, "Timeouts do not match")
 }
 
+func TestWatchClient(t *testing.T) {
+	config := getKubeConfigWithTimeout()
+	getter := &defaultGetter{}
+	restAPI, err := getter.GetKubeRESTAPI(context.Background(), config)
+	require.NoError(t, err, "Error occurred getting Kubernetes REST API")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchAPI, err := restAPI.WatchClient(ctx)
+	require.NoError(t, err, "Error occurred getting watch client")
+	client, ok := watchAPI.(*kubeAPIClient)
+	require.True(t, ok, "WatchClient returned %s instead of *kubeAPIClient", reflect.TypeOf(watchAPI))
+	require.Equal(t, config.ClusterURL, client.restConfig.Host, "Host config is not set to cluster URL")
+	require.Equal(t, time.Duration(0), client.restConfig.Timeout, "Watches must not time out")
+	rt, ok := client.restConfig.WrapTransport(http.DefaultTransport).(*contextRoundTripper)
+	require.True(t, ok, "Watch requests are not bound to a context")
+	require.Equal(t, ctx, rt.ctx, "Watch requests are not bound to the given context")
+	// The client of single requests keeps its timeout
+	require.Equal(t, config.Timeout, restAPI.(*kubeAPIClient).restConfig.Timeout, "Timeouts do not match")
+}
+
+func TestGetPodLogs(t *testing.T) {
+	const logDelay = 500 * time.Millisecond
+	var requestPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		w.Write([]byte("first line\n"))
+		w.(http.Flusher).Flush()
+		// Produce more log output only after the client timeout has passed
+		time.Sleep(logDelay)
+		w.Write([]byte("second line\n"))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		testName   string
+		follow     bool
+		shouldFail bool
+	}{
+		{testName: "Timeout Applies Without Follow", follow: false, shouldFail: true},
+		{testName: "No Timeout With Follow", follow: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			config := getKubeConfigWithTimeout()
+			config.ClusterURL = server.URL
+			config.Timeout = logDelay / 5
+			getter := &defaultGetter{}
+			restAPI, err := getter.GetKubeRESTAPI(context.Background(), config)
+			require.NoError(t, err, "Error occurred getting Kubernetes REST API")
+
+			logs, err := restAPI.GetPodLogs("myNamespace", "myPod", &v1.PodLogOptions{Follow: testCase.follow})
+			require.NoError(t, err, "Error occurred getting pod logs")
+			defer logs.Close()
+			require.Equal(t, "/api/v1/namespaces/myNamespace/pods/myPod/log", requestPath, "Wrong URL requested")
+			content, err := ioutil.ReadAll(logs)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected the timeout to interrupt reading logs")
+			} else {
+				require.NoError(t, err, "Unexpected error reading logs")
+				require.Equal(t, "first line\nsecond line\n", string(content), "Wrong logs returned")
+			}
+		})
+	}
+}
+
+func TestTransportConfiguration(t *testing.T) {
+	caServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer caServer.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: caServer.TLS.Certificates[0].Certificate[0],
+	})
+
+	testCases := []struct {
+		testName     string
+		proxyURL     string
+		caBundle     []byte
+		insecure     bool
+		expectCustom bool
+		shouldFail   bool
+	}{
+		{testName: "Defaults"},
+		{testName: "Proxy", proxyURL: "http://proxy.example.com:3128", expectCustom: true},
+		{testName: "CA Bundle", caBundle: caBundle, expectCustom: true},
+		{testName: "Insecure", insecure: true, expectCustom: true},
+		{testName: "Invalid Proxy URL", proxyURL: "://proxy.example.com", shouldFail: true},
+		{testName: "Invalid CA Bundle", caBundle: []byte("not a certificate"), shouldFail: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			config := getKubeConfigWithTimeout()
+			config.ProxyURL = testCase.proxyURL
+			config.CABundle = testCase.caBundle
+			config.InsecureSkipTLSVerify = testCase.insecure
+			getter := &defaultGetter{}
+
+			kubeAPI, err := getter.GetKubeRESTAPI(context.Background(), config)
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected an error")
+				_, err = getter.GetOpenShiftRESTAPI(context.Background(), config)
+				require.Error(t, err, "Expected an error")
+				return
+			}
+			require.NoError(t, err, "Error occurred getting Kubernetes REST API")
+			kubeTransport := kubeAPI.(*kubeAPIClient).restConfig.Transport
+			osAPI, err := getter.GetOpenShiftRESTAPI(context.Background(), config)
+			require.NoError(t, err, "Error occurred getting OpenShift REST API")
+			osTransport := osAPI.(*openShiftAPIClient).httpClient.Transport
+			if !testCase.expectCustom {
+				require.Nil(t, kubeTransport, "Kubernetes REST API should use the default transport")
+				require.Nil(t, osTransport, "OpenShift REST API should use the default transport")
+				return
+			}
+
+			for _, transport := range []http.RoundTripper{kubeTransport, osTransport} {
+				httpTransport, ok := transport.(*http.Transport)
+				require.True(t, ok, "Transport is not a *http.Transport")
+				require.NotNil(t, httpTransport.TLSClientConfig, "TLS configuration is nil")
+				require.Equal(t, testCase.insecure, httpTransport.TLSClientConfig.InsecureSkipVerify,
+					"Wrong TLS verification setting")
+				if testCase.caBundle != nil {
+					require.NotNil(t, httpTransport.TLSClientConfig.RootCAs, "CA bundle not applied")
+				} else {
+					require.Nil(t, httpTransport.TLSClientConfig.RootCAs, "System CAs should be used")
+				}
+				if len(testCase.proxyURL) > 0 {
+					req, err := http.NewRequest("GET", config.ClusterURL, nil)
+					require.NoError(t, err, "Error creating request")
+					proxyURL, err := httpTransport.Proxy(req)
+					require.NoError(t, err, "Error getting proxy")
+					require.Equal(t, &url.URL{Scheme: "http", Host: "proxy.example.com:3128"}, proxyURL, "Wrong proxy")
+				}
+			}
+		})
+	}
+}
+
+func TestCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.TLS.Certificates[0].Certificate[0],
+	})
+
+	testCases := []struct {
+		testName   string
+		caBundle   []byte
+		shouldFail bool
+	}{
+		{testName: "Trusted", caBundle: caBundle},
+		{testName: "Untrusted", shouldFail: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			config := getKubeConfigWithTimeout()
+			config.ClusterURL = server.URL
+			config.CABundle = testCase.caBundle
+			restAPI, err := (&defaultGetter{}).GetOpenShiftRESTAPI(context.Background(), config)
+			require.NoError(t, err, "Error occurred getting OpenShift REST API")
+
+			// The server responds with 404, which is allowed for deployment configs
+			_, err = restAPI.GetDeploymentConfig("myNamespace", "myApp")
+			if testCase.shouldFail {
+				require.Error(t, err, "Expected the server certificate to be rejected")
+			} else {
+				require.NoError(t, err, "Server certificate was not trusted")
+			}
+		})
+	}
+}
+
 func TestGetOpenShiftRESTAPI(t *testing.T) {
 	config := getKubeConfigWithTimeout()
 	getter := &defaultGetter{}
-	restAPI, err := getter.GetOpenShiftRESTAPI(config)
+	restAPI, err := getter.GetOpenShiftRESTAPI(context.Background(), config)
 	require.NoError(t, err, "Error occurred getting OpenShift REST API")
 
 	// Check that fields are correct in underlying openShiftAPIClient struct
@@ -125,6 +605,222 @@ func TestGetOpenShiftRESTAPI(t *testing.T) {
 	require.Equal(t, config.Timeout, client.httpClient.Timeout, "Timeouts do not match")
 }
 
+func TestOpenShiftRequestID(t *testing.T) {
+	var receivedRequestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedRequestID = r.Header.Get("X-Request-Id")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		testName  string
+		requestID string
+	}{
+		{testName: "With Request ID", requestID: "myRequestID"},
+		{testName: "Without Request ID", requestID: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			receivedRequestID = ""
+			config := getKubeConfigWithTimeout()
+			config.ClusterURL = server.URL
+			config.RequestID = testCase.requestID
+			getter := &defaultGetter{}
+			restAPI, err := getter.GetOpenShiftRESTAPI(context.Background(), config)
+			require.NoError(t, err, "Error occurred getting OpenShift REST API")
+
+			_, err = restAPI.GetDeploymentConfig("myNamespace", "myApp")
+			require.Error(t, err, "Expected an error")
+			require.Equal(t, testCase.requestID, receivedRequestID, "Request ID was not forwarded")
+			if testCase.requestID != "" {
+				require.Contains(t, err.Error(), testCase.requestID, "Request ID is missing from error")
+			}
+		})
+	}
+}
+
+func TestContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Never respond unless the client gives up
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		testName string
+		request  func(ctx context.Context, config *KubeClientConfig) error
+		// Whether the context error is returned unwrapped by the HTTP client
+		expectCause bool
+	}{
+		{
+			testName: "Kubernetes",
+			request: func(ctx context.Context, config *KubeClientConfig) error {
+				restAPI, err := (&defaultGetter{}).GetKubeRESTAPI(ctx, config)
+				require.NoError(t, err, "Error occurred getting Kubernetes REST API")
+				_, err = restAPI.Pods("myNamespace").List(metav1.ListOptions{})
+				return err
+			},
+		},
+		{
+			testName: "OpenShift",
+			request: func(ctx context.Context, config *KubeClientConfig) error {
+				restAPI, err := (&defaultGetter{}).GetOpenShiftRESTAPI(ctx, config)
+				require.NoError(t, err, "Error occurred getting OpenShift REST API")
+				_, err = restAPI.GetDeploymentConfig("myNamespace", "myApp")
+				return err
+			},
+			expectCause: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			config := getKubeConfigWithTimeout()
+			config.ClusterURL = server.URL
+			ctx, cancel := context.WithCancel(context.Background())
+			// Cancel while the request is in flight
+			timer := time.AfterFunc(100*time.Millisecond, cancel)
+			defer timer.Stop()
+
+			start := time.Now()
+			err := testCase.request(ctx, config)
+			require.Error(t, err, "Expected an error")
+			require.Contains(t, err.Error(), context.Canceled.Error(), "Expected a context error")
+			require.True(t, time.Since(start) < 5*time.Second, "Request was not aborted promptly")
+			if testCase.expectCause {
+				require.Equal(t, context.Canceled, errs.Cause(err), "Expected the context error as cause")
+			}
+		})
+	}
+}
+
+func TestPingWithoutRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := getKubeConfigWithTimeout()
+	config.ClusterURL = server.URL
+	config.MaxRetries = 2
+	config.KubeRESTAPIGetter = &defaultGetter{}
+	kc := &kubeClient{
+		config: config,
+	}
+
+	_, err := kc.Ping(context.Background())
+	require.Error(t, err, "Expected an error")
+	require.Equal(t, 1, attempts, "Ping should not be retried")
+	require.Equal(t, 2, config.MaxRetries, "Ping should not modify the client's configuration")
+}
+
+func TestPingTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Never respond unless the client gives up
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	config := getKubeConfigWithTimeout()
+	config.ClusterURL = server.URL
+	config.Timeout = 100 * time.Millisecond
+	config.KubeRESTAPIGetter = &defaultGetter{}
+	kc := &kubeClient{
+		config: config,
+	}
+
+	latency, err := kc.Ping(context.Background())
+	require.Error(t, err, "Expected an error")
+	require.True(t, latency < 5*time.Second, "Ping did not respect the timeout")
+}
+
+func TestWithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Never respond unless the client gives up
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	config := getKubeConfigWithTimeout()
+	config.ClusterURL = server.URL
+	config.KubeRESTAPIGetter = &defaultGetter{}
+	config.OpenShiftRESTAPIGetter = &defaultGetter{}
+	kc := &kubeClient{
+		config: config,
+	}
+
+	const timeout = 100 * time.Millisecond
+	result, err := kc.WithTimeout(context.Background(), timeout)
+	require.NoError(t, err, "Error occurred overriding the timeout")
+	timeoutClient, ok := result.(*kubeClient)
+	require.True(t, ok, "WithTimeout returned %s instead of *kubeClient", reflect.TypeOf(result))
+	require.Equal(t, timeout, timeoutClient.config.Timeout, "Timeout was not overridden")
+	require.Equal(t, timeout, timeoutClient.KubeRESTAPI.(*kubeAPIClient).restConfig.Timeout,
+		"Kubernetes REST API does not use the overridden timeout")
+	require.Equal(t, timeout, timeoutClient.OpenShiftRESTAPI.(*openShiftAPIClient).httpClient.Timeout,
+		"OpenShift REST API does not use the overridden timeout")
+	require.Equal(t, 30*time.Second, kc.config.Timeout, "Timeout of the original client was changed")
+	require.True(t, timeoutClient.sharedMetrics, "Metrics client should be shared")
+
+	// The override wins over the configured timeout
+	start := time.Now()
+	_, err = timeoutClient.Namespaces().Get(config.UserNamespace, metav1.GetOptions{})
+	require.Error(t, err, "Expected an error")
+	require.True(t, time.Since(start) < 5*time.Second, "Request did not respect the overridden timeout")
+	_, err = timeoutClient.GetDeploymentConfig("myNamespace", "myApp")
+	require.Error(t, err, "Expected an error")
+	require.True(t, time.Since(start) < 10*time.Second, "Request did not respect the overridden timeout")
+}
+
+func TestGetBuildLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oapi/v1/namespaces/myNamespace/builds/myApp-1/log":
+			require.Equal(t, "Bearer myToken", r.Header.Get("Authorization"), "Missing bearer token")
+			w.Write([]byte("Cloning \"https://example.com/myApp.git\" ...\n"))
+		case "/oapi/v1/namespaces/myNamespace/builds/myApp-2/log":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := getKubeConfigWithTimeout()
+	config.ClusterURL = server.URL
+	restAPI, err := (&defaultGetter{}).GetOpenShiftRESTAPI(context.Background(), config)
+	require.NoError(t, err, "Error occurred getting OpenShift REST API")
+
+	logs, err := restAPI.GetBuildLog("myNamespace", "myApp-1")
+	require.NoError(t, err, "Error occurred getting build log")
+	require.NotNil(t, logs, "Log stream is nil")
+	defer logs.Close()
+	content, err := ioutil.ReadAll(logs)
+	require.NoError(t, err, "Error reading logs")
+	require.Equal(t, "Cloning \"https://example.com/myApp.git\" ...\n", string(content), "Wrong logs returned")
+
+	logs, err = restAPI.GetBuildLog("myNamespace", "myApp-3")
+	require.NoError(t, err, "Missing build should not be an error")
+	require.Nil(t, logs, "Missing build should have no logs")
+
+	_, err = restAPI.GetBuildLog("myNamespace", "myApp-2")
+	require.Error(t, err, "Expected an error")
+}
+
 func getKubeConfigWithTimeout() *KubeClientConfig {
 	return &KubeClientConfig{
 		ClusterURL:    "http://api.myCluster",
@@ -0,0 +1,117 @@
+package kubernetes
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/log"
+)
+
+// Backoff before the first retry of a request, doubled for each further retry
+const initialRetryBackoff = 100 * time.Millisecond
+
+// Upper bound for the backoff between two attempts of a request
+const maxRetryBackoff = 5 * time.Second
+
+// retryRoundTripper retries requests that failed due to network errors, rate limiting
+// or server errors with exponential backoff. Other client errors are returned immediately.
+// Requests with a method that is not idempotent, like the POST that rolls back a
+// deployment config, are only retried when they were rate limited, as they may already
+// have taken effect when a server or network error occurred.
+type retryRoundTripper struct {
+	http.RoundTripper
+	// Number of retries after the first attempt
+	maxRetries int
+	// No retry is started that would end after this duration since the first attempt,
+	// zero means no limit
+	timeout time.Duration
+}
+
+func newRetryRoundTripper(rt http.RoundTripper, config *KubeClientConfig) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &retryRoundTripper{
+		RoundTripper: rt,
+		maxRetries:   config.MaxRetries,
+		timeout:      config.Timeout,
+	}
+}
+
+func (rt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	backoff := initialRetryBackoff
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := rt.RoundTripper.RoundTrip(attemptReq)
+		if !isRetryable(req, resp, err) || attempt >= rt.maxRetries || req.Context().Err() != nil {
+			return resp, err
+		}
+		// The body of a request can only be sent again if it can be recreated
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+		if rt.timeout > 0 && time.Since(start)+backoff > rt.timeout {
+			return resp, err
+		}
+
+		log.Warn(req.Context(), map[string]interface{}{
+			"err":     err,
+			"url":     req.URL.String(),
+			"method":  req.Method,
+			"attempt": attempt + 1,
+			"backoff": backoff.String(),
+		}, "retrying failed request")
+		if resp != nil {
+			// Allow the connection to be reused
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-time.After(backoff):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+
+		attemptReq = req.WithContext(req.Context())
+		if req.Body != nil {
+			attemptReq.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// isRetryable returns whether a request may succeed when sent again given its
+// response or error, without repeating any effect it may already have had
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+	// A rate limited request was rejected before it was processed
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if !isIdempotent(req.Method) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// isIdempotent returns whether sending a request with the given method more than
+// once has the same effect as sending it once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
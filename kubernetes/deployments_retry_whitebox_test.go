@@ -0,0 +1,212 @@
+package kubernetes
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRetries(t *testing.T) {
+	testCases := []struct {
+		testName string
+		// Status codes returned by the stub server in order, the last one is repeated
+		statuses       []int
+		maxRetries     int
+		timeout        time.Duration
+		expectAttempts int
+		shouldSucceed  bool
+	}{
+		{
+			testName:       "Server Errors",
+			statuses:       []int{http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK},
+			maxRetries:     2,
+			expectAttempts: 3,
+			shouldSucceed:  true,
+		},
+		{
+			testName:       "Rate Limited",
+			statuses:       []int{http.StatusTooManyRequests, http.StatusOK},
+			maxRetries:     2,
+			expectAttempts: 2,
+			shouldSucceed:  true,
+		},
+		{
+			testName:       "Retries Exhausted",
+			statuses:       []int{http.StatusInternalServerError},
+			maxRetries:     2,
+			expectAttempts: 3,
+		},
+		{
+			testName:       "Retries Disabled",
+			statuses:       []int{http.StatusInternalServerError},
+			maxRetries:     0,
+			expectAttempts: 1,
+		},
+		{
+			testName:       "Client Error",
+			statuses:       []int{http.StatusForbidden},
+			maxRetries:     2,
+			expectAttempts: 1,
+		},
+		{
+			// Waiting for the first retry would already exceed the timeout
+			testName:       "Backoff Exceeds Timeout",
+			statuses:       []int{http.StatusInternalServerError},
+			maxRetries:     2,
+			timeout:        50 * time.Millisecond,
+			expectAttempts: 1,
+		},
+	}
+
+	requests := []struct {
+		testName string
+		request  func(config *KubeClientConfig) error
+	}{
+		{
+			testName: "Kubernetes",
+			request: func(config *KubeClientConfig) error {
+				restAPI, err := (&defaultGetter{}).GetKubeRESTAPI(context.Background(), config)
+				require.NoError(t, err, "Error occurred getting Kubernetes REST API")
+				_, err = restAPI.Pods("myNamespace").List(metav1.ListOptions{})
+				return err
+			},
+		},
+		{
+			testName: "OpenShift",
+			request: func(config *KubeClientConfig) error {
+				restAPI, err := (&defaultGetter{}).GetOpenShiftRESTAPI(context.Background(), config)
+				require.NoError(t, err, "Error occurred getting OpenShift REST API")
+				_, err = restAPI.GetDeploymentConfig("myNamespace", "myApp")
+				return err
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		for _, request := range requests {
+			t.Run(testCase.testName+"/"+request.testName, func(t *testing.T) {
+				attempts := 0
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					status := testCase.statuses[len(testCase.statuses)-1]
+					if attempts < len(testCase.statuses) {
+						status = testCase.statuses[attempts]
+					}
+					attempts++
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(status)
+					w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[]}`))
+				}))
+				defer server.Close()
+
+				config := getKubeConfigWithTimeout()
+				config.ClusterURL = server.URL
+				config.MaxRetries = testCase.maxRetries
+				if testCase.timeout > 0 {
+					config.Timeout = testCase.timeout
+				}
+				err := request.request(config)
+				if testCase.shouldSucceed {
+					require.NoError(t, err, "Request should succeed after retries")
+				} else {
+					require.Error(t, err, "Expected an error")
+				}
+				require.Equal(t, testCase.expectAttempts, attempts, "Unexpected number of attempts")
+			})
+		}
+	}
+}
+
+func TestRetryResendsBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err, "Failed to read request body")
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := getKubeConfigWithTimeout()
+	config.ClusterURL = server.URL
+	config.MaxRetries = 1
+	restAPI, err := (&defaultGetter{}).GetOpenShiftRESTAPI(context.Background(), config)
+	require.NoError(t, err, "Error occurred getting OpenShift REST API")
+
+	scale := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": 2,
+		},
+	}
+	err = restAPI.SetDeploymentConfigScale("myNamespace", "myApp", scale)
+	require.NoError(t, err, "Request should succeed after a retry")
+	require.Len(t, bodies, 2, "Expected one retry")
+	require.NotEmpty(t, bodies[0], "Request body is missing")
+	require.Equal(t, bodies[0], bodies[1], "Retry should send the same body")
+}
+
+func TestRetryNonIdempotentRequests(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		status         int
+		expectAttempts int
+	}{
+		{testName: "Server Error", status: http.StatusInternalServerError, expectAttempts: 1},
+		{testName: "Rate Limited", status: http.StatusTooManyRequests, expectAttempts: 3},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "POST", r.Method, "Rollback should be a POST")
+				attempts++
+				w.WriteHeader(testCase.status)
+			}))
+			defer server.Close()
+
+			config := getKubeConfigWithTimeout()
+			config.ClusterURL = server.URL
+			config.MaxRetries = 2
+			restAPI, err := (&defaultGetter{}).GetOpenShiftRESTAPI(context.Background(), config)
+			require.NoError(t, err, "Error occurred getting OpenShift REST API")
+			_, err = restAPI.RollbackDeploymentConfig("myNamespace", "myApp", map[string]interface{}{
+				"kind": "DeploymentConfigRollback",
+			})
+			require.Error(t, err, "Expected an error")
+			require.Equal(t, testCase.expectAttempts, attempts, "Unexpected number of attempts")
+		})
+	}
+}
+
+func TestRetryStopsOnCancel(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := getKubeConfigWithTimeout()
+	config.ClusterURL = server.URL
+	config.MaxRetries = 10
+	ctx, cancel := context.WithCancel(context.Background())
+	// Cancel during the backoff before a retry
+	timer := time.AfterFunc(50*time.Millisecond, cancel)
+	defer timer.Stop()
+
+	restAPI, err := (&defaultGetter{}).GetOpenShiftRESTAPI(ctx, config)
+	require.NoError(t, err, "Error occurred getting OpenShift REST API")
+	_, err = restAPI.GetDeploymentConfig("myNamespace", "myApp")
+	require.Error(t, err, "Expected an error")
+	require.Equal(t, 1, attempts, "Request should not be retried after cancellation")
+}
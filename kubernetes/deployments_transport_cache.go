@@ -0,0 +1,121 @@
+package kubernetes
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"net/http"
+	"sync"
+	"time"
+
+	errs "github.com/pkg/errors"
+)
+
+// Maximum number of cluster and token combinations whose transports are cached
+const transportCacheSize = 100
+
+// RESTAPIGetter provides access to both the Kubernetes and OpenShift REST APIs
+type RESTAPIGetter interface {
+	KubeRESTAPIGetter
+	OpenShiftRESTAPIGetter
+}
+
+// NewCachingRESTAPIGetter returns a RESTAPIGetter whose REST API clients share their
+// transport, and therefore their connections, with all other clients for the same
+// cluster, bearer token, proxy and TLS settings. Since all of these are part of the
+// cache key, a client for a changed token or TLS configuration never reuses the
+// transport of the old one. Cached transports
+// expire after the given TTL, and the least recently used ones are evicted once the
+// cache is full. The clients themselves are still created for every call, as they are
+// bound to the context and configuration they are created with.
+func NewCachingRESTAPIGetter(ttl time.Duration) RESTAPIGetter {
+	return &defaultGetter{
+		cache: newTransportCache(transportCacheSize, ttl),
+	}
+}
+
+// transportCacheKey holds everything a cached transport is built from. The CA
+// bundle is only kept as a hash to not hold on to the certificates.
+type transportCacheKey struct {
+	clusterURL            string
+	bearerToken           string
+	proxyURL              string
+	caBundleHash          [sha256.Size]byte
+	insecureSkipTLSVerify bool
+}
+
+func newTransportCacheKey(config *KubeClientConfig) transportCacheKey {
+	return transportCacheKey{
+		clusterURL:            config.ClusterURL,
+		bearerToken:           config.BearerToken,
+		proxyURL:              config.ProxyURL,
+		caBundleHash:          sha256.Sum256(config.CABundle),
+		insecureSkipTLSVerify: config.InsecureSkipTLSVerify,
+	}
+}
+
+type transportCacheEntry struct {
+	key       transportCacheKey
+	transport http.RoundTripper
+	expires   time.Time
+}
+
+// transportCache is an LRU cache of transports to Kubernetes and OpenShift API
+// servers, whose entries expire after a fixed TTL
+type transportCache struct {
+	lock    sync.Mutex
+	maxSize int
+	ttl     time.Duration
+	// Elements hold a *transportCacheEntry, the most recently used one first
+	entries *list.List
+	byKey   map[transportCacheKey]*list.Element
+	// Returns the current time, replaceable for tests
+	now func() time.Time
+}
+
+func newTransportCache(maxSize int, ttl time.Duration) *transportCache {
+	return &transportCache{
+		maxSize: maxSize,
+		ttl:     ttl,
+		entries: list.New(),
+		byKey:   make(map[transportCacheKey]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// get returns the cached transport for the cluster, bearer token, proxy and TLS
+// settings of the given configuration, creating it if it's missing or expired
+func (c *transportCache) get(config *KubeClientConfig) (http.RoundTripper, error) {
+	key := newTransportCacheKey(config)
+	now := c.now()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if elem, pres := c.byKey[key]; pres {
+		entry := elem.Value.(*transportCacheEntry)
+		if now.Before(entry.expires) {
+			c.entries.MoveToFront(elem)
+			return entry.transport, nil
+		}
+		c.remove(elem)
+	}
+
+	transport, err := newHTTPTransport(config)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	entry := &transportCacheEntry{
+		key:       key,
+		transport: transport,
+		expires:   now.Add(c.ttl),
+	}
+	c.byKey[key] = c.entries.PushFront(entry)
+	for c.entries.Len() > c.maxSize {
+		c.remove(c.entries.Back())
+	}
+	return transport, nil
+}
+
+func (c *transportCache) remove(elem *list.Element) {
+	entry := c.entries.Remove(elem).(*transportCacheEntry)
+	delete(c.byKey, entry.key)
+}
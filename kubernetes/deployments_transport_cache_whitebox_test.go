@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportCache(t *testing.T) {
+	const ttl = time.Minute
+	now := time.Now()
+	cache := newTransportCache(2, ttl)
+	cache.now = func() time.Time {
+		return now
+	}
+	config := getKubeConfigWithTimeout()
+
+	first, err := cache.get(config)
+	require.NoError(t, err, "Error getting transport")
+	require.NotNil(t, first, "Transport is nil")
+
+	t.Run("Reused", func(t *testing.T) {
+		transport, err := cache.get(config)
+		require.NoError(t, err, "Error getting transport")
+		require.True(t, first == transport, "Transport was not reused")
+	})
+
+	t.Run("Changed Token", func(t *testing.T) {
+		otherConfig := getKubeConfigWithTimeout()
+		otherConfig.BearerToken = "myOtherToken"
+		transport, err := cache.get(otherConfig)
+		require.NoError(t, err, "Error getting transport")
+		require.False(t, first == transport, "Transport for another token was reused")
+	})
+
+	t.Run("Least Recently Used Evicted", func(t *testing.T) {
+		// Uses the first transport, so the one for "myOtherToken" is evicted
+		_, err := cache.get(config)
+		require.NoError(t, err, "Error getting transport")
+		thirdConfig := getKubeConfigWithTimeout()
+		thirdConfig.ClusterURL = "http://api.myOtherCluster"
+		_, err = cache.get(thirdConfig)
+		require.NoError(t, err, "Error getting transport")
+		require.Equal(t, 2, cache.entries.Len(), "Cache exceeds its maximum size")
+		otherConfig := getKubeConfigWithTimeout()
+		otherConfig.BearerToken = "myOtherToken"
+		require.Contains(t, cache.byKey, newTransportCacheKey(config))
+		require.NotContains(t, cache.byKey, newTransportCacheKey(otherConfig))
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		now = now.Add(ttl)
+		transport, err := cache.get(config)
+		require.NoError(t, err, "Error getting transport")
+		require.False(t, first == transport, "Expired transport was reused")
+	})
+}
+
+func TestTransportCacheTLSSettings(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.TLS.Certificates[0].Certificate[0],
+	})
+	cache := newTransportCache(10, time.Minute)
+	config := getKubeConfigWithTimeout()
+	first, err := cache.get(config)
+	require.NoError(t, err, "Error getting transport")
+
+	testCases := []struct {
+		testName string
+		modify   func(config *KubeClientConfig)
+	}{
+		{testName: "Proxy", modify: func(config *KubeClientConfig) { config.ProxyURL = "http://proxy.example.com:3128" }},
+		{testName: "CA Bundle", modify: func(config *KubeClientConfig) { config.CABundle = caBundle }},
+		{testName: "Insecure", modify: func(config *KubeClientConfig) { config.InsecureSkipTLSVerify = true }},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			otherConfig := getKubeConfigWithTimeout()
+			testCase.modify(otherConfig)
+			transport, err := cache.get(otherConfig)
+			require.NoError(t, err, "Error getting transport")
+			require.False(t, first == transport, "Transport for other TLS settings was reused")
+			again, err := cache.get(otherConfig)
+			require.NoError(t, err, "Error getting transport")
+			require.True(t, transport == again, "Transport was not reused")
+		})
+	}
+
+	t.Run("Insecure Transport Not Reused", func(t *testing.T) {
+		insecureConfig := getKubeConfigWithTimeout()
+		insecureConfig.InsecureSkipTLSVerify = true
+		_, err := cache.get(insecureConfig)
+		require.NoError(t, err, "Error getting transport")
+		transport, err := cache.get(getKubeConfigWithTimeout())
+		require.NoError(t, err, "Error getting transport")
+		httpTransport, ok := transport.(*http.Transport)
+		require.True(t, ok, "Transport is not a *http.Transport")
+		require.False(t, httpTransport.TLSClientConfig.InsecureSkipVerify, "Insecure transport was reused")
+	})
+}
+
+func TestCachingRESTAPIGetter(t *testing.T) {
+	getter := NewCachingRESTAPIGetter(time.Minute)
+	config := getKubeConfigWithTimeout()
+
+	kubeAPI, err := getter.GetKubeRESTAPI(context.Background(), config)
+	require.NoError(t, err, "Error occurred getting Kubernetes REST API")
+	kubeClient, ok := kubeAPI.(*kubeAPIClient)
+	require.True(t, ok, "GetKubeRESTAPI did not return a *kubeAPIClient")
+	require.NotNil(t, kubeClient.restConfig.Transport, "Kubernetes REST API does not use the cached transport")
+
+	osAPI, err := getter.GetOpenShiftRESTAPI(context.Background(), config)
+	require.NoError(t, err, "Error occurred getting OpenShift REST API")
+	osClient, ok := osAPI.(*openShiftAPIClient)
+	require.True(t, ok, "GetOpenShiftRESTAPI did not return a *openShiftAPIClient")
+	require.True(t, kubeClient.restConfig.Transport == osClient.httpClient.Transport,
+		"Kubernetes and OpenShift REST APIs do not share the cached transport")
+	require.Equal(t, config.Timeout, osClient.httpClient.Timeout, "Timeouts do not match")
+}
+
+func TestDefaultGetterWithoutCache(t *testing.T) {
+	getter := &defaultGetter{}
+	transport, err := getter.getTransport(getKubeConfigWithTimeout())
+	require.NoError(t, err, "Unexpected error occurred")
+	require.Nil(t, transport, "Transport should not be cached")
+}
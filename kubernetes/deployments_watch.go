@@ -0,0 +1,168 @@
+package kubernetes
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/log"
+	errs "github.com/pkg/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// DeploymentEvent is sent by WatchDeployment when the rollout status of an
+// application's deployment changes
+type DeploymentEvent struct {
+	RolloutStatus
+}
+
+// WatchDeployment watches the RCs and pods of an application in the given environment,
+// and sends the rollout status of its deployment to the returned channel whenever it
+// changes. The current status is sent first. The channel is closed once the context is
+// cancelled. Watches that are disconnected are reestablished with exponential backoff.
+// ReplicaSets of Kubernetes Deployments can't be watched, so their changes are only
+// noticed through their pods.
+func (kc *kubeClient) WatchDeployment(ctx context.Context, spaceName string, appName string, envName string) (<-chan *DeploymentEvent, error) {
+	envNS, err := kc.getEnvironmentNamespace(envName)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	// Fails for unknown applications
+	status, err := kc.GetDeploymentRolloutStatus(spaceName, appName, envName)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *DeploymentEvent)
+	w := &deploymentWatch{
+		kc:        kc,
+		spaceName: spaceName,
+		appName:   appName,
+		envName:   envName,
+		envNS:     envNS,
+		events:    events,
+		last:      *status,
+	}
+	go w.run(ctx)
+	return events, nil
+}
+
+// deploymentWatch holds the state of a single WatchDeployment call
+type deploymentWatch struct {
+	kc        *kubeClient
+	spaceName string
+	appName   string
+	envName   string
+	envNS     string
+	events    chan<- *DeploymentEvent
+	// Last status sent to the channel
+	last RolloutStatus
+}
+
+func (w *deploymentWatch) run(ctx context.Context) {
+	defer close(w.events)
+	if !w.send(ctx) {
+		return
+	}
+	backoff := initialRetryBackoff
+	for attempt := 0; ; attempt++ {
+		connected, err := w.watch(ctx, attempt > 0)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = initialRetryBackoff
+		}
+		log.Warn(ctx, map[string]interface{}{
+			"space_name":       w.spaceName,
+			"application_name": w.appName,
+			"environment_name": w.envName,
+			"err":              err,
+			"backoff":          backoff.String(),
+		}, "deployment watch disconnected, reconnecting")
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+// watch watches the RCs and pods of the application until either watch is
+// disconnected or the context is cancelled, and returns whether the watches
+// were established. Changes missed while reconnecting are picked up if refresh
+// is set. The watches don't use the client timeout, which is meant for single
+// requests, but end with the context.
+func (w *deploymentWatch) watch(ctx context.Context, refresh bool) (bool, error) {
+	client, err := w.kc.WatchClient(ctx)
+	if err != nil {
+		return false, errs.WithStack(err)
+	}
+	opts := metaV1.ListOptions{
+		LabelSelector: "app=" + w.appName,
+	}
+	rcWatch, err := client.ReplicationControllers(w.envNS).Watch(opts)
+	if err != nil {
+		return false, errs.WithStack(err)
+	}
+	defer rcWatch.Stop()
+	podWatch, err := client.Pods(w.envNS).Watch(opts)
+	if err != nil {
+		return false, errs.WithStack(err)
+	}
+	defer podWatch.Stop()
+
+	if refresh && !w.update(ctx) {
+		return true, nil
+	}
+	for {
+		var event watch.Event
+		var ok bool
+		select {
+		case event, ok = <-rcWatch.ResultChan():
+		case event, ok = <-podWatch.ResultChan():
+		case <-ctx.Done():
+			return true, nil
+		}
+		if !ok {
+			return true, errs.New("watch channel closed")
+		} else if event.Type == watch.Error {
+			return true, errs.Errorf("watch failed: %v", event.Object)
+		}
+		if !w.update(ctx) {
+			return true, nil
+		}
+	}
+}
+
+// update fetches the current rollout status and sends it if it changed. It returns
+// false if the context was cancelled while sending.
+func (w *deploymentWatch) update(ctx context.Context) bool {
+	status, err := w.kc.GetDeploymentRolloutStatus(w.spaceName, w.appName, w.envName)
+	if err != nil {
+		log.Warn(ctx, map[string]interface{}{
+			"space_name":       w.spaceName,
+			"application_name": w.appName,
+			"environment_name": w.envName,
+			"err":              err,
+		}, "failed to get rollout status of watched deployment")
+		return true
+	} else if *status == w.last {
+		return true
+	}
+	w.last = *status
+	return w.send(ctx)
+}
+
+func (w *deploymentWatch) send(ctx context.Context) bool {
+	select {
+	case w.events <- &DeploymentEvent{RolloutStatus: w.last}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
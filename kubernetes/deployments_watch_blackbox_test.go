@@ -0,0 +1,109 @@
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/kubernetes"
+	"k8s.io/apimachinery/pkg/watch"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+const watchTestTimeout = 5 * time.Second
+
+func TestWatchDeployment(t *testing.T) {
+	fixture := &testFixture{
+		deploymentInput: defaultDeploymentInput,
+		rcWatches:       make(chan *watch.FakeWatcher, 1),
+		podWatches:      make(chan *watch.FakeWatcher, 1),
+	}
+	kc := getDefaultKubeClient(fixture, t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := kc.WatchDeployment(ctx, "mySpace", "myApp", "run")
+	require.NoError(t, err, "Unexpected error occurred")
+
+	// The current status is sent first
+	expectEvent(t, events, kubernetes.RolloutStatus{
+		Phase:           kubernetes.RolloutComplete,
+		Version:         1,
+		ReadyReplicas:   2,
+		DesiredReplicas: 2,
+	})
+	rcWatch := expectWatch(t, fixture.rcWatches)
+	podWatch := expectWatch(t, fixture.podWatches)
+	require.Equal(t, "app=myApp", fixture.kube.podHolder.options.LabelSelector, "Wrong label selector")
+
+	// A new version is being rolled out
+	fixture.rcInput = map[string]string{
+		"my-run": "replicationcontroller-rollout-running.json",
+	}
+	rcWatch.Add(&v1.ReplicationController{})
+	expectEvent(t, events, kubernetes.RolloutStatus{
+		Phase:           kubernetes.RolloutRunning,
+		Version:         2,
+		ReadyReplicas:   1,
+		DesiredReplicas: 2,
+	})
+
+	// The rollout fails while the watch is disconnected, which is noticed after
+	// reconnecting
+	fixture.rcInput = map[string]string{
+		"my-run": "replicationcontroller-scaled-down.json",
+	}
+	podWatch.Stop()
+	expectWatch(t, fixture.rcWatches)
+	expectWatch(t, fixture.podWatches)
+	expectEvent(t, events, kubernetes.RolloutStatus{
+		Phase:           kubernetes.RolloutFailed,
+		Version:         3,
+		ReadyReplicas:   0,
+		DesiredReplicas: 1,
+	})
+
+	// The channel is closed once the context is cancelled
+	cancel()
+	select {
+	case _, ok := <-events:
+		require.False(t, ok, "Expected the channel to be closed")
+	case <-time.After(watchTestTimeout):
+		require.Fail(t, "Channel was not closed")
+	}
+}
+
+func TestWatchDeploymentUnknownApplication(t *testing.T) {
+	fixture := &testFixture{
+		deploymentInput: defaultDeploymentInput,
+	}
+	kc := getDefaultKubeClient(fixture, t)
+
+	_, err := kc.WatchDeployment(context.Background(), "mySpace", "doesNotExist", "run")
+	require.Error(t, err, "Expected an error")
+	notFound, _ := errors.IsNotFoundError(err)
+	require.True(t, notFound, "Expected a not found error, got %v", err)
+}
+
+func expectEvent(t *testing.T, events <-chan *kubernetes.DeploymentEvent, expected kubernetes.RolloutStatus) {
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "Channel closed unexpectedly")
+		require.Equal(t, expected, event.RolloutStatus, "Wrong rollout status")
+	case <-time.After(watchTestTimeout):
+		require.Fail(t, "No event received")
+	}
+}
+
+func expectWatch(t *testing.T, watches <-chan *watch.FakeWatcher) *watch.FakeWatcher {
+	select {
+	case watcher := <-watches:
+		return watcher
+	case <-time.After(watchTestTimeout):
+		require.Fail(t, "Watch was not established")
+		return nil
+	}
+}
@@ -262,6 +262,14 @@ func main() {
 	workItemRelationshipsLinksCtrl := controller.NewWorkItemRelationshipsLinksController(service, appDB, config)
 	app.MountWorkItemRelationshipsLinksController(service, workItemRelationshipsLinksCtrl)
 
+	// Mount "work item link graph" controller
+	workItemLinkGraphCtrl := controller.NewWorkItemLinkGraphController(service, appDB, config)
+	app.MountWorkItemLinkGraphController(service, workItemLinkGraphCtrl)
+
+	// Mount "space work item links" controller
+	spaceWorkItemLinksCtrl := controller.NewSpaceWorkItemLinksController(service, appDB)
+	app.MountSpaceWorkItemLinksController(service, spaceWorkItemLinksCtrl)
+
 	// Mount "comments" controller
 	//commentsCtrl := controller.NewCommentsController(service, appDB, config)
 	commentsCtrl := controller.NewNotifyingCommentsController(service, appDB, notificationChannel, config)
@@ -348,6 +356,10 @@ func main() {
 	filterCtrl := controller.NewFilterController(service, config)
 	app.MountFilterController(service, filterCtrl)
 
+	// Mount "work item link type topology" controller
+	workItemLinkTypeTopologyCtrl := controller.NewWorkItemLinkTypeTopologyController(service, config)
+	app.MountWorkItemLinkTypeTopologyController(service, workItemLinkTypeTopologyCtrl)
+
 	// Mount "namedspaces" controller
 	namedSpacesCtrl := controller.NewNamedspacesController(service, appDB)
 	app.MountNamedspacesController(service, namedSpacesCtrl)
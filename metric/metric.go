@@ -45,6 +45,40 @@ var (
 		Help:      "Bucketed histogram of the HTTP request sizes in bytes.",
 		Buckets:   []float64{1000, 5000, 10000, 20000, 30000, 40000, 50000},
 	}, reqLabels)
+
+	// the space label only distinguishes the system space from all other
+	// spaces so that the number of time series doesn't grow with the spaces
+	linkTypeOpLabels = []string{"operation", "space"}
+
+	linkTypeOpCnt = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "workitemlinktype_operations_total",
+		Help:      "Counter of operations on work item link types.",
+	}, linkTypeOpLabels)
+
+	linkTypeOpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "workitemlinktype_operation_duration_seconds",
+		Help:      "Bucketed histogram of processing time (s) of operations on work item link types.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 8),
+	}, linkTypeOpLabels)
+)
+
+// Operations on work item link types that are reported with
+// ReportLinkTypeOperation
+const (
+	LinkTypeOperationCreate = "create"
+	LinkTypeOperationUpdate = "update"
+	LinkTypeOperationDelete = "delete"
+	LinkTypeOperationList   = "list"
+)
+
+// Values of the space label of work item link type operations
+const (
+	LinkTypeSpaceSystem = "system"
+	LinkTypeSpaceCustom = "custom"
 )
 
 func registerMetrics() {
@@ -52,6 +86,8 @@ func registerMetrics() {
 	reqDuration = register(reqDuration, "request_duration_seconds").(*prometheus.HistogramVec)
 	resSize = register(resSize, "response_size_bytes").(*prometheus.HistogramVec)
 	reqSize = register(reqSize, "request_size_bytes").(*prometheus.HistogramVec)
+	linkTypeOpCnt = register(linkTypeOpCnt, "workitemlinktype_operations_total").(*prometheus.CounterVec)
+	linkTypeOpDuration = register(linkTypeOpDuration, "workitemlinktype_operation_duration_seconds").(*prometheus.HistogramVec)
 	log.Info(nil, nil, "metrics registered successfully")
 }
 
@@ -95,3 +131,15 @@ func reportRequestSize(method, entity, code string, size int64) {
 		reqSize.WithLabelValues(method, entity, code).Observe(float64(size))
 	}
 }
+
+// ReportLinkTypeOperation counts the given operation on work item link types
+// and records its latency measured from the given start time. The space must
+// be either LinkTypeSpaceSystem or LinkTypeSpaceCustom. Use it with defer:
+//
+//	defer metric.ReportLinkTypeOperation(metric.LinkTypeOperationList, space, time.Now())
+func ReportLinkTypeOperation(operation, space string, startTime time.Time) {
+	if operation != "" && space != "" && !startTime.IsZero() {
+		linkTypeOpCnt.WithLabelValues(operation, space).Inc()
+		linkTypeOpDuration.WithLabelValues(operation, space).Observe(time.Since(startTime).Seconds())
+	}
+}
@@ -111,6 +111,37 @@ func TestReqSizeMetric(t *testing.T) {
 	checkHistogram(t, m, uint64(len(reqSizes)), expectedBound, expectedCnt)
 }
 
+func TestLinkTypeOperationMetric(t *testing.T) {
+	// given the current values, since other tests may report operations too
+	count := func(operation, space string) int64 {
+		c, _ := linkTypeOpCnt.GetMetricWithLabelValues(operation, space)
+		m := &dto.Metric{}
+		c.Write(m)
+		return int64(m.Counter.GetValue())
+	}
+	samples := func(operation, space string) uint64 {
+		h, _ := linkTypeOpDuration.GetMetricWithLabelValues(operation, space)
+		m := &dto.Metric{}
+		h.Write(m)
+		return m.Histogram.GetSampleCount()
+	}
+	createCustom := count(LinkTypeOperationCreate, LinkTypeSpaceCustom)
+	listSystem := count(LinkTypeOperationList, LinkTypeSpaceSystem)
+	listSystemSamples := samples(LinkTypeOperationList, LinkTypeSpaceSystem)
+
+	// when
+	ReportLinkTypeOperation(LinkTypeOperationCreate, LinkTypeSpaceCustom, time.Now())
+	ReportLinkTypeOperation(LinkTypeOperationList, LinkTypeSpaceSystem, time.Now().Add(-100*time.Millisecond))
+	ReportLinkTypeOperation(LinkTypeOperationList, LinkTypeSpaceSystem, time.Now())
+	// incomplete labels are ignored
+	ReportLinkTypeOperation(LinkTypeOperationCreate, "", time.Now())
+
+	// then
+	assert.Equal(t, createCustom+1, count(LinkTypeOperationCreate, LinkTypeSpaceCustom))
+	assert.Equal(t, listSystem+2, count(LinkTypeOperationList, LinkTypeSpaceSystem))
+	assert.Equal(t, listSystemSamples+2, samples(LinkTypeOperationList, LinkTypeSpaceSystem))
+}
+
 func TestLabelsVal(t *testing.T) {
 	svc := goa.New("metric")
 	ctrl := svc.NewController(dummyCtrl)
@@ -379,6 +379,27 @@ func GetMigrations() Migrations {
 	// Version 83
 	m = append(m, steps{ExecuteSQLFile("083-index-comments-parent.sql")})
 
+	// Version 84
+	m = append(m, steps{ExecuteSQLFile("084-link-types-created-by.sql")})
+
+	// Version 85
+	m = append(m, steps{ExecuteSQLFile("085-space-default-link-type.sql")})
+
+	// Version 86
+	m = append(m, steps{ExecuteSQLFile("086-space-allow-cross-space-links.sql")})
+
+	// Version 87
+	m = append(m, steps{ExecuteSQLFile("087-link-types-self-reference-allowed.sql")})
+
+	// Version 88
+	m = append(m, steps{ExecuteSQLFile("088-link-types-allowed-work-item-types.sql")})
+
+	// Version 89
+	m = append(m, steps{ExecuteSQLFile("089-link-type-revisions.sql")})
+
+	// Version 90
+	m = append(m, steps{ExecuteSQLFile("090-link-type-description-markup.sql")})
+
 	// Version N
 	//
 	// In order to add an upgrade, simply append an array of MigrationFunc to the
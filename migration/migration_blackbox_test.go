@@ -129,6 +129,13 @@ func TestMigrations(t *testing.T) {
 	t.Run("TestMigration80", testMigration80)
 	t.Run("TestMigration81", testMigration81)
 	t.Run("TestMigration82", testMigration82)
+	t.Run("TestMigration84", testMigration84)
+	t.Run("TestMigration85", testMigration85)
+	t.Run("TestMigration86", testMigration86)
+	t.Run("TestMigration87", testMigration87)
+	t.Run("TestMigration88", testMigration88)
+	t.Run("TestMigration89", testMigration89)
+	t.Run("TestMigration90", testMigration90)
 
 	// Perform the migration
 	err = migration.Migrate(sqlDB, databaseName)
@@ -686,6 +693,43 @@ func testMigration82(t *testing.T) {
 	assert.Equal(t, updatedAt.String(), relationshipsChangedAt.String())
 }
 
+func testMigration84(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:85], 85)
+	assert.True(t, dialect.HasColumn("work_item_link_types", "created_by"))
+	assert.True(t, dialect.HasIndex("work_item_link_types", "work_item_link_types_created_by_idx"))
+}
+
+func testMigration85(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:86], 86)
+	assert.True(t, dialect.HasColumn("spaces", "default_link_type_id"))
+}
+
+func testMigration86(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:87], 87)
+	assert.True(t, dialect.HasColumn("spaces", "allow_cross_space_links"))
+}
+
+func testMigration87(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:88], 88)
+	assert.True(t, dialect.HasColumn("work_item_link_types", "self_reference_allowed"))
+}
+
+func testMigration88(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:89], 89)
+	assert.True(t, dialect.HasColumn("work_item_link_types", "source_work_item_type_ids"))
+	assert.True(t, dialect.HasColumn("work_item_link_types", "target_work_item_type_ids"))
+}
+
+func testMigration89(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:90], 90)
+	assert.True(t, dialect.HasTable("work_item_link_type_revisions"))
+}
+
+func testMigration90(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:91], 91)
+	assert.True(t, dialect.HasColumn("work_item_link_types", "description_markup"))
+}
+
 // runSQLscript loads the given filename from the packaged SQL test files and
 // executes it on the given database. Golang text/template module is used
 // to handle all the optional arguments passed to the sql test files
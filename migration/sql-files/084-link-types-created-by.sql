@@ -0,0 +1,3 @@
+-- add the identity that created a work item link type (NULL for system types)
+ALTER TABLE work_item_link_types ADD COLUMN created_by uuid;
+CREATE INDEX work_item_link_types_created_by_idx ON work_item_link_types USING btree (created_by);
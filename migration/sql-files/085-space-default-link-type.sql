@@ -0,0 +1,3 @@
+-- add the work item link type that a space uses by default for quick-linking
+-- (NULL means the system "relates to" link type is used)
+ALTER TABLE spaces ADD COLUMN default_link_type_id uuid REFERENCES work_item_link_types(id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+-- spaces have to opt in to links between their work items and work items of
+-- other spaces
+ALTER TABLE spaces ADD COLUMN allow_cross_space_links boolean NOT NULL DEFAULT false;
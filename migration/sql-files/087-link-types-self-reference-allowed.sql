@@ -0,0 +1,2 @@
+-- work item link types have to opt in to links from a work item to itself
+ALTER TABLE work_item_link_types ADD COLUMN self_reference_allowed boolean NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+-- work item link types can restrict the types of the work items they connect,
+-- an empty array allows any type
+ALTER TABLE work_item_link_types ADD COLUMN source_work_item_type_ids jsonb NOT NULL DEFAULT '[]';
+ALTER TABLE work_item_link_types ADD COLUMN target_work_item_type_ids jsonb NOT NULL DEFAULT '[]';
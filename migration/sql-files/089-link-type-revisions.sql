@@ -0,0 +1,7 @@
+-- the revision of the work item link types of a space is bumped whenever one
+-- of them is created, updated, deleted or restored
+CREATE TABLE work_item_link_type_revisions (
+    space_id uuid primary key NOT NULL REFERENCES spaces (id) ON DELETE CASCADE,
+    revision integer NOT NULL DEFAULT 0,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
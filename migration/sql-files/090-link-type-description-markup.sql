@@ -0,0 +1,2 @@
+-- the markup language of the description of a work item link type
+ALTER TABLE work_item_link_types ADD COLUMN description_markup text NOT NULL DEFAULT 'PlainText';
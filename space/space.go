@@ -32,6 +32,12 @@ type Space struct {
 	Name        string
 	Description string
 	OwnerID     uuid.UUID `sql:"type:uuid"` // Belongs To Identity
+	// DefaultLinkTypeID is the work item link type to use for quick-linking
+	// work items of this space (nil means the system default is used)
+	DefaultLinkTypeID *uuid.UUID `sql:"type:uuid"`
+	// AllowCrossSpaceLinks is true if work items of this space may be linked
+	// with work items of other spaces
+	AllowCrossSpaceLinks bool
 }
 
 // Ensure Fields implements the Equaler interface
@@ -60,6 +66,15 @@ func (p Space) Equal(u convert.Equaler) bool {
 	if !uuid.Equal(p.OwnerID, other.OwnerID) {
 		return false
 	}
+	if (p.DefaultLinkTypeID == nil) != (other.DefaultLinkTypeID == nil) {
+		return false
+	}
+	if p.DefaultLinkTypeID != nil && !uuid.Equal(*p.DefaultLinkTypeID, *other.DefaultLinkTypeID) {
+		return false
+	}
+	if p.AllowCrossSpaceLinks != other.AllowCrossSpaceLinks {
+		return false
+	}
 	return true
 }
 
@@ -86,6 +101,7 @@ type Repository interface {
 	Save(ctx context.Context, space *Space) (*Space, error)
 	Load(ctx context.Context, ID uuid.UUID) (*Space, error)
 	LoadMany(ctx context.Context, IDs []uuid.UUID) ([]Space, error)
+	LoadBatch(ctx context.Context, IDs []uuid.UUID) ([]Space, error)
 	Delete(ctx context.Context, ID uuid.UUID) error
 	LoadByOwner(ctx context.Context, userID *uuid.UUID, start *int, length *int) ([]Space, int, error)
 	LoadByOwnerAndName(ctx context.Context, userID *uuid.UUID, spaceName *string) (*Space, error)
@@ -170,6 +186,44 @@ func (r *GormRepository) LoadMany(ctx context.Context, IDs []uuid.UUID) ([]Space
 	return result, nil
 }
 
+// LoadBatch returns the spaces for the given IDs with a single query. Unlike
+// LoadMany the result has the order of the given IDs (without duplicates) and
+// a NotFoundError is returned if any of the spaces doesn't exist.
+// returns NotFoundError or InternalError
+func (r *GormRepository) LoadBatch(ctx context.Context, IDs []uuid.UUID) ([]Space, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "space", "loadBatch"}, time.Now())
+	result := []Space{}
+	if len(IDs) == 0 {
+		return result, nil
+	}
+	var spaces []Space
+	tx := r.db.Where("id IN (?)", IDs).Find(&spaces)
+	if tx.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err":       tx.Error,
+			"space_ids": IDs,
+		}, "unable to load spaces by their IDs")
+		return nil, errors.NewInternalError(ctx, tx.Error)
+	}
+	byID := make(map[uuid.UUID]Space, len(spaces))
+	for _, s := range spaces {
+		byID[s.ID] = s
+	}
+	seen := make(map[uuid.UUID]struct{}, len(IDs))
+	for _, ID := range IDs {
+		if _, ok := seen[ID]; ok {
+			continue
+		}
+		seen[ID] = struct{}{}
+		s, ok := byID[ID]
+		if !ok {
+			return nil, errors.NewNotFoundError("space", ID.String())
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
 // CheckExists returns nil if the given ID exists otherwise returns an error
 func (r *GormRepository) CheckExists(ctx context.Context, id uuid.UUID) error {
 	defer goa.MeasureSince([]string{"goa", "db", "space", "exists"}, time.Now())
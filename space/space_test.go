@@ -309,6 +309,38 @@ func (s *SpaceRepositoryTestSuite) TestLoadMany() {
 	})
 }
 
+func (s *SpaceRepositoryTestSuite) TestLoadBatch() {
+	s.T().Run("ok - keeps order and drops duplicates", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(3))
+		ids := []uuid.UUID{fxt.Spaces[2].ID, fxt.Spaces[0].ID, fxt.Spaces[2].ID}
+		// when
+		result, err := s.repo.LoadBatch(s.Ctx, ids)
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, fxt.Spaces[2].ID, result[0].ID)
+		assert.Equal(t, fxt.Spaces[0].ID, result[1].ID)
+	})
+
+	s.T().Run("ok with none", func(t *testing.T) {
+		// when
+		result, err := s.repo.LoadBatch(s.Ctx, nil)
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	s.T().Run("not found", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1))
+		// when
+		_, err := s.repo.LoadBatch(s.Ctx, []uuid.UUID{fxt.Spaces[0].ID, uuid.NewV4()})
+		// then
+		require.IsType(t, errors.NotFoundError{}, err)
+	})
+}
+
 // containsAllSpaces verifies that the `expectedSpaces` array contains all `actualSpaces` in any order,
 // by comparing the lengths and each ID,
 func containsAllSpaces(t *testing.T, expectedSpaces []*space.Space, actualSpaces ...space.Space) assert.Comparison {
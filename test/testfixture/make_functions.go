@@ -108,8 +108,8 @@ func makeWorkItemLinkTypes(fxt *TestFixture) error {
 			Name:        testsupport.CreateRandomValidTestName("work item link type "),
 			Description: &desc,
 			Topology:    link.TopologyTree,
-			ForwardName: "forward name (e.g. blocks)",
-			ReverseName: "reverse name (e.g. blocked by)",
+			ForwardName: testsupport.CreateRandomValidTestName("forward name (e.g. blocks) "),
+			ReverseName: testsupport.CreateRandomValidTestName("reverse name (e.g. blocked by) "),
 		}
 		if !fxt.isolatedCreation {
 			fxt.WorkItemLinkTypes[i].SpaceID = fxt.Spaces[0].ID
@@ -20,6 +20,7 @@ type WorkItemLinkCategoryRepository interface {
 	repository.Exister
 	Create(ctx context.Context, linkCat *WorkItemLinkCategory) (*WorkItemLinkCategory, error)
 	Load(ctx context.Context, ID uuid.UUID) (*WorkItemLinkCategory, error)
+	LoadBatch(ctx context.Context, IDs []uuid.UUID) ([]WorkItemLinkCategory, error)
 	List(ctx context.Context) ([]WorkItemLinkCategory, error)
 	Delete(ctx context.Context, ID uuid.UUID) error
 	Save(ctx context.Context, linkCat WorkItemLinkCategory) (*WorkItemLinkCategory, error)
@@ -80,6 +81,42 @@ func (r *GormWorkItemLinkCategoryRepository) Load(ctx context.Context, ID uuid.U
 	return &result, nil
 }
 
+// LoadBatch returns the work item link categories for the given IDs with a
+// single query. The result has the order of the given IDs without duplicates.
+// Returns NotFoundError or InternalError
+func (r *GormWorkItemLinkCategoryRepository) LoadBatch(ctx context.Context, IDs []uuid.UUID) ([]WorkItemLinkCategory, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlinkcategory", "loadbatch"}, time.Now())
+	result := []WorkItemLinkCategory{}
+	if len(IDs) == 0 {
+		return result, nil
+	}
+	var categories []WorkItemLinkCategory
+	db := r.db.Where("id IN (?)", IDs).Find(&categories)
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	byID := make(map[uuid.UUID]WorkItemLinkCategory, len(categories))
+	for _, cat := range categories {
+		byID[cat.ID] = cat
+	}
+	seen := make(map[uuid.UUID]struct{}, len(IDs))
+	for _, ID := range IDs {
+		if _, ok := seen[ID]; ok {
+			continue
+		}
+		seen[ID] = struct{}{}
+		cat, ok := byID[ID]
+		if !ok {
+			log.Error(ctx, map[string]interface{}{
+				"wilc_id": ID,
+			}, "work item link category not found by id ", ID)
+			return nil, errors.NewNotFoundError("work item link category", ID.String())
+		}
+		result = append(result, cat)
+	}
+	return result, nil
+}
+
 // CheckExists returns nil if the given ID exists otherwise returns an error
 func (r *GormWorkItemLinkCategoryRepository) CheckExists(ctx context.Context, id uuid.UUID) error {
 	defer goa.MeasureSince([]string{"goa", "db", "workitemlinkcategory", "exists"}, time.Now())
@@ -108,7 +145,21 @@ func (r *GormWorkItemLinkCategoryRepository) Delete(ctx context.Context, ID uuid
 	log.Info(ctx, map[string]interface{}{
 		"wilc_id": ID,
 	}, "Work item link category to delete")
-	db := r.db.Delete(&cat)
+	// Deleting a category would also delete all of its link types, so refuse
+	// to delete a category that is still referenced.
+	var typeCount int
+	db := r.db.Model(&WorkItemLinkType{}).Where("link_category_id = ?", ID).Count(&typeCount)
+	if db.Error != nil {
+		return errors.NewInternalError(ctx, db.Error)
+	}
+	if typeCount > 0 {
+		log.Error(ctx, map[string]interface{}{
+			"wilc_id":    ID,
+			"type_count": typeCount,
+		}, "unable to delete work item link category because it is still referenced by work item link types")
+		return errors.NewDataConflictError(fmt.Sprintf("work item link category %s is still referenced by %d work item link type(s); reassign them first", ID, typeCount))
+	}
+	db = r.db.Delete(&cat)
 	if db.Error != nil {
 		return errors.NewInternalError(ctx, db.Error)
 	}
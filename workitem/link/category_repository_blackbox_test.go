@@ -0,0 +1,72 @@
+package link_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type categoryRepoBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	categoryRepo *link.GormWorkItemLinkCategoryRepository
+}
+
+func TestRunCategoryRepoBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &categoryRepoBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite("../../config.yaml")})
+}
+
+func (s *categoryRepoBlackBoxTest) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.categoryRepo = link.NewWorkItemLinkCategoryRepository(s.DB)
+}
+
+func (s *categoryRepoBlackBoxTest) TestDelete() {
+	s.T().Run("ok - unreferenced category", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinkCategories(1))
+		err := s.categoryRepo.Delete(s.Ctx, fxt.WorkItemLinkCategories[0].ID)
+		require.NoError(t, err)
+	})
+	s.T().Run("conflict - category referenced by link type", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinkTypes(1))
+		err := s.categoryRepo.Delete(s.Ctx, fxt.WorkItemLinkCategories[0].ID)
+		require.IsType(t, errors.DataConflictError{}, err)
+		// the category and its link type must still exist
+		_, err = s.categoryRepo.Load(s.Ctx, fxt.WorkItemLinkCategories[0].ID)
+		require.NoError(t, err)
+	})
+}
+
+func (s *categoryRepoBlackBoxTest) TestLoadBatch() {
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.WorkItemLinkCategories(3))
+	s.T().Run("ok - keeps order and drops duplicates", func(t *testing.T) {
+		// when
+		ids := []uuid.UUID{
+			fxt.WorkItemLinkCategories[2].ID,
+			fxt.WorkItemLinkCategories[0].ID,
+			fxt.WorkItemLinkCategories[2].ID,
+		}
+		categories, err := s.categoryRepo.LoadBatch(s.Ctx, ids)
+		// then
+		require.NoError(t, err)
+		require.Len(t, categories, 2)
+		require.Equal(t, fxt.WorkItemLinkCategories[2].ID, categories[0].ID)
+		require.Equal(t, fxt.WorkItemLinkCategories[0].ID, categories[1].ID)
+	})
+	s.T().Run("ok - no IDs", func(t *testing.T) {
+		categories, err := s.categoryRepo.LoadBatch(s.Ctx, nil)
+		require.NoError(t, err)
+		require.Empty(t, categories)
+	})
+	s.T().Run("not found", func(t *testing.T) {
+		_, err := s.categoryRepo.LoadBatch(s.Ctx, []uuid.UUID{fxt.WorkItemLinkCategories[0].ID, uuid.NewV4()})
+		require.IsType(t, errors.NotFoundError{}, err)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"hash/fnv"
+	"sort"
 	"time"
 
 	"github.com/fabric8-services/fabric8-wit/application/repository"
@@ -12,6 +13,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/id"
 	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 
 	"github.com/goadesign/goa"
@@ -33,16 +35,50 @@ const (
 type WorkItemLinkRepository interface {
 	repository.Exister
 	Create(ctx context.Context, sourceID, targetID uuid.UUID, linkTypeID uuid.UUID, creatorID uuid.UUID) (*WorkItemLink, error)
+	// CreateBatch creates the given links and returns the created link or the
+	// reason why it couldn't be created for each of them.
+	CreateBatch(ctx context.Context, links []WorkItemLink, creatorID uuid.UUID) ([]*WorkItemLink, []error, error)
 	Load(ctx context.Context, ID uuid.UUID) (*WorkItemLink, error)
+	// LoadByEndpoints returns the link of the given type between the given
+	// source and target. If more than one such link exists a
+	// DataConflictError is returned.
+	LoadByEndpoints(ctx context.Context, sourceID, targetID uuid.UUID, linkTypeID uuid.UUID) (*WorkItemLink, error)
 	List(ctx context.Context) ([]WorkItemLink, error)
 	ListByWorkItem(ctx context.Context, wiID uuid.UUID) ([]WorkItemLink, error)
+	// ListByWorkItemCrossing returns the work item links that have wiID as
+	// source or target and whose source and target lie on different sides of
+	// the given boundary.
+	ListByWorkItemCrossing(ctx context.Context, wiID uuid.UUID, boundary Boundary) ([]WorkItemLink, error)
+	// ListByWorkItems returns the work item links that have any of the given
+	// work items as source or target.
+	ListByWorkItems(ctx context.Context, wiIDs ...uuid.UUID) ([]WorkItemLink, error)
+	// Stream calls fn for every work item link whose source or target is in
+	// the given space without loading all links into memory at once.
+	Stream(ctx context.Context, spaceID uuid.UUID, fn func(WorkItemLink) error) error
 	DeleteRelatedLinks(ctx context.Context, wiID uuid.UUID, suppressorID uuid.UUID) error
+	// DeleteByTypeID deletes all links of the given link type and returns
+	// the number of deleted links.
+	DeleteByTypeID(ctx context.Context, linkTypeID uuid.UUID, suppressorID uuid.UUID) (int, error)
 	Delete(ctx context.Context, ID uuid.UUID, suppressorID uuid.UUID) error
 	ListChildLinks(ctx context.Context, linkTypeID uuid.UUID, parentIDs ...uuid.UUID) (WorkItemLinkList, error)
 	ListWorkItemChildren(ctx context.Context, parentID uuid.UUID, start *int, limit *int) ([]workitem.WorkItem, int, error)
 	WorkItemHasChildren(ctx context.Context, parentID uuid.UUID) (bool, error)
 	// GetAncestors returns all ancestors for the given work items.
 	GetAncestors(ctx context.Context, linkTypeID uuid.UUID, upToLevel int, workItemIDs ...uuid.UUID) (ancestors AncestorList, err error)
+	// CountByTypeID returns the number of links of the given link type.
+	CountByTypeID(ctx context.Context, linkTypeID uuid.UUID) (int, error)
+	// CountByTypeIDs returns the number of links for each of the given link
+	// types. Link types without any link are contained with a count of 0.
+	CountByTypeIDs(ctx context.Context, linkTypeIDs ...uuid.UUID) (map[uuid.UUID]int, error)
+	// CountDirectionsByTypeIDs returns the forward and reverse usage of each
+	// of the given link types.
+	CountDirectionsByTypeIDs(ctx context.Context, linkTypeIDs ...uuid.UUID) (map[uuid.UUID]DirectionCount, error)
+	// ConsolidateDuplicateTypes merges work item link types that share the
+	// same name within the given space into a single link type.
+	ConsolidateDuplicateTypes(ctx context.Context, spaceID uuid.UUID, modifierID uuid.UUID) ([]TypeConsolidation, error)
+	// UsageOverTime returns the number of links of the given type created per
+	// time bucket between from and to.
+	UsageOverTime(ctx context.Context, linkTypeID uuid.UUID, from, to time.Time, granularity UsageGranularity) ([]UsageBucket, error)
 }
 
 // NewWorkItemLinkRepository creates a work item link repository based on gorm
@@ -115,7 +151,12 @@ func (r *GormWorkItemLinkRepository) ValidateTopology(ctx context.Context, sourc
 			return errs.Wrapf(err, "error during cycle-detection of new link")
 		}
 		if hasCycle {
-			return errs.New("link cycle detected")
+			log.Error(ctx, map[string]interface{}{
+				"wilt_id":   linkType.ID,
+				"source_id": sourceID,
+				"target_id": targetID,
+			}, "unable to create/update work item link because it would cause a cycle in a topology of type \"%s\"", linkType.Topology)
+			return errors.NewBadParameterError("target", targetID).Expected(fmt.Sprintf("a work item that doesn't cause a cycle in the %s topology of link type %s when linked from source %s", linkType.Topology, linkType.ID, sourceID))
 		}
 	}
 	return nil
@@ -216,24 +257,56 @@ func (r *GormWorkItemLinkRepository) Create(ctx context.Context, sourceID, targe
 		return nil, errs.WithStack(err)
 	}
 
-	// double check only links between the same space are allowed.
-	// NOTE(kwk): This is only until we have a proper cross-space
-	// cycle detection with locks.
+	// links between different spaces are only allowed if all involved spaces
+	// opted in to cross-space linking.
 	wiRepo := workitem.NewWorkItemRepository(r.db)
 	workItemIDs := []uuid.UUID{sourceID, targetID}
 	items, err := wiRepo.LoadBatchByID(ctx, workItemIDs)
 	if err != nil {
 		return nil, errs.Wrapf(err, "failed to load source and target work items: %+v", workItemIDs)
 	}
-	spaceID := items[0].SpaceID
+	found := map[uuid.UUID]uuid.UUID{}
+	witIDs := map[uuid.UUID]uuid.UUID{}
+	var spaceIDs id.Slice
 	for _, item := range items {
-		if item.SpaceID != spaceID {
-			return nil, errs.Errorf("cross-space links are not allowed (for now)")
+		found[item.ID] = item.SpaceID
+		witIDs[item.ID] = item.Type
+		spaceIDs = append(spaceIDs, item.SpaceID)
+	}
+	spaceID, ok := found[sourceID]
+	if !ok {
+		return nil, errors.NewNotFoundError("source", sourceID.String())
+	}
+	if _, ok := found[targetID]; !ok {
+		return nil, errors.NewNotFoundError("target", targetID.String())
+	}
+	spaceIDs = spaceIDs.Unique()
+	crossSpace := len(spaceIDs) > 1
+	if crossSpace {
+		spaceRepo := space.NewRepository(r.db)
+		for _, sID := range spaceIDs {
+			s, err := spaceRepo.Load(ctx, sID)
+			if err != nil {
+				return nil, errs.Wrapf(err, "failed to load space %s", sID)
+			}
+			if !s.AllowCrossSpaceLinks {
+				log.Error(ctx, map[string]interface{}{
+					"source_id": sourceID,
+					"target_id": targetID,
+					"space_id":  sID,
+				}, "unable to create work item link because the space doesn't allow cross-space links")
+				return nil, errors.NewDataConflictError(fmt.Sprintf("space %s doesn't allow links to work items of other spaces", sID))
+			}
 		}
 	}
 
-	if err := r.acquireLock(spaceID); err != nil {
-		return nil, errs.Wrap(err, "failed to acquire lock during link creation")
+	// NOTE: locks are always acquired in the same order to avoid deadlocks
+	// between concurrent cross-space link creations.
+	sort.Slice(spaceIDs, func(i, j int) bool { return spaceIDs[i].String() < spaceIDs[j].String() })
+	for _, sID := range spaceIDs {
+		if err := r.acquireLock(sID); err != nil {
+			return nil, errs.Wrap(err, "failed to acquire lock during link creation")
+		}
 	}
 
 	// Fetch the link type
@@ -242,6 +315,42 @@ func (r *GormWorkItemLinkRepository) Create(ctx context.Context, sourceID, targe
 		return nil, errs.Wrap(err, "failed to load link type")
 	}
 
+	// Cross-space links require a global link type from the system space
+	// because no single space owns them.
+	if crossSpace && linkType.SpaceID != space.SystemSpace {
+		log.Error(ctx, map[string]interface{}{
+			"wilt_id":       linkTypeID,
+			"wilt_space_id": linkType.SpaceID,
+		}, "unable to create cross-space work item link because the link type is not global")
+		return nil, errors.NewBadParameterError("data.relationships.link_type.data.id", linkTypeID).Expected("a global link type for links between spaces")
+	}
+
+	// Only link types of the link's own space or global link types from the
+	// system space may be used.
+	if linkType.SpaceID != spaceID && linkType.SpaceID != space.SystemSpace {
+		log.Error(ctx, map[string]interface{}{
+			"wilt_id":       linkTypeID,
+			"wilt_space_id": linkType.SpaceID,
+			"space_id":      spaceID,
+		}, "unable to create work item link because the link type belongs to another space")
+		return nil, errors.NewBadParameterError("data.relationships.link_type.data.id", linkTypeID).Expected(fmt.Sprintf("link type of space %s or a global link type", spaceID))
+	}
+
+	if uuid.Equal(sourceID, targetID) && !linkType.SelfReferenceAllowed {
+		log.Error(ctx, map[string]interface{}{
+			"wilt_id":   linkTypeID,
+			"source_id": sourceID,
+		}, "unable to create work item link because the link type doesn't allow linking a work item to itself")
+		return nil, errors.NewBadParameterError("data.relationships.target.data.id", targetID).Expected("a work item other than the source because the link type doesn't allow self-references")
+	}
+
+	if !linkType.SourceWorkItemTypeIDs.Allows(witIDs[sourceID]) {
+		return nil, errors.NewBadParameterError("data.relationships.source.data.id", sourceID).Expected("a work item of a type that the link type allows as source")
+	}
+	if !linkType.TargetWorkItemTypeIDs.Allows(witIDs[targetID]) {
+		return nil, errors.NewBadParameterError("data.relationships.target.data.id", targetID).Expected("a work item of a type that the link type allows as target")
+	}
+
 	// Make sure we don't violate the topology when we add the link from source
 	// to target.
 	if err := r.ValidateTopology(ctx, sourceID, targetID, *linkType); err != nil {
@@ -272,6 +381,41 @@ func (r *GormWorkItemLinkRepository) Create(ctx context.Context, sourceID, targe
 	return link, nil
 }
 
+// CreateBatch creates the given links on behalf of the given creator. Each
+// link is validated and created like a link passed to Create, but within its
+// own savepoint, so that a link that can't be created doesn't prevent the
+// remaining links from being validated. The returned slices hold the created
+// link or the reason why the link couldn't be created at the index of the
+// given link. The returned error is only set if the batch itself failed.
+//
+// NOTE: The caller has to roll back the transaction if any link couldn't be
+// created and the batch must succeed or fail as a whole.
+func (r *GormWorkItemLinkRepository) CreateBatch(ctx context.Context, links []WorkItemLink, creatorID uuid.UUID) ([]*WorkItemLink, []error, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "createBatch"}, time.Now())
+	created := make([]*WorkItemLink, len(links))
+	linkErrs := make([]error, len(links))
+	for i, l := range links {
+		if db := r.db.Exec("SAVEPOINT create_work_item_link"); db.Error != nil {
+			return nil, nil, errs.Wrap(db.Error, "failed to create savepoint")
+		}
+		createdLink, err := r.Create(ctx, l.SourceID, l.TargetID, l.LinkTypeID, creatorID)
+		if err != nil {
+			linkErrs[i] = err
+			// A failed statement aborts the whole transaction unless we return
+			// to the state before the link.
+			if db := r.db.Exec("ROLLBACK TO SAVEPOINT create_work_item_link"); db.Error != nil {
+				return nil, nil, errs.Wrap(db.Error, "failed to roll back to savepoint")
+			}
+			continue
+		}
+		if db := r.db.Exec("RELEASE SAVEPOINT create_work_item_link"); db.Error != nil {
+			return nil, nil, errs.Wrap(db.Error, "failed to release savepoint")
+		}
+		created[i] = createdLink
+	}
+	return created, linkErrs, nil
+}
+
 // Load returns the work item link for the given ID.
 // Returns NotFoundError, ConversionError or InternalError
 func (r *GormWorkItemLinkRepository) Load(ctx context.Context, ID uuid.UUID) (*WorkItemLink, error) {
@@ -293,6 +437,34 @@ func (r *GormWorkItemLinkRepository) Load(ctx context.Context, ID uuid.UUID) (*W
 	return &result, nil
 }
 
+// LoadByEndpoints returns the work item link of the given type from the given
+// source to the given target. Returns NotFoundError, DataConflictError or
+// InternalError
+func (r *GormWorkItemLinkRepository) LoadByEndpoints(ctx context.Context, sourceID, targetID uuid.UUID, linkTypeID uuid.UUID) (*WorkItemLink, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "loadByEndpoints"}, time.Now())
+	// The unique index on the endpoints and type should prevent more than one
+	// match, but look for a second one to not silently pick any of them
+	var result []WorkItemLink
+	db := r.db.Where("source_id=? AND target_id=? AND link_type_id=?", sourceID, targetID, linkTypeID).Limit(2).Find(&result)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"source_id": sourceID,
+			"target_id": targetID,
+			"wilt_id":   linkTypeID,
+			"err":       db.Error,
+		}, "failed to load work item link by its endpoints")
+		return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to load work item link by its endpoints"))
+	}
+	switch len(result) {
+	case 0:
+		return nil, errors.NewNotFoundError("work item link", fmt.Sprintf("%s -> %s of type %s", sourceID, targetID, linkTypeID))
+	case 1:
+		return &result[0], nil
+	default:
+		return nil, errors.NewDataConflictError(fmt.Sprintf("more than one work item link %s -> %s of type %s exists", sourceID, targetID, linkTypeID))
+	}
+}
+
 // CheckExists returns nil if the given ID exists otherwise returns an error
 func (r *GormWorkItemLinkRepository) CheckExists(ctx context.Context, id uuid.UUID) error {
 	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "exists"}, time.Now())
@@ -316,6 +488,119 @@ func (r *GormWorkItemLinkRepository) ListByWorkItem(ctx context.Context, wiID uu
 	return modelLinks, nil
 }
 
+// Boundary is a work item attribute that separates work items into groups
+// (e.g. teams) and which links can cross.
+type Boundary string
+
+// Boundaries that links can cross
+const (
+	BoundaryIteration Boundary = "iteration"
+	BoundaryArea      Boundary = "area"
+)
+
+// field returns the work item field that determines the side of the boundary
+// a work item is on.
+func (b Boundary) field() (string, error) {
+	switch b {
+	case BoundaryIteration:
+		return workitem.SystemIteration, nil
+	case BoundaryArea:
+		return workitem.SystemArea, nil
+	default:
+		return "", errors.NewBadParameterError("boundary", b).Expected(BoundaryIteration + "|" + BoundaryArea)
+	}
+}
+
+// ListByWorkItemCrossing returns the work item links that have wiID as source
+// or target and whose source and target work items belong to different
+// iterations or areas, depending on the given boundary.
+func (r *GormWorkItemLinkRepository) ListByWorkItemCrossing(ctx context.Context, wiID uuid.UUID, boundary Boundary) ([]WorkItemLink, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "listByWorkItemCrossing"}, time.Now())
+	field, err := boundary.field()
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	wi, err := r.workItemRepo.LoadFromDB(ctx, wiID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	linkTable := WorkItemLink{}.TableName()
+	wiTable := workitem.WorkItemStorage{}.TableName()
+	modelLinks := []WorkItemLink{}
+	db := r.db.Model(&WorkItemLink{}).
+		Select(linkTable+".*").
+		Joins(fmt.Sprintf("JOIN %[1]s src ON src.id = %[2]s.source_id JOIN %[1]s tgt ON tgt.id = %[2]s.target_id", wiTable, linkTable)).
+		Where(fmt.Sprintf("? IN (%[1]s.source_id, %[1]s.target_id)", linkTable), wi.ID).
+		Where(fmt.Sprintf("(src.fields->>'%[1]s') IS DISTINCT FROM (tgt.fields->>'%[1]s')", field)).
+		Order(fmt.Sprintf("%s.created_at", linkTable)).
+		Find(&modelLinks)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wi_id":    wiID,
+			"boundary": boundary,
+			"err":      db.Error,
+		}, "failed to list work item links crossing a boundary")
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to list work item links crossing the %s boundary", boundary))
+	}
+	return modelLinks, nil
+}
+
+// ListByWorkItems returns the work item links that have any of the given work
+// items as source or target. Every link is contained only once, even if both
+// its source and target are among the given work items.
+func (r *GormWorkItemLinkRepository) ListByWorkItems(ctx context.Context, wiIDs ...uuid.UUID) ([]WorkItemLink, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "listByWorkItems"}, time.Now())
+	modelLinks := []WorkItemLink{}
+	if len(wiIDs) == 0 {
+		return modelLinks, nil
+	}
+	db := r.db.Where("source_id IN (?) OR target_id IN (?)", wiIDs, wiIDs).Order("created_at").Find(&modelLinks)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wi_ids": wiIDs,
+			"err":    db.Error,
+		}, "failed to list work item links by work items")
+		return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to list work item links by work items"))
+	}
+	return modelLinks, nil
+}
+
+// Stream calls fn for every work item link whose source or target work item
+// lives in the given space, so that links crossing into the space from
+// another space are streamed as well. Each link is streamed only once. The
+// links are read one at a time from a database cursor in the order of their
+// creation, so the whole set never has to fit in memory. If fn returns an
+// error the stream is aborted and that error is returned.
+func (r *GormWorkItemLinkRepository) Stream(ctx context.Context, spaceID uuid.UUID, fn func(WorkItemLink) error) error {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "stream"}, time.Now())
+	spaceWorkItems := fmt.Sprintf("SELECT id FROM %s WHERE space_id = ?", workitem.WorkItemStorage{}.TableName())
+	rows, err := r.db.Model(&WorkItemLink{}).
+		Where("source_id IN ("+spaceWorkItems+") OR target_id IN ("+spaceWorkItems+")", spaceID, spaceID).
+		Order("created_at").
+		Rows()
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"space_id": spaceID,
+			"err":      err,
+		}, "failed to stream work item links of space")
+		return errors.NewInternalError(ctx, errs.Wrapf(err, "failed to stream work item links of space %s", spaceID))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var l WorkItemLink
+		if err := r.db.ScanRows(rows, &l); err != nil {
+			return errors.NewInternalError(ctx, errs.Wrapf(err, "failed to scan work item link of space %s", spaceID))
+		}
+		if err := fn(l); err != nil {
+			return errs.WithStack(err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(err, "failed to stream work item links of space %s", spaceID))
+	}
+	return nil
+}
+
 // List returns all work item links if wiID is nil; otherwise the work item links are returned
 // that have wiID as source or target.
 // TODO: Handle pagination
@@ -379,6 +664,31 @@ func (r *GormWorkItemLinkRepository) DeleteRelatedLinks(ctx context.Context, wiI
 	return nil
 }
 
+// DeleteByTypeID deletes all work item links of the given link type and
+// returns the number of deleted links. The links are deleted one by one in
+// order to create a revision for each of them.
+func (r *GormWorkItemLinkRepository) DeleteByTypeID(ctx context.Context, linkTypeID uuid.UUID, suppressorID uuid.UUID) (int, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "deleteByTypeID"}, time.Now())
+	log.Info(ctx, map[string]interface{}{
+		"wilt_id": linkTypeID,
+	}, "Deleting the links of work item link type")
+	var workitemLinks []WorkItemLink
+	db := r.db.Where("link_type_id = ?", linkTypeID).Find(&workitemLinks)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wilt_id": linkTypeID,
+			"err":     db.Error,
+		}, "unable to list the links of work item link type")
+		return 0, errors.NewInternalError(ctx, db.Error)
+	}
+	for _, workitemLink := range workitemLinks {
+		if err := r.deleteLink(ctx, workitemLink, suppressorID); err != nil {
+			return 0, errs.WithStack(err)
+		}
+	}
+	return len(workitemLinks), nil
+}
+
 // Delete deletes the work item link with the given id
 // returns NotFoundError or InternalError
 func (r *GormWorkItemLinkRepository) deleteLink(ctx context.Context, lnk WorkItemLink, suppressorID uuid.UUID) error {
@@ -540,6 +850,276 @@ func (r *GormWorkItemLinkRepository) WorkItemHasChildren(ctx context.Context, pa
 	return hasChildren, nil
 }
 
+// CountByTypeID returns the number of existing links of the given link type.
+func (r *GormWorkItemLinkRepository) CountByTypeID(ctx context.Context, linkTypeID uuid.UUID) (int, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "count", "by", "type"}, time.Now())
+	var count int
+	db := r.db.Model(&WorkItemLink{}).Where("link_type_id = ?", linkTypeID).Count(&count)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wilt_id": linkTypeID,
+			"err":     db.Error,
+		}, "failed to count work item links of link type")
+		return 0, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to count work item links of link type"))
+	}
+	return count, nil
+}
+
+// CountByTypeIDs returns the number of existing links for each of the given
+// link types. Every given link type is contained in the result, even if there
+// is no link of that type.
+func (r *GormWorkItemLinkRepository) CountByTypeIDs(ctx context.Context, linkTypeIDs ...uuid.UUID) (map[uuid.UUID]int, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "count", "by", "types"}, time.Now())
+	res := make(map[uuid.UUID]int, len(linkTypeIDs))
+	if len(linkTypeIDs) == 0 {
+		return res, nil
+	}
+	for _, id := range linkTypeIDs {
+		res[id] = 0
+	}
+	var rows []struct {
+		LinkTypeID uuid.UUID
+		Count      int
+	}
+	db := r.db.Model(&WorkItemLink{}).Select("link_type_id, count(*) as count").Where("link_type_id IN (?)", linkTypeIDs).Group("link_type_id").Scan(&rows)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wilt_ids": linkTypeIDs,
+			"err":      db.Error,
+		}, "failed to count work item links by link type")
+		return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to count work item links by link type"))
+	}
+	for _, row := range rows {
+		res[row.LinkTypeID] = row.Count
+	}
+	return res, nil
+}
+
+// TypeConsolidation describes how the work item link types sharing one name
+// within a space were merged into a single link type.
+type TypeConsolidation struct {
+	Name string
+	// KeptTypeID is the link type that was kept because it had the most links.
+	KeptTypeID uuid.UUID
+	// RemovedTypeIDs are the duplicate link types that were deleted.
+	RemovedTypeIDs []uuid.UUID
+	// RepointedLinks is the number of links that were moved from a removed
+	// link type to the kept one.
+	RepointedLinks int
+	// MergedLinks is the number of links that were deleted because the kept
+	// link type already links the same source and target.
+	MergedLinks int
+}
+
+// ConsolidateDuplicateTypes finds work item link types with the same name in
+// the given space and keeps only the one with the most links (or the oldest
+// one in case of a tie). Links of the duplicates are repointed to the kept
+// link type and the duplicates are deleted afterwards. A link that would
+// violate the topology of the kept link type causes a DataConflictError; the
+// caller is expected to run this in a transaction so that nothing is changed
+// in that case.
+func (r *GormWorkItemLinkRepository) ConsolidateDuplicateTypes(ctx context.Context, spaceID uuid.UUID, modifierID uuid.UUID) ([]TypeConsolidation, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "consolidate", "types"}, time.Now())
+	var types []WorkItemLinkType
+	db := r.db.Where("space_id = ?", spaceID).Order("created_at").Find(&types)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"space_id": spaceID,
+			"err":      db.Error,
+		}, "unable to list work item link types of space")
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to list work item link types of space %s", spaceID))
+	}
+	// group the link types by name while preserving the creation order
+	var names []string
+	byName := map[string][]WorkItemLinkType{}
+	for _, t := range types {
+		if _, ok := byName[t.Name]; !ok {
+			names = append(names, t.Name)
+		}
+		byName[t.Name] = append(byName[t.Name], t)
+	}
+	res := []TypeConsolidation{}
+	if len(names) == len(types) {
+		return res, nil
+	}
+	ids := make([]uuid.UUID, len(types))
+	for i, t := range types {
+		ids[i] = t.ID
+	}
+	counts, err := r.CountByTypeIDs(ctx, ids...)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	if err := r.acquireLock(spaceID); err != nil {
+		return nil, errs.Wrap(err, "failed to acquire lock during link type consolidation")
+	}
+	for _, name := range names {
+		group := byName[name]
+		if len(group) < 2 {
+			continue
+		}
+		kept := group[0]
+		for _, t := range group[1:] {
+			if counts[t.ID] > counts[kept.ID] {
+				kept = t
+			}
+		}
+		c := TypeConsolidation{Name: name, KeptTypeID: kept.ID}
+		for _, t := range group {
+			if t.ID == kept.ID {
+				continue
+			}
+			var links []WorkItemLink
+			db := r.db.Where("link_type_id = ?", t.ID).Order("created_at").Find(&links)
+			if db.Error != nil {
+				return nil, errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to list work item links of type %s", t.ID))
+			}
+			for _, l := range links {
+				var existing int
+				db := r.db.Model(&WorkItemLink{}).Where("link_type_id = ? AND source_id = ? AND target_id = ?", kept.ID, l.SourceID, l.TargetID).Count(&existing)
+				if db.Error != nil {
+					return nil, errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to check for existing work item link of type %s", kept.ID))
+				}
+				if existing > 0 {
+					if err := r.deleteLink(ctx, l, modifierID); err != nil {
+						return nil, errs.Wrapf(err, "failed to delete duplicate work item link %s", l.ID)
+					}
+					c.MergedLinks++
+					continue
+				}
+				if err := r.ValidateTopology(ctx, l.SourceID, l.TargetID, kept); err != nil {
+					log.Error(ctx, map[string]interface{}{
+						"wil_id":       l.ID,
+						"wilt_id":      t.ID,
+						"kept_wilt_id": kept.ID,
+						"err":          err,
+					}, "unable to repoint work item link without violating the topology of the kept link type")
+					return nil, errors.NewDataConflictError(fmt.Sprintf("work item link %s of type %s can't be moved to type %s without violating its %s topology: %s", l.ID, t.ID, kept.ID, kept.Topology, err))
+				}
+				l.LinkTypeID = kept.ID
+				l.Version++
+				if db := r.db.Save(&l); db.Error != nil {
+					return nil, errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to repoint work item link %s", l.ID))
+				}
+				if err := r.revisionRepo.Create(ctx, modifierID, RevisionTypeUpdate, l); err != nil {
+					return nil, errs.Wrapf(err, "failed to create revision for repointed work item link %s", l.ID)
+				}
+				c.RepointedLinks++
+			}
+			if err := r.workItemLinkTypeRepo.Delete(ctx, spaceID, t.ID); err != nil {
+				return nil, errs.Wrapf(err, "failed to delete duplicate work item link type %s", t.ID)
+			}
+			c.RemovedTypeIDs = append(c.RemovedTypeIDs, t.ID)
+		}
+		log.Info(ctx, map[string]interface{}{
+			"space_id":         spaceID,
+			"wilt_name":        name,
+			"kept_wilt_id":     kept.ID,
+			"removed_wilt_ids": c.RemovedTypeIDs,
+		}, "consolidated duplicate work item link types")
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+// DirectionCount holds the usage of a link type broken down by direction.
+type DirectionCount struct {
+	// Forward is the number of distinct work items that use the link type's
+	// forward name, i.e. that are the source of at least one link of the type.
+	Forward int
+	// Reverse is the number of distinct work items that use the link type's
+	// reverse name, i.e. that are the target of at least one link of the type.
+	Reverse int
+}
+
+// CountDirectionsByTypeIDs returns the forward and reverse usage for each of
+// the given link types. Every given link type is contained in the result,
+// even if there is no link of that type.
+func (r *GormWorkItemLinkRepository) CountDirectionsByTypeIDs(ctx context.Context, linkTypeIDs ...uuid.UUID) (map[uuid.UUID]DirectionCount, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "count", "directions", "by", "types"}, time.Now())
+	res := make(map[uuid.UUID]DirectionCount, len(linkTypeIDs))
+	if len(linkTypeIDs) == 0 {
+		return res, nil
+	}
+	for _, id := range linkTypeIDs {
+		res[id] = DirectionCount{}
+	}
+	var rows []struct {
+		LinkTypeID uuid.UUID
+		Forward    int
+		Reverse    int
+	}
+	db := r.db.Model(&WorkItemLink{}).
+		Select("link_type_id, count(DISTINCT source_id) as forward, count(DISTINCT target_id) as reverse").
+		Where("link_type_id IN (?)", linkTypeIDs).
+		Group("link_type_id").
+		Scan(&rows)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wilt_ids": linkTypeIDs,
+			"err":      db.Error,
+		}, "failed to count work item link directions by link type")
+		return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to count work item link directions by link type"))
+	}
+	for _, row := range rows {
+		res[row.LinkTypeID] = DirectionCount{Forward: row.Forward, Reverse: row.Reverse}
+	}
+	return res, nil
+}
+
+// UsageOverTime returns the number of links of the given type that were
+// created in each time bucket between from and to and are not deleted. The
+// size of a bucket is determined by the given granularity. Buckets without any
+// link creation are included with a count of zero, so the result is a gapless
+// time series. At most MaxUsageBuckets buckets can be requested at once.
+func (r *GormWorkItemLinkRepository) UsageOverTime(ctx context.Context, linkTypeID uuid.UUID, from, to time.Time, granularity UsageGranularity) ([]UsageBucket, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "usage"}, time.Now())
+	if err := granularity.CheckValid(); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	if err := granularity.CheckRange(from, to); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	// generate_series produces every bucket in the range; the LEFT JOIN makes
+	// sure that buckets without any link still show up with a count of 0.
+	query := fmt.Sprintf(`
+		SELECT
+			b.bucket,
+			COUNT(l.id)
+		FROM generate_series(date_trunc($2, $3::timestamptz), date_trunc($2, $4::timestamptz), ('1 ' || $2)::interval) AS b(bucket)
+		LEFT JOIN %[1]s l ON
+			l.link_type_id = $1
+			AND l.deleted_at IS NULL
+			AND l.created_at >= $3
+			AND l.created_at <= $4
+			AND date_trunc($2, l.created_at) = b.bucket
+		GROUP BY b.bucket
+		ORDER BY b.bucket`,
+		WorkItemLink{}.TableName())
+	rows, err := r.db.CommonDB().Query(query, linkTypeID, string(granularity), from, to)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wilt_id":     linkTypeID,
+			"granularity": granularity,
+			"err":         err,
+		}, "failed to count work item links per time bucket")
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to count work item links of type %s per %s", linkTypeID, granularity))
+	}
+	defer rows.Close()
+	buckets := []UsageBucket{}
+	for rows.Next() {
+		var b UsageBucket
+		if err := rows.Scan(&b.Start, &b.Count); err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to scan work item link usage bucket"))
+		}
+		buckets = append(buckets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to iterate over work item link usage buckets"))
+	}
+	return buckets, nil
+}
+
 // GetAncestors returns all ancestors for the given work items based on the
 // given level. Level stands for -1=all, 0=no, 1=up to parent, 2=up to
 // grandparent, 3=up to great-grandparent, and so forth.
@@ -5,15 +5,18 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
 	"github.com/fabric8-services/fabric8-wit/ptr"
 	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
 	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 	_ "github.com/lib/pq" // need to import postgres driver
+	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -255,6 +258,29 @@ func (s *linkRepoBlackBoxTest) TestValidateTopology() {
 		// then: there must be an error because a link of the same type already exists with another parent
 		require.Error(t, err)
 	})
+
+	s.T().Run("fail - closing a chain causes a cycle", func(t *testing.T) {
+		// given a chain A-B-C of a tree topology
+		fxt := tf.NewTestFixture(t, s.DB,
+			tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+			tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyTree)),
+			tf.WorkItemLinksCustom(2, tf.BuildLinks(tf.LinkChain("A", "B", "C")...)),
+		)
+		// when validating a link from C back to the root A (which has no
+		// parent yet, so only the cycle check can fail)
+		err := s.workitemLinkRepo.ValidateTopology(s.Ctx, fxt.WorkItemByTitle("C").ID, fxt.WorkItemByTitle("A").ID, *fxt.WorkItemLinkTypes[0])
+		// then
+		require.Error(t, err)
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		require.Equal(t, "target", errs.Cause(err).(errors.BadParameterError).Parameter())
+		// and creating the link fails the same way without inserting it
+		_, err = s.workitemLinkRepo.Create(s.Ctx, fxt.WorkItemByTitle("C").ID, fxt.WorkItemByTitle("A").ID, fxt.WorkItemLinkTypes[0].ID, fxt.Identities[0].ID)
+		require.Error(t, err)
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		counts, err := s.workitemLinkRepo.CountByTypeIDs(s.Ctx, fxt.WorkItemLinkTypes[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, counts[fxt.WorkItemLinkTypes[0].ID])
+	})
 }
 
 // createLinksConcurrently accepts a list of function of which only 1 is
@@ -324,9 +350,35 @@ func (s *linkRepoBlackBoxTest) TestCreate() {
 			// then
 			require.NoError(t, err)
 		})
+		t.Run("self-reference allowed by link type", func(t *testing.T) {
+			// given
+			fxt := tf.NewTestFixture(t, s.DB,
+				tf.WorkItems(1),
+				tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyNetwork), func(fxt *tf.TestFixture, idx int) error {
+					fxt.WorkItemLinkTypes[idx].SelfReferenceAllowed = true
+					return nil
+				}),
+			)
+			// when
+			_, err := s.workitemLinkRepo.Create(s.Ctx, fxt.WorkItems[0].ID, fxt.WorkItems[0].ID, fxt.WorkItemLinkTypes[0].ID, fxt.Identities[0].ID)
+			// then
+			require.NoError(t, err)
+		})
 	})
 
 	s.T().Run("fail", func(t *testing.T) {
+		t.Run("self-reference not allowed by link type", func(t *testing.T) {
+			// given
+			fxt := tf.NewTestFixture(t, s.DB,
+				tf.WorkItems(1),
+				tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyNetwork)),
+			)
+			// when
+			_, err := s.workitemLinkRepo.Create(s.Ctx, fxt.WorkItems[0].ID, fxt.WorkItems[0].ID, fxt.WorkItemLinkTypes[0].ID, fxt.Identities[0].ID)
+			// then
+			require.Error(t, err)
+			require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		})
 		t.Run("single-parent violation in tree topology", func(t *testing.T) {
 			// given 2 work items linked with one tree-topology link type
 			fxt := tf.NewTestFixture(t, s.DB,
@@ -354,10 +406,83 @@ func (s *linkRepoBlackBoxTest) TestCreate() {
 				_, err := s.workitemLinkRepo.Create(s.Ctx, fxt1.WorkItemByTitle("A").ID, fxt2.WorkItemByTitle("B").ID, fxt1.WorkItemLinkTypes[0].ID, fxt1.Identities[0].ID)
 				// then
 				require.Error(t, err)
+				require.IsType(t, errors.DataConflictError{}, errs.Cause(err))
 			}
 		})
 	})
 
+	s.T().Run("cross-space linking allowed", func(t *testing.T) {
+		allowCrossSpaceLinks := func(allow bool) tf.CustomizeSpaceFunc {
+			return func(fxt *tf.TestFixture, idx int) error {
+				fxt.Spaces[idx].AllowCrossSpaceLinks = allow
+				return nil
+			}
+		}
+		t.Run("ok with global link type", func(t *testing.T) {
+			// given two work items in different spaces that both allow cross-space links
+			fxt1 := tf.NewTestFixture(t, s.DB, tf.Spaces(1, allowCrossSpaceLinks(true)), tf.WorkItems(1, tf.SetWorkItemTitles("A")))
+			fxt2 := tf.NewTestFixture(t, s.DB, tf.Spaces(1, allowCrossSpaceLinks(true)), tf.WorkItems(1, tf.SetWorkItemTitles("B")))
+			// when
+			l, err := s.workitemLinkRepo.Create(s.Ctx, fxt1.WorkItemByTitle("A").ID, fxt2.WorkItemByTitle("B").ID, link.SystemWorkItemLinkPlannerItemRelatedID, fxt1.Identities[0].ID)
+			// then
+			require.NoError(t, err)
+			require.Equal(t, fxt2.WorkItemByTitle("B").ID, l.TargetID)
+		})
+		t.Run("fail with link type of a space", func(t *testing.T) {
+			// given
+			fxt1 := tf.NewTestFixture(t, s.DB, tf.Spaces(1, allowCrossSpaceLinks(true)), tf.WorkItems(1, tf.SetWorkItemTitles("A")), tf.WorkItemLinkTypes(1))
+			fxt2 := tf.NewTestFixture(t, s.DB, tf.Spaces(1, allowCrossSpaceLinks(true)), tf.WorkItems(1, tf.SetWorkItemTitles("B")))
+			// when
+			_, err := s.workitemLinkRepo.Create(s.Ctx, fxt1.WorkItemByTitle("A").ID, fxt2.WorkItemByTitle("B").ID, fxt1.WorkItemLinkTypes[0].ID, fxt1.Identities[0].ID)
+			// then
+			require.Error(t, err)
+			require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		})
+		t.Run("fail if only one space allows it", func(t *testing.T) {
+			// given
+			fxt1 := tf.NewTestFixture(t, s.DB, tf.Spaces(1, allowCrossSpaceLinks(true)), tf.WorkItems(1, tf.SetWorkItemTitles("A")))
+			fxt2 := tf.NewTestFixture(t, s.DB, tf.Spaces(1, allowCrossSpaceLinks(false)), tf.WorkItems(1, tf.SetWorkItemTitles("B")))
+			// when
+			_, err := s.workitemLinkRepo.Create(s.Ctx, fxt1.WorkItemByTitle("A").ID, fxt2.WorkItemByTitle("B").ID, link.SystemWorkItemLinkPlannerItemRelatedID, fxt1.Identities[0].ID)
+			// then
+			require.Error(t, err)
+			require.IsType(t, errors.DataConflictError{}, errs.Cause(err))
+		})
+	})
+
+	s.T().Run("link type space", func(t *testing.T) {
+		t.Run("link type from another space", func(t *testing.T) {
+			// given a link type that belongs to a different space than the work items
+			fxt := tf.NewTestFixture(t, s.DB,
+				tf.Spaces(2),
+				tf.WorkItems(2, tf.SetWorkItemTitles("A", "B")),
+				tf.WorkItemLinkTypes(1, func(fxt *tf.TestFixture, idx int) error {
+					fxt.WorkItemLinkTypes[idx].SpaceID = fxt.Spaces[1].ID
+					return nil
+				}),
+			)
+			// when
+			_, err := s.workitemLinkRepo.Create(s.Ctx, fxt.WorkItemByTitle("A").ID, fxt.WorkItemByTitle("B").ID, fxt.WorkItemLinkTypes[0].ID, fxt.Identities[0].ID)
+			// then
+			require.Error(t, err)
+			require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		})
+		t.Run("global link type", func(t *testing.T) {
+			// given a link type from the system space
+			fxt := tf.NewTestFixture(t, s.DB,
+				tf.WorkItems(2, tf.SetWorkItemTitles("A", "B")),
+				tf.WorkItemLinkTypes(1, func(fxt *tf.TestFixture, idx int) error {
+					fxt.WorkItemLinkTypes[idx].SpaceID = space.SystemSpace
+					return nil
+				}),
+			)
+			// when
+			_, err := s.workitemLinkRepo.Create(s.Ctx, fxt.WorkItemByTitle("A").ID, fxt.WorkItemByTitle("B").ID, fxt.WorkItemLinkTypes[0].ID, fxt.Identities[0].ID)
+			// then
+			require.NoError(t, err)
+		})
+	})
+
 	s.T().Run("cycle detection", func(t *testing.T) {
 		t.Run("serial", func(t *testing.T) {
 			// These are the scenarios we test here.
@@ -600,6 +725,51 @@ func (s *linkRepoBlackBoxTest) TestCreate() {
 	})
 }
 
+func (s *linkRepoBlackBoxTest) TestCreateWithAllowedWorkItemTypes() {
+	// given a link type that only connects bugs to tasks
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItemTypes(2, tf.SetWorkItemTypeNames("Bug", "Task")),
+		tf.WorkItems(4, tf.SetWorkItemTitles("bug1", "bug2", "task1", "task2"), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItems[idx].Type = fxt.WorkItemTypes[idx/2].ID
+			return nil
+		}),
+		tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyNetwork), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].SourceWorkItemTypeIDs = link.WorkItemTypeIDs{fxt.WorkItemTypeByName("Bug").ID}
+			fxt.WorkItemLinkTypes[idx].TargetWorkItemTypeIDs = link.WorkItemTypeIDs{fxt.WorkItemTypeByName("Task").ID}
+			return nil
+		}),
+	)
+	linkTypeID := fxt.WorkItemLinkTypes[0].ID
+	s.T().Run("allowed types", func(t *testing.T) {
+		// when
+		_, err := s.workitemLinkRepo.Create(s.Ctx, fxt.WorkItemByTitle("bug1").ID, fxt.WorkItemByTitle("task1").ID, linkTypeID, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+	})
+	s.T().Run("source type not allowed", func(t *testing.T) {
+		// when
+		_, err := s.workitemLinkRepo.Create(s.Ctx, fxt.WorkItemByTitle("task1").ID, fxt.WorkItemByTitle("task2").ID, linkTypeID, fxt.Identities[0].ID)
+		// then
+		require.Error(t, err)
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+	s.T().Run("target type not allowed", func(t *testing.T) {
+		// when
+		_, err := s.workitemLinkRepo.Create(s.Ctx, fxt.WorkItemByTitle("bug1").ID, fxt.WorkItemByTitle("bug2").ID, linkTypeID, fxt.Identities[0].ID)
+		// then
+		require.Error(t, err)
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+	s.T().Run("restrictions are stored", func(t *testing.T) {
+		// when
+		loaded, err := link.NewWorkItemLinkTypeRepository(s.DB).Load(s.Ctx, linkTypeID)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].SourceWorkItemTypeIDs, loaded.SourceWorkItemTypeIDs)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].TargetWorkItemTypeIDs, loaded.TargetWorkItemTypeIDs)
+	})
+}
+
 func (s *linkRepoBlackBoxTest) TestExistsLink() {
 	s.T().Run("link exists", func(t *testing.T) {
 		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(1))
@@ -613,6 +783,386 @@ func (s *linkRepoBlackBoxTest) TestExistsLink() {
 	})
 }
 
+func (s *linkRepoBlackBoxTest) TestLoadByEndpoints() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.WorkItemLinks(1))
+	l := fxt.WorkItemLinks[0]
+	s.T().Run("link exists", func(t *testing.T) {
+		// when
+		loaded, err := s.workitemLinkRepo.LoadByEndpoints(s.Ctx, l.SourceID, l.TargetID, l.LinkTypeID)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, l.ID, loaded.ID)
+	})
+	s.T().Run("link in other direction doesn't exist", func(t *testing.T) {
+		// when
+		_, err := s.workitemLinkRepo.LoadByEndpoints(s.Ctx, l.TargetID, l.SourceID, l.LinkTypeID)
+		// then
+		require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+	s.T().Run("link of other type doesn't exist", func(t *testing.T) {
+		// when
+		_, err := s.workitemLinkRepo.LoadByEndpoints(s.Ctx, l.SourceID, l.TargetID, uuid.NewV4())
+		// then
+		require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+}
+
+func (s *linkRepoBlackBoxTest) TestCreateBatch() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyNetwork)),
+		tf.WorkItemLinksCustom(1, tf.BuildLinks(tf.L("A", "B"))),
+	)
+	A := fxt.WorkItemByTitle("A").ID
+	B := fxt.WorkItemByTitle("B").ID
+	C := fxt.WorkItemByTitle("C").ID
+	linkTypeID := fxt.WorkItemLinkTypes[0].ID
+	newLink := func(sourceID, targetID uuid.UUID) link.WorkItemLink {
+		return link.WorkItemLink{SourceID: sourceID, TargetID: targetID, LinkTypeID: linkTypeID}
+	}
+	// savepoints require a transaction
+	inTransaction := func(t *testing.T, fn func(repo link.WorkItemLinkRepository)) {
+		db := s.DB.Begin()
+		require.NoError(t, db.Error)
+		defer db.Rollback()
+		fn(link.NewWorkItemLinkRepository(db))
+	}
+
+	s.T().Run("all links valid", func(t *testing.T) {
+		inTransaction(t, func(repo link.WorkItemLinkRepository) {
+			// when
+			created, linkErrs, err := repo.CreateBatch(s.Ctx, []link.WorkItemLink{newLink(A, C), newLink(B, C)}, fxt.Identities[0].ID)
+			// then
+			require.NoError(t, err)
+			require.Equal(t, []error{nil, nil}, linkErrs)
+			require.Len(t, created, 2)
+			require.Equal(t, A, created[0].SourceID)
+			require.Equal(t, B, created[1].SourceID)
+		})
+	})
+
+	s.T().Run("invalid links don't prevent validation of others", func(t *testing.T) {
+		inTransaction(t, func(repo link.WorkItemLinkRepository) {
+			// when the first link already exists and the second one has an
+			// unknown target
+			created, linkErrs, err := repo.CreateBatch(s.Ctx, []link.WorkItemLink{newLink(A, B), newLink(A, uuid.NewV4()), newLink(B, C)}, fxt.Identities[0].ID)
+			// then
+			require.NoError(t, err)
+			require.Len(t, linkErrs, 3)
+			require.IsType(t, errors.DataConflictError{}, errs.Cause(linkErrs[0]))
+			require.IsType(t, errors.NotFoundError{}, errs.Cause(linkErrs[1]))
+			require.NoError(t, linkErrs[2])
+			require.Nil(t, created[0])
+			require.Nil(t, created[1])
+			require.NotNil(t, created[2])
+			require.Equal(t, C, created[2].TargetID)
+		})
+	})
+}
+
+func (s *linkRepoBlackBoxTest) TestListByWorkItems() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(4, tf.SetWorkItemTitles("A", "B", "C", "D")),
+		tf.WorkItemLinksCustom(3, tf.BuildLinks(tf.L("A", "B"), tf.L("B", "C"), tf.L("C", "D"))),
+	)
+	s.T().Run("links are only returned once", func(t *testing.T) {
+		// when
+		links, err := s.workitemLinkRepo.ListByWorkItems(s.Ctx, fxt.WorkItemByTitle("A").ID, fxt.WorkItemByTitle("B").ID)
+		// then
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		require.Equal(t, fxt.WorkItemLinks[0].ID, links[0].ID)
+		require.Equal(t, fxt.WorkItemLinks[1].ID, links[1].ID)
+	})
+	s.T().Run("no work items", func(t *testing.T) {
+		// when
+		links, err := s.workitemLinkRepo.ListByWorkItems(s.Ctx)
+		// then
+		require.NoError(t, err)
+		require.Empty(t, links)
+	})
+}
+
+func (s *linkRepoBlackBoxTest) TestListByWorkItemCrossing() {
+	// given work item A linked to B in the same iteration and to C in another
+	// iteration
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Iterations(2),
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C"), func(fxt *tf.TestFixture, idx int) error {
+			iteration := fxt.Iterations[0]
+			if idx == 2 {
+				iteration = fxt.Iterations[1]
+			}
+			fxt.WorkItems[idx].Fields[workitem.SystemIteration] = iteration.ID.String()
+			return nil
+		}),
+		tf.WorkItemLinksCustom(2, tf.BuildLinks(tf.L("A", "B"), tf.L("A", "C"))),
+	)
+	s.T().Run("iteration", func(t *testing.T) {
+		// when
+		links, err := s.workitemLinkRepo.ListByWorkItemCrossing(s.Ctx, fxt.WorkItemByTitle("A").ID, link.BoundaryIteration)
+		// then
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		require.Equal(t, fxt.WorkItemLinks[1].ID, links[0].ID)
+	})
+	s.T().Run("area", func(t *testing.T) {
+		// when
+		links, err := s.workitemLinkRepo.ListByWorkItemCrossing(s.Ctx, fxt.WorkItemByTitle("A").ID, link.BoundaryArea)
+		// then all work items are in the same area
+		require.NoError(t, err)
+		require.Empty(t, links)
+	})
+	s.T().Run("unknown boundary", func(t *testing.T) {
+		// when
+		_, err := s.workitemLinkRepo.ListByWorkItemCrossing(s.Ctx, fxt.WorkItemByTitle("A").ID, link.Boundary("foo"))
+		// then
+		require.Error(t, err)
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+}
+
+func (s *linkRepoBlackBoxTest) TestStream() {
+	// given three links in one space and one link in another space
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(4, tf.SetWorkItemTitles("A", "B", "C", "D")),
+		tf.WorkItemLinksCustom(3, tf.BuildLinks(tf.L("A", "B"), tf.L("A", "C"), tf.L("A", "D"))),
+	)
+	other := tf.NewTestFixture(s.T(), s.DB, tf.WorkItemLinks(1))
+	s.T().Run("all links of the space", func(t *testing.T) {
+		// when
+		var streamed []uuid.UUID
+		err := s.workitemLinkRepo.Stream(s.Ctx, fxt.Spaces[0].ID, func(l link.WorkItemLink) error {
+			streamed = append(streamed, l.ID)
+			return nil
+		})
+		// then
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{fxt.WorkItemLinks[0].ID, fxt.WorkItemLinks[1].ID, fxt.WorkItemLinks[2].ID}, streamed)
+		require.NotContains(t, streamed, other.WorkItemLinks[0].ID)
+	})
+	s.T().Run("links crossing spaces", func(t *testing.T) {
+		// given a link from a work item of one space to a work item of another
+		allowCrossSpaceLinks := func(fxt *tf.TestFixture, idx int) error {
+			fxt.Spaces[idx].AllowCrossSpaceLinks = true
+			return nil
+		}
+		fxt1 := tf.NewTestFixture(t, s.DB, tf.Spaces(1, allowCrossSpaceLinks), tf.WorkItems(1, tf.SetWorkItemTitles("A")))
+		fxt2 := tf.NewTestFixture(t, s.DB, tf.Spaces(1, allowCrossSpaceLinks), tf.WorkItems(1, tf.SetWorkItemTitles("B")))
+		l, err := s.workitemLinkRepo.Create(s.Ctx, fxt1.WorkItemByTitle("A").ID, fxt2.WorkItemByTitle("B").ID, link.SystemWorkItemLinkPlannerItemRelatedID, fxt1.Identities[0].ID)
+		require.NoError(t, err)
+		// when streaming either space then the link is streamed once
+		for _, spaceID := range []uuid.UUID{fxt1.Spaces[0].ID, fxt2.Spaces[0].ID} {
+			var streamed []uuid.UUID
+			err := s.workitemLinkRepo.Stream(s.Ctx, spaceID, func(l link.WorkItemLink) error {
+				streamed = append(streamed, l.ID)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, []uuid.UUID{l.ID}, streamed)
+		}
+	})
+	s.T().Run("error aborts stream", func(t *testing.T) {
+		// when
+		calls := 0
+		err := s.workitemLinkRepo.Stream(s.Ctx, fxt.Spaces[0].ID, func(l link.WorkItemLink) error {
+			calls++
+			return errs.New("stop")
+		})
+		// then
+		require.Error(t, err)
+		require.Equal(t, "stop", errs.Cause(err).Error())
+		require.Equal(t, 1, calls)
+	})
+}
+
+func (s *linkRepoBlackBoxTest) TestConsolidateDuplicateTypes() {
+	// given two link types with the same name in different categories where
+	// the second one has more links
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinkCategories(2),
+		tf.WorkItemLinkTypes(2,
+			tf.SetWorkItemLinkTypeNames("dup", "dup"),
+			tf.SetTopologies(link.TopologyNetwork, link.TopologyNetwork),
+			func(fxt *tf.TestFixture, idx int) error {
+				fxt.WorkItemLinkTypes[idx].LinkCategoryID = fxt.WorkItemLinkCategories[idx].ID
+				return nil
+			},
+		),
+		tf.WorkItemLinksCustom(5, func(fxt *tf.TestFixture, idx int) error {
+			links := []struct {
+				src, tgt string
+				typeIdx  int
+			}{
+				{"A", "B", 0}, {"C", "A", 0},
+				{"A", "B", 1}, {"A", "C", 1}, {"B", "C", 1},
+			}
+			fxt.WorkItemLinks[idx].SourceID = fxt.WorkItemByTitle(links[idx].src).ID
+			fxt.WorkItemLinks[idx].TargetID = fxt.WorkItemByTitle(links[idx].tgt).ID
+			fxt.WorkItemLinks[idx].LinkTypeID = fxt.WorkItemLinkTypes[links[idx].typeIdx].ID
+			return nil
+		}),
+	)
+	kept := fxt.WorkItemLinkTypes[1].ID
+	removed := fxt.WorkItemLinkTypes[0].ID
+	// when
+	res, err := s.workitemLinkRepo.ConsolidateDuplicateTypes(s.Ctx, fxt.Spaces[0].ID, fxt.Identities[0].ID)
+	// then
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []link.TypeConsolidation{{
+		Name:           "dup",
+		KeptTypeID:     kept,
+		RemovedTypeIDs: []uuid.UUID{removed},
+		RepointedLinks: 1,
+		MergedLinks:    1,
+	}}, res)
+	counts, err := s.workitemLinkRepo.CountByTypeIDs(s.Ctx, kept, removed)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[uuid.UUID]int{kept: 4, removed: 0}, counts)
+	_, err = link.NewWorkItemLinkTypeRepository(s.DB).Load(s.Ctx, removed)
+	require.IsType(s.T(), errors.NotFoundError{}, errs.Cause(err))
+	s.T().Run("nothing left to consolidate", func(t *testing.T) {
+		res, err := s.workitemLinkRepo.ConsolidateDuplicateTypes(s.Ctx, fxt.Spaces[0].ID, fxt.Identities[0].ID)
+		require.NoError(t, err)
+		require.Empty(t, res)
+	})
+}
+
+func (s *linkRepoBlackBoxTest) TestCountByTypeID() {
+	// given two link types of which only the first one is used
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinkTypes(2, tf.SetWorkItemLinkTypeNames("used", "unused")),
+		tf.WorkItemLinksCustom(2, tf.BuildLinks(tf.L("A", "B", "used"), tf.L("A", "C", "used"))),
+	)
+	s.T().Run("used", func(t *testing.T) {
+		count, err := s.workitemLinkRepo.CountByTypeID(s.Ctx, fxt.WorkItemLinkTypes[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+	s.T().Run("unused", func(t *testing.T) {
+		count, err := s.workitemLinkRepo.CountByTypeID(s.Ctx, fxt.WorkItemLinkTypes[1].ID)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+}
+
+func (s *linkRepoBlackBoxTest) TestDeleteByTypeID() {
+	// given three links of which two have the first type
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinkTypes(2, tf.SetWorkItemLinkTypeNames("doomed", "kept")),
+		tf.WorkItemLinksCustom(3, tf.BuildLinks(tf.L("A", "B", "doomed"), tf.L("A", "C", "doomed"), tf.L("B", "C", "kept"))),
+	)
+	// when
+	deleted, err := s.workitemLinkRepo.DeleteByTypeID(s.Ctx, fxt.WorkItemLinkTypes[0].ID, fxt.Identities[0].ID)
+	// then
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 2, deleted)
+	counts, err := s.workitemLinkRepo.CountByTypeIDs(s.Ctx, fxt.WorkItemLinkTypes[0].ID, fxt.WorkItemLinkTypes[1].ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[uuid.UUID]int{
+		fxt.WorkItemLinkTypes[0].ID: 0,
+		fxt.WorkItemLinkTypes[1].ID: 1,
+	}, counts)
+	// a revision is kept for each deleted link
+	revisions, err := link.NewRevisionRepository(s.DB).List(s.Ctx, fxt.WorkItemLinks[0].ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), link.RevisionTypeDelete, revisions[len(revisions)-1].Type)
+}
+
+func (s *linkRepoBlackBoxTest) TestCountByTypeIDs() {
+	// given two link types of which only the first one is used
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinkTypes(2, tf.SetWorkItemLinkTypeNames("used", "unused")),
+		tf.WorkItemLinksCustom(2, tf.BuildLinks(tf.L("A", "B", "used"), tf.L("A", "C", "used"))),
+	)
+	// when
+	counts, err := s.workitemLinkRepo.CountByTypeIDs(s.Ctx, fxt.WorkItemLinkTypes[0].ID, fxt.WorkItemLinkTypes[1].ID)
+	// then
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[uuid.UUID]int{
+		fxt.WorkItemLinkTypes[0].ID: 2,
+		fxt.WorkItemLinkTypes[1].ID: 0,
+	}, counts)
+}
+
+func (s *linkRepoBlackBoxTest) TestCountDirectionsByTypeIDs() {
+	// given one work item that is the source of two links of the first type
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinkTypes(2, tf.SetWorkItemLinkTypeNames("used", "unused")),
+		tf.WorkItemLinksCustom(2, tf.BuildLinks(tf.L("A", "B", "used"), tf.L("A", "C", "used"))),
+	)
+	// when
+	counts, err := s.workitemLinkRepo.CountDirectionsByTypeIDs(s.Ctx, fxt.WorkItemLinkTypes[0].ID, fxt.WorkItemLinkTypes[1].ID)
+	// then
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[uuid.UUID]link.DirectionCount{
+		fxt.WorkItemLinkTypes[0].ID: {Forward: 1, Reverse: 2},
+		fxt.WorkItemLinkTypes[1].ID: {},
+	}, counts)
+}
+
+func (s *linkRepoBlackBoxTest) TestUsageOverTime() {
+	s.T().Run("gaps are filled with zero buckets", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(2))
+		now := time.Now()
+		// when
+		buckets, err := s.workitemLinkRepo.UsageOverTime(s.Ctx, fxt.WorkItemLinkTypes[0].ID, now.Add(-48*time.Hour), now, link.UsageGranularityDay)
+		// then
+		require.NoError(t, err)
+		require.Len(t, buckets, 3)
+		require.Equal(t, 0, buckets[0].Count)
+		require.Equal(t, 0, buckets[1].Count)
+		require.Equal(t, 2, buckets[2].Count)
+	})
+
+	s.T().Run("invalid time range", func(t *testing.T) {
+		now := time.Now()
+		_, err := s.workitemLinkRepo.UsageOverTime(s.Ctx, uuid.NewV4(), now, now.Add(-time.Hour), link.UsageGranularityDay)
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+
+	s.T().Run("invalid granularity", func(t *testing.T) {
+		now := time.Now()
+		_, err := s.workitemLinkRepo.UsageOverTime(s.Ctx, uuid.NewV4(), now.Add(-time.Hour), now, link.UsageGranularity("year"))
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+
+	s.T().Run("deleted links are not counted", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(2))
+		require.NoError(t, s.workitemLinkRepo.Delete(s.Ctx, fxt.WorkItemLinks[0].ID, fxt.Identities[0].ID))
+		now := time.Now()
+		// when
+		buckets, err := s.workitemLinkRepo.UsageOverTime(s.Ctx, fxt.WorkItemLinkTypes[0].ID, now.Add(-time.Hour), now, link.UsageGranularityDay)
+		// then
+		require.NoError(t, err)
+		total := 0
+		for _, b := range buckets {
+			total += b.Count
+		}
+		require.Equal(t, 1, total)
+	})
+
+	s.T().Run("too many buckets", func(t *testing.T) {
+		now := time.Now()
+		from := now.AddDate(-10, 0, 0)
+		_, err := s.workitemLinkRepo.UsageOverTime(s.Ctx, uuid.NewV4(), from, now, link.UsageGranularityDay)
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		// the same range is fine with a coarser granularity
+		_, err = s.workitemLinkRepo.UsageOverTime(s.Ctx, uuid.NewV4(), from, now, link.UsageGranularityMonth)
+		require.NoError(t, err)
+	})
+}
+
 func (s *linkRepoBlackBoxTest) TestGetAncestors() {
 	validateAncestry := func(t *testing.T, fxt *tf.TestFixture, toBeFound map[link.Ancestor]struct{}, ancestors []link.Ancestor) {
 		// uncomment for more information:
@@ -28,6 +28,30 @@ const (
 	TopologyTree            Topology = "tree"
 )
 
+// ValidTopologies holds all topologies that a work item link type can have
+var ValidTopologies = []Topology{TopologyNetwork, TopologyDirectedNetwork, TopologyDependency, TopologyTree}
+
+// topologyDescriptions holds a short human readable description for each of
+// the ValidTopologies
+var topologyDescriptions = map[Topology]string{
+	TopologyNetwork:         "Links have no direction and any work item can be linked to any other work item.",
+	TopologyDirectedNetwork: "Links point from a source to a target and any work item can be linked to any other work item.",
+	TopologyDependency:      "Links point from a source to a target and must not form a cycle.",
+	TopologyTree:            "Links point from a parent to a child, every work item has at most one parent and links must not form a cycle.",
+}
+
+// Description returns a short human readable description of the topology or
+// an empty string if the topology is not valid.
+func (t Topology) Description() string {
+	return topologyDescriptions[t]
+}
+
+// IsDirected returns true if links of this topology have a meaningful
+// direction from source to target.
+func (t Topology) IsDirected() bool {
+	return t != TopologyNetwork
+}
+
 // CheckValid returns nil if the given topology is valid; otherwise a
 // BadParameterError is returned.
 func (t Topology) CheckValid() error {
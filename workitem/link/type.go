@@ -1,6 +1,8 @@
 package link
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"time"
 
 	convert "github.com/fabric8-services/fabric8-wit/convert"
@@ -50,6 +52,9 @@ type WorkItemLinkType struct {
 	Name string
 	// Description is an optional description of the work item link type
 	Description *string
+	// DescriptionMarkup is the markup language of the description, one of
+	// rendering.SystemMarkupPlainText and rendering.SystemMarkupMarkdown
+	DescriptionMarkup string
 	// Version for optimistic concurrency control
 	Version  int
 	Topology Topology
@@ -61,6 +66,73 @@ type WorkItemLinkType struct {
 
 	// Reference to one Space
 	SpaceID uuid.UUID `sql:"type:uuid"`
+
+	// CreatedBy is the identity that created this link type. It is nil for
+	// link types that were created by the system.
+	CreatedBy *uuid.UUID `sql:"type:uuid"`
+
+	// SelfReferenceAllowed determines if a work item can be linked to itself
+	// with this link type.
+	SelfReferenceAllowed bool
+
+	// SourceWorkItemTypeIDs and TargetWorkItemTypeIDs restrict the types of
+	// the work items that links of this type can start and end at. Empty
+	// lists allow work items of any type.
+	SourceWorkItemTypeIDs WorkItemTypeIDs `gorm:"column:source_work_item_type_ids" sql:"type:jsonb"`
+	TargetWorkItemTypeIDs WorkItemTypeIDs `gorm:"column:target_work_item_type_ids" sql:"type:jsonb"`
+}
+
+// WorkItemTypeIDs is a list of work item type IDs that is stored as a JSON
+// array.
+type WorkItemTypeIDs []uuid.UUID
+
+// Allows returns true if the list is empty or contains the given work item
+// type.
+func (ids WorkItemTypeIDs) Allows(witID uuid.UUID) bool {
+	if len(ids) == 0 {
+		return true
+	}
+	for _, id := range ids {
+		if uuid.Equal(id, witID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal returns true if both lists contain the same IDs in the same order.
+// A nil list equals an empty one.
+func (ids WorkItemTypeIDs) Equal(other WorkItemTypeIDs) bool {
+	if len(ids) != len(other) {
+		return false
+	}
+	for i := range ids {
+		if !uuid.Equal(ids[i], other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Value implements the driver.Valuer interface
+func (ids WorkItemTypeIDs) Value() (driver.Value, error) {
+	if ids == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(ids)
+}
+
+// Scan implements the sql.Scanner interface
+func (ids *WorkItemTypeIDs) Scan(src interface{}) error {
+	if src == nil {
+		*ids = nil
+		return nil
+	}
+	b, ok := src.([]byte)
+	if !ok {
+		return errs.Errorf("failed to scan work item type IDs from %T", src)
+	}
+	return json.Unmarshal(b, ids)
 }
 
 // Ensure Fields implements the Equaler interface
@@ -88,6 +160,9 @@ func (t WorkItemLinkType) Equal(u convert.Equaler) bool {
 	if !strPtrIsNilOrContentIsEqual(t.Description, other.Description) {
 		return false
 	}
+	if t.DescriptionMarkup != other.DescriptionMarkup {
+		return false
+	}
 	if t.Topology != other.Topology {
 		return false
 	}
@@ -103,29 +178,74 @@ func (t WorkItemLinkType) Equal(u convert.Equaler) bool {
 	if !uuid.Equal(t.SpaceID, other.SpaceID) {
 		return false
 	}
+	if (t.CreatedBy == nil) != (other.CreatedBy == nil) {
+		return false
+	}
+	if t.CreatedBy != nil && !uuid.Equal(*t.CreatedBy, *other.CreatedBy) {
+		return false
+	}
+	if t.SelfReferenceAllowed != other.SelfReferenceAllowed {
+		return false
+	}
+	if !t.SourceWorkItemTypeIDs.Equal(other.SourceWorkItemTypeIDs) {
+		return false
+	}
+	if !t.TargetWorkItemTypeIDs.Equal(other.TargetWorkItemTypeIDs) {
+		return false
+	}
 	return true
 }
 
+// Placeholders used in display templates of work item link types
+const (
+	DisplayTemplateSource = "{source}"
+	DisplayTemplateTarget = "{target}"
+)
+
+// DisplayTemplate returns a template that clients can use to phrase a link of
+// this type, e.g. "{source} blocks {target}" or "{source} has subtask
+// {target}". The DisplayTemplateSource and DisplayTemplateTarget placeholders
+// are to be replaced with the source and target work items. A link always
+// points from its source to its target, so it is phrased with the forward
+// name whatever the topology of the link type is; even a tree link from the
+// parent to the child is best described by the name the space chose for it.
+func (t WorkItemLinkType) DisplayTemplate() string {
+	return DisplayTemplateSource + " " + t.ForwardName + " " + DisplayTemplateTarget
+}
+
+// NameForDirection returns the name of a link of this type with the given
+// source as seen from the given work item: the forward name if the work item
+// is the source of the link (e.g. "blocks") and the reverse name otherwise
+// (e.g. "blocked by"). Links from a work item to itself use the forward name.
+func (t WorkItemLinkType) NameForDirection(sourceID, workItemID uuid.UUID) string {
+	if uuid.Equal(sourceID, workItemID) {
+		return t.ForwardName
+	}
+	return t.ReverseName
+}
+
 // CheckValidForCreation returns an error if the work item link type
-// cannot be used for the creation of a new work item link type.
+// cannot be used for the creation of a new work item link type. The
+// parameters of the returned errors refer to the fields of the JSONAPI
+// payload, so that clients can map them to their input.
 func (t *WorkItemLinkType) CheckValidForCreation() error {
 	if t.Name == "" {
-		return errors.NewBadParameterError("name", t.Name)
+		return errors.NewBadParameterError("data.attributes.name", t.Name)
 	}
 	if t.ForwardName == "" {
-		return errors.NewBadParameterError("forward_name", t.ForwardName)
+		return errors.NewBadParameterError("data.attributes.forward_name", t.ForwardName)
 	}
 	if t.ReverseName == "" {
-		return errors.NewBadParameterError("reverse_name", t.ReverseName)
+		return errors.NewBadParameterError("data.attributes.reverse_name", t.ReverseName)
 	}
 	if err := t.Topology.CheckValid(); err != nil {
-		return errs.WithStack(err)
+		return errors.NewBadParameterError("data.attributes.topology", t.Topology).Expected(TopologyNetwork + "|" + TopologyDirectedNetwork + "|" + TopologyDependency + "|" + TopologyTree)
 	}
 	if t.LinkCategoryID == uuid.Nil {
-		return errors.NewBadParameterError("link_category_id", t.LinkCategoryID)
+		return errors.NewBadParameterError("data.relationships.link_category.data.id", t.LinkCategoryID)
 	}
 	if t.SpaceID == uuid.Nil {
-		return errors.NewBadParameterError("space_id", t.SpaceID)
+		return errors.NewBadParameterError("data.relationships.space.data.id", t.SpaceID)
 	}
 	return nil
 }
@@ -144,3 +264,28 @@ func (t WorkItemLinkType) GetETagData() []interface{} {
 func (t WorkItemLinkType) GetLastModified() time.Time {
 	return t.UpdatedAt
 }
+
+// TypesRevision is the revision of the work item link types of a space. It
+// is bumped whenever a link type of the space is created, updated, deleted or
+// restored, so that it changes the ETag of a list of link types even if the
+// changed link type is not part of the list anymore.
+type TypesRevision struct {
+	SpaceID   uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	Revision  int
+	UpdatedAt time.Time
+}
+
+// TableName implements gorm.tabler
+func (r TypesRevision) TableName() string {
+	return "work_item_link_type_revisions"
+}
+
+// GetETagData returns the field values to use to generate the ETag
+func (r TypesRevision) GetETagData() []interface{} {
+	return []interface{}{r.SpaceID, r.Revision}
+}
+
+// GetLastModified returns the last modification time
+func (r TypesRevision) GetLastModified() time.Time {
+	return r.UpdatedAt
+}
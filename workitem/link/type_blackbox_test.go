@@ -89,6 +89,32 @@ func TestWorkItemLinkType_Equal(t *testing.T) {
 	b = a
 	b.SpaceID = uuid.FromStringOrNil("aaa71e36-871b-43a6-9166-0v5ce684dBBB")
 	require.False(t, a.Equal(b))
+
+	// Test SelfReferenceAllowed
+	b = a
+	b.SelfReferenceAllowed = !a.SelfReferenceAllowed
+	require.False(t, a.Equal(b))
+
+	// Test SourceWorkItemTypeIDs
+	b = a
+	b.SourceWorkItemTypeIDs = link.WorkItemTypeIDs{uuid.NewV4()}
+	require.False(t, a.Equal(b))
+
+	// Test TargetWorkItemTypeIDs
+	b = a
+	b.TargetWorkItemTypeIDs = link.WorkItemTypeIDs{uuid.NewV4()}
+	require.False(t, a.Equal(b))
+}
+
+func TestWorkItemTypeIDs_Allows(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	bug := uuid.NewV4()
+	task := uuid.NewV4()
+	require.True(t, link.WorkItemTypeIDs(nil).Allows(bug))
+	require.True(t, link.WorkItemTypeIDs{}.Allows(bug))
+	require.True(t, link.WorkItemTypeIDs{task, bug}.Allows(bug))
+	require.False(t, link.WorkItemTypeIDs{task}.Allows(bug))
 }
 
 func TestWorkItemLinkTypeCheckValidForCreation(t *testing.T) {
@@ -142,3 +168,56 @@ func TestWorkItemLinkTypeCheckValidForCreation(t *testing.T) {
 	b.SpaceID = uuid.Nil
 	require.NotNil(t, b.CheckValidForCreation())
 }
+
+func TestWorkItemLinkType_DisplayTemplate(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	testCases := []struct {
+		topology link.Topology
+		forward  string
+		reverse  string
+		expected string
+	}{
+		{link.TopologyNetwork, "relates to", "relates to", "{source} relates to {target}"},
+		{link.TopologyDirectedNetwork, "blocks", "blocked by", "{source} blocks {target}"},
+		{link.TopologyDependency, "depends on", "dependency of", "{source} depends on {target}"},
+		{link.TopologyTree, "parent of", "child of", "{source} parent of {target}"},
+		{link.TopologyTree, "has subtask", "subtask of", "{source} has subtask {target}"},
+	}
+	// every topology is covered
+	for _, topology := range link.ValidTopologies {
+		covered := false
+		for _, tc := range testCases {
+			covered = covered || tc.topology == topology
+		}
+		require.True(t, covered, "no test case for topology %s", topology)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.topology.String()+" "+tc.forward, func(t *testing.T) {
+			linkType := link.WorkItemLinkType{
+				Topology:    tc.topology,
+				ForwardName: tc.forward,
+				ReverseName: tc.reverse,
+			}
+			require.Equal(t, tc.expected, linkType.DisplayTemplate())
+		})
+	}
+}
+
+func TestWorkItemLinkType_NameForDirection(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	linkType := link.WorkItemLinkType{
+		ForwardName: "blocks",
+		ReverseName: "blocked by",
+	}
+	a, b := uuid.NewV4(), uuid.NewV4()
+	t.Run("source", func(t *testing.T) {
+		require.Equal(t, "blocks", linkType.NameForDirection(a, a))
+	})
+	t.Run("target", func(t *testing.T) {
+		require.Equal(t, "blocked by", linkType.NameForDirection(a, b))
+	})
+}
@@ -2,6 +2,7 @@ package link
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"context"
@@ -10,7 +11,9 @@ import (
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/rendering"
 	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/workitem"
 
 	"github.com/goadesign/goa"
 	"github.com/jinzhu/gorm"
@@ -23,11 +26,39 @@ type WorkItemLinkTypeRepository interface {
 	repository.Exister
 	Create(ctx context.Context, linkType *WorkItemLinkType) (*WorkItemLinkType, error)
 	Load(ctx context.Context, ID uuid.UUID) (*WorkItemLinkType, error)
-	List(ctx context.Context, spaceID uuid.UUID) ([]WorkItemLinkType, error)
+	List(ctx context.Context, spaceID uuid.UUID, createdBy *uuid.UUID, topology *Topology, linkCategoryID *uuid.UUID, includeDeleted bool, sort *string, start *int, limit *int) ([]WorkItemLinkType, int, error)
+	// Count returns the number of link types that List returns for the
+	// given space without any filters.
+	Count(ctx context.Context, spaceID uuid.UUID) (int, error)
+	// Revisions returns the revisions of the link types that List returns
+	// for the given space.
+	Revisions(ctx context.Context, spaceID uuid.UUID) ([]TypesRevision, error)
+	ListByCategory(ctx context.Context, categoryID uuid.UUID) ([]WorkItemLinkType, error)
+	FindByForwardName(ctx context.Context, name string, start *int, limit *int) ([]WorkItemLinkType, int, error)
 	Delete(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) error
+	// DeleteAll deletes all link types of the given space and returns the
+	// number of deleted link types. The link types of the system space
+	// cannot be deleted this way.
+	DeleteAll(ctx context.Context, spaceID uuid.UUID) (int, error)
+	Restore(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) (*WorkItemLinkType, error)
 	Save(ctx context.Context, linkCat WorkItemLinkType) (*WorkItemLinkType, error)
+	CreateDefaultsForSpace(ctx context.Context, spaceID uuid.UUID, defaults []WorkItemLinkType) ([]WorkItemLinkType, error)
+	CheckUniqueForwardAndReverseName(ctx context.Context, linkType WorkItemLinkType) error
+	// Suggest returns the link types that can be used to link the given
+	// source and target work items, most suitable first.
+	Suggest(ctx context.Context, sourceID, targetID uuid.UUID) ([]WorkItemLinkType, error)
 }
 
+// Sort keys supported when listing work item link types
+const (
+	SortByUsageCount     = "usage_count"
+	SortByUsageCountDesc = "-usage_count"
+	SortByName           = "name"
+	SortByNameDesc       = "-name"
+	SortByCreatedAt      = "created-at"
+	SortByCreatedAtDesc  = "-created-at"
+)
+
 // NewWorkItemLinkTypeRepository creates a work item link type repository based on gorm
 func NewWorkItemLinkTypeRepository(db *gorm.DB) *GormWorkItemLinkTypeRepository {
 	return &GormWorkItemLinkTypeRepository{db}
@@ -39,7 +70,7 @@ type GormWorkItemLinkTypeRepository struct {
 }
 
 // Create creates a new work item link type in the repository.
-// Returns BadParameterError, ConversionError or InternalError
+// Returns BadParameterError, ConversionError, DataConflictError or InternalError
 func (r *GormWorkItemLinkTypeRepository) Create(ctx context.Context, linkType *WorkItemLinkType) (*WorkItemLinkType, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "workitemlinktype", "create"}, time.Now())
 	if err := linkType.CheckValidForCreation(); err != nil {
@@ -63,6 +94,24 @@ func (r *GormWorkItemLinkTypeRepository) Create(ctx context.Context, linkType *W
 	if db.Error != nil {
 		return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to find work item link space"))
 	}
+	if err := r.CheckUniqueForwardAndReverseName(ctx, *linkType); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	if linkType.DescriptionMarkup == "" {
+		linkType.DescriptionMarkup = rendering.SystemMarkupDefault
+	}
+	// A predetermined ID must not be used by any other link type, not even a
+	// deleted one. Otherwise a new ID is generated.
+	if linkType.ID != uuid.Nil {
+		var count int
+		db = r.db.Unscoped().Model(&WorkItemLinkType{}).Where("id = ?", linkType.ID).Count(&count)
+		if db.Error != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to check the ID of the work item link type"))
+		}
+		if count > 0 {
+			return nil, errors.NewDataConflictError(fmt.Sprintf("work item link type with ID %s already exists", linkType.ID))
+		}
+	}
 
 	db = r.db.Create(linkType)
 	if db.Error != nil {
@@ -77,9 +126,181 @@ func (r *GormWorkItemLinkTypeRepository) Create(ctx context.Context, linkType *W
 
 		return nil, errors.NewInternalError(ctx, db.Error)
 	}
+	if err := r.bumpRevision(ctx, linkType.SpaceID); err != nil {
+		return nil, errs.WithStack(err)
+	}
 	return linkType, nil
 }
 
+// bumpRevision increments the revision of the link types of the given space.
+func (r *GormWorkItemLinkTypeRepository) bumpRevision(ctx context.Context, spaceID uuid.UUID) error {
+	db := r.db.Exec(fmt.Sprintf(`INSERT INTO %[1]s (space_id, revision, updated_at) VALUES (?, 1, now())
+		ON CONFLICT (space_id) DO UPDATE SET revision = %[1]s.revision + 1, updated_at = now()`, TypesRevision{}.TableName()), spaceID)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"space_id": spaceID,
+			"err":      db.Error,
+		}, "unable to bump the revision of the work item link types of space")
+		return errors.NewInternalError(ctx, db.Error)
+	}
+	return nil
+}
+
+// Revisions returns the revisions of the work item link types of the given
+// space and of the system space. Spaces whose link types never changed have
+// no revision.
+func (r *GormWorkItemLinkTypeRepository) Revisions(ctx context.Context, spaceID uuid.UUID) ([]TypesRevision, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlinktype", "revisions"}, time.Now())
+	// TODO(kwk): Remove the system space from the query, once we have space templates
+	revisions := []TypesRevision{}
+	db := r.db.Where("space_id IN (?, ?)", spaceID, space.SystemSpace).Order("space_id").Find(&revisions)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"space_id": spaceID,
+			"err":      db.Error,
+		}, "unable to load the revisions of the work item link types of space")
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	return revisions, nil
+}
+
+// CheckUniqueForwardAndReverseName returns a DataConflictError if another
+// work item link type in the same space already uses the forward name or the
+// reverse name of the given link type. Otherwise users can't tell the link
+// types apart when picking one by its forward or reverse name.
+func (r *GormWorkItemLinkTypeRepository) CheckUniqueForwardAndReverseName(ctx context.Context, linkType WorkItemLinkType) error {
+	for _, c := range []struct{ column, name string }{
+		{"forward_name", linkType.ForwardName},
+		{"reverse_name", linkType.ReverseName},
+	} {
+		column, name := c.column, c.name
+		var count int64
+		db := r.db.Model(&WorkItemLinkType{}).
+			Where("space_id = ? AND "+column+" = ? AND id != ?", linkType.SpaceID, name, linkType.ID).
+			Count(&count)
+		if db.Error != nil {
+			log.Error(ctx, map[string]interface{}{
+				"space_id": linkType.SpaceID,
+				column:     name,
+				"err":      db.Error,
+			}, "unable to check uniqueness of work item link type %s", column)
+			return errors.NewInternalError(ctx, db.Error)
+		}
+		if count > 0 {
+			return errors.NewDataConflictError(fmt.Sprintf("work item link type already exists in space %s with the same %s %q", linkType.SpaceID, column, name))
+		}
+	}
+	return nil
+}
+
+// suggestRecentUsagePeriod is the period in which links count as recent usage
+// of a link type when suggesting link types.
+const suggestRecentUsagePeriod = 30 * 24 * time.Hour
+
+// Suggest returns the link types of the source work item's space and the
+// global link types that can be used to link the given source and target work
+// items. The link types are ranked by these heuristics:
+//
+//  1. how often the link type was used in the space between work items of the
+//     same types as the given source and target,
+//  2. how often the link type was used in the space recently,
+//  3. the name of the link type.
+//
+// Link types with a tree topology are left out if the target already has a
+// parent of that link type, because the link could not be created anyway.
+func (r *GormWorkItemLinkTypeRepository) Suggest(ctx context.Context, sourceID, targetID uuid.UUID) ([]WorkItemLinkType, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlinktype", "suggest"}, time.Now())
+	var items []workitem.WorkItemStorage
+	db := r.db.Where("id IN (?)", []uuid.UUID{sourceID, targetID}).Find(&items)
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to load source and target work items"))
+	}
+	var source, target *workitem.WorkItemStorage
+	for i := range items {
+		if items[i].ID == sourceID {
+			source = &items[i]
+		}
+		if items[i].ID == targetID {
+			target = &items[i]
+		}
+	}
+	if source == nil {
+		return nil, errors.NewNotFoundError("source work item", sourceID.String())
+	}
+	if target == nil {
+		return nil, errors.NewNotFoundError("target work item", targetID.String())
+	}
+
+	var types []WorkItemLinkType
+	db = r.db.Where("space_id IN (?)", []uuid.UUID{source.SpaceID, space.SystemSpace}).Find(&types)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"space_id": source.SpaceID,
+			"err":      db.Error,
+		}, "unable to list work item link types to suggest")
+		return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to list work item link types to suggest"))
+	}
+
+	linkTable := WorkItemLink{}.TableName()
+	wiTable := workitem.WorkItemStorage{}.TableName()
+	type usage struct {
+		LinkTypeID uuid.UUID
+		Count      int
+	}
+	countUsage := func(where string, args ...interface{}) (map[uuid.UUID]int, error) {
+		var rows []usage
+		db := r.db.Table(linkTable).
+			Select(fmt.Sprintf("%s.link_type_id, count(*) as count", linkTable)).
+			Joins(fmt.Sprintf("JOIN %[1]s src ON src.id = %[2]s.source_id JOIN %[1]s tgt ON tgt.id = %[2]s.target_id", wiTable, linkTable)).
+			Where(fmt.Sprintf("%s.deleted_at IS NULL AND src.space_id = ?", linkTable), source.SpaceID).
+			Where(where, args...).
+			Group(fmt.Sprintf("%s.link_type_id", linkTable)).
+			Scan(&rows)
+		if db.Error != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to count work item link type usage"))
+		}
+		res := make(map[uuid.UUID]int, len(rows))
+		for _, row := range rows {
+			res[row.LinkTypeID] = row.Count
+		}
+		return res, nil
+	}
+	pairUsage, err := countUsage("src.type = ? AND tgt.type = ?", source.Type, target.Type)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	recentUsage, err := countUsage(fmt.Sprintf("%s.created_at > ?", linkTable), time.Now().Add(-suggestRecentUsagePeriod))
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	res := make([]WorkItemLinkType, 0, len(types))
+	for _, t := range types {
+		if t.Topology == TopologyTree {
+			var parents int
+			db := r.db.Model(&WorkItemLink{}).Where("link_type_id = ? AND target_id = ?", t.ID, targetID).Count(&parents)
+			if db.Error != nil {
+				return nil, errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to check if target %s has a parent", targetID))
+			}
+			if parents > 0 {
+				continue
+			}
+		}
+		res = append(res, t)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		a, b := res[i], res[j]
+		if pairUsage[a.ID] != pairUsage[b.ID] {
+			return pairUsage[a.ID] > pairUsage[b.ID]
+		}
+		if recentUsage[a.ID] != recentUsage[b.ID] {
+			return recentUsage[a.ID] > recentUsage[b.ID]
+		}
+		return a.Name < b.Name
+	})
+	return res, nil
+}
+
 // Load returns the work item link type for the given ID.
 // Returns NotFoundError, ConversionError or InternalError
 func (r *GormWorkItemLinkTypeRepository) Load(ctx context.Context, ID uuid.UUID) (*WorkItemLinkType, error) {
@@ -107,24 +328,230 @@ func (r *GormWorkItemLinkTypeRepository) CheckExists(ctx context.Context, id uui
 	return repository.CheckExists(ctx, r.db, WorkItemLinkType{}.TableName(), id)
 }
 
-// List returns all work item link types
-// TODO: Handle pagination
-func (r *GormWorkItemLinkTypeRepository) List(ctx context.Context, spaceID uuid.UUID) ([]WorkItemLinkType, error) {
+// List returns all work item link types. If createdBy is not nil, only the
+// link types created by the given identity are returned. If topology is not
+// nil, only the link types of that topology are returned. If linkCategoryID
+// is not nil, only the link types of that link category are returned.
+// Deleted link types are only returned if includeDeleted is true. The
+// start and limit parameters page the result and the returned count is the
+// total number of link types matching the filters regardless of paging.
+func (r *GormWorkItemLinkTypeRepository) List(ctx context.Context, spaceID uuid.UUID, createdBy *uuid.UUID, topology *Topology, linkCategoryID *uuid.UUID, includeDeleted bool, sort *string, start *int, limit *int) ([]WorkItemLinkType, int, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "workitemlinktype", "list"}, time.Now())
 	log.Info(ctx, map[string]interface{}{
-		"space_id": spaceID,
+		"space_id":        spaceID,
+		"created_by":      createdBy,
+		"topology":        topology,
+		"wilc_id":         linkCategoryID,
+		"include_deleted": includeDeleted,
+		"sort":            sort,
+		"start":           start,
+		"limit":           limit,
 	}, "Listing work item link types by space ID %s", spaceID.String())
 
-	// We don't have any where clause or paging at the moment.
 	var modelLinkTypes []WorkItemLinkType
 	// TODO(kwk): Remove the system space from the query, once we have space templates
-	db := r.db.Where("space_id IN (?, ?)", spaceID, space.SystemSpace)
+	db := r.db.Model(&WorkItemLinkType{}).Where(fmt.Sprintf("%s.space_id IN (?, ?)", WorkItemLinkType{}.TableName()), spaceID, space.SystemSpace)
+	if includeDeleted {
+		db = db.Unscoped()
+	}
+	if createdBy != nil {
+		db = db.Where(fmt.Sprintf("%s.created_by = ?", WorkItemLinkType{}.TableName()), *createdBy)
+	}
+	if topology != nil {
+		if err := topology.CheckValid(); err != nil {
+			return nil, 0, errs.WithStack(err)
+		}
+		db = db.Where(fmt.Sprintf("%s.topology = ?", WorkItemLinkType{}.TableName()), *topology)
+	}
+	if linkCategoryID != nil {
+		db = db.Where(fmt.Sprintf("%s.link_category_id = ?", WorkItemLinkType{}.TableName()), *linkCategoryID)
+	}
+	var count int
+	if err := db.Count(&count).Error; err != nil {
+		return nil, 0, errors.NewInternalError(ctx, err)
+	}
+	if start != nil {
+		if *start < 0 {
+			return nil, 0, errors.NewBadParameterError("start", *start)
+		}
+		db = db.Offset(*start)
+	}
+	if limit != nil {
+		if *limit <= 0 {
+			return nil, 0, errors.NewBadParameterError("limit", *limit)
+		}
+		db = db.Limit(*limit)
+	}
+	if sort != nil {
+		switch *sort {
+		case SortByUsageCount, SortByUsageCountDesc:
+			// count the links in the database so that we don't have to load
+			// all link types into memory in order to sort them
+			direction := "ASC"
+			if *sort == SortByUsageCountDesc {
+				direction = "DESC"
+			}
+			db = db.Select(fmt.Sprintf("%[1]s.*", WorkItemLinkType{}.TableName())).
+				Joins(fmt.Sprintf(`LEFT JOIN (
+					SELECT link_type_id, count(*) AS usage_count
+					FROM %[1]s
+					WHERE deleted_at IS NULL
+					GROUP BY link_type_id
+				) link_usage ON link_usage.link_type_id = %[2]s.id`, WorkItemLink{}.TableName(), WorkItemLinkType{}.TableName())).
+				Order(fmt.Sprintf("COALESCE(link_usage.usage_count, 0) %[1]s, %[2]s.name", direction, WorkItemLinkType{}.TableName()))
+		case SortByName:
+			db = db.Order(fmt.Sprintf("%s.name ASC", WorkItemLinkType{}.TableName()))
+		case SortByNameDesc:
+			db = db.Order(fmt.Sprintf("%s.name DESC", WorkItemLinkType{}.TableName()))
+		case SortByCreatedAt:
+			// same as the default order below
+		case SortByCreatedAtDesc:
+			db = db.Order(fmt.Sprintf("%s.created_at DESC", WorkItemLinkType{}.TableName()))
+		default:
+			return nil, 0, errors.NewBadParameterError("sort", *sort).Expected(SortByUsageCount + "|" + SortByUsageCountDesc + "|" + SortByName + "|" + SortByNameDesc + "|" + SortByCreatedAt + "|" + SortByCreatedAtDesc)
+		}
+	}
+	// ensure that pages are always cut from the same order
+	db = db.Order(fmt.Sprintf("%[1]s.created_at, %[1]s.id", WorkItemLinkType{}.TableName()))
 	if err := db.Find(&modelLinkTypes).Error; err != nil {
-		return nil, errs.WithStack(err)
+		return nil, 0, errs.WithStack(err)
+	}
+	return modelLinkTypes, count, nil
+}
+
+// Count returns the number of work item link types available in the given
+// space, which includes the link types of the system space. Deleted link
+// types are not counted.
+func (r *GormWorkItemLinkTypeRepository) Count(ctx context.Context, spaceID uuid.UUID) (int, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlinktype", "count"}, time.Now())
+	// TODO(kwk): Remove the system space from the query, once we have space templates
+	var count int
+	db := r.db.Model(&WorkItemLinkType{}).Where("space_id IN (?, ?)", spaceID, space.SystemSpace).Count(&count)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"space_id": spaceID,
+			"err":      db.Error,
+		}, "unable to count work item link types")
+		return 0, errors.NewInternalError(ctx, db.Error)
+	}
+	return count, nil
+}
+
+// ListByCategory returns all work item link types of all spaces that belong to
+// the given link category.
+func (r *GormWorkItemLinkTypeRepository) ListByCategory(ctx context.Context, categoryID uuid.UUID) ([]WorkItemLinkType, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlinktype", "listbycategory"}, time.Now())
+	log.Info(ctx, map[string]interface{}{
+		"wilc_id": categoryID,
+	}, "Listing work item link types by category ID %s", categoryID.String())
+	var modelLinkTypes []WorkItemLinkType
+	db := r.db.Where("link_category_id = ?", categoryID).Find(&modelLinkTypes)
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, db.Error)
 	}
 	return modelLinkTypes, nil
 }
 
+// FindByForwardName returns the work item link types of all spaces whose
+// forward name equals the given name. Every link type carries the ID of the
+// space it belongs to. The start and limit parameters page the result and the
+// returned count is the total number of matching link types regardless of
+// paging.
+//
+// NOTE: This crosses space boundaries and is meant for administrative tooling
+// only, so callers must make sure that only admins can reach it.
+func (r *GormWorkItemLinkTypeRepository) FindByForwardName(ctx context.Context, name string, start *int, limit *int) ([]WorkItemLinkType, int, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlinktype", "findbyforwardname"}, time.Now())
+	log.Info(ctx, map[string]interface{}{
+		"forward_name": name,
+		"start":        start,
+		"limit":        limit,
+	}, "Finding work item link types by forward name %s", name)
+	if name == "" {
+		return nil, 0, errors.NewBadParameterError("name", name).Expected("not empty")
+	}
+	db := r.db.Model(&WorkItemLinkType{}).Where("forward_name = ?", name)
+	var count int
+	if err := db.Count(&count).Error; err != nil {
+		return nil, 0, errors.NewInternalError(ctx, err)
+	}
+	if start != nil {
+		if *start < 0 {
+			return nil, 0, errors.NewBadParameterError("start", *start)
+		}
+		db = db.Offset(*start)
+	}
+	if limit != nil {
+		if *limit <= 0 {
+			return nil, 0, errors.NewBadParameterError("limit", *limit)
+		}
+		db = db.Limit(*limit)
+	}
+	var modelLinkTypes []WorkItemLinkType
+	if err := db.Order("space_id, created_at, id").Find(&modelLinkTypes).Error; err != nil {
+		return nil, 0, errors.NewInternalError(ctx, err)
+	}
+	return modelLinkTypes, count, nil
+}
+
+// CreateDefaultsForSpace creates those of the given default link types that
+// don't yet exist in the space with the given ID. Existing link types are
+// matched by name, so it is safe to call this function again after new
+// defaults have been added to a template. Link types whose forward or reverse
+// name is already taken in the space (or by an earlier link type of the given
+// defaults) are skipped as well because they could not be created. Only the
+// link types that were actually created are returned.
+func (r *GormWorkItemLinkTypeRepository) CreateDefaultsForSpace(ctx context.Context, spaceID uuid.UUID, defaults []WorkItemLinkType) ([]WorkItemLinkType, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlinktype", "createdefaults"}, time.Now())
+	var existing []WorkItemLinkType
+	db := r.db.Where("space_id = ?", spaceID).Find(&existing)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"space_id": spaceID,
+			"err":      db.Error,
+		}, "unable to list existing work item link types of space")
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	existingNames := make(map[string]struct{}, len(existing))
+	existingForwardNames := make(map[string]struct{}, len(existing))
+	existingReverseNames := make(map[string]struct{}, len(existing))
+	for _, t := range existing {
+		existingNames[t.Name] = struct{}{}
+		existingForwardNames[t.ForwardName] = struct{}{}
+		existingReverseNames[t.ReverseName] = struct{}{}
+	}
+	added := []WorkItemLinkType{}
+	for _, d := range defaults {
+		if _, ok := existingNames[d.Name]; ok {
+			continue
+		}
+		if _, ok := existingForwardNames[d.ForwardName]; ok {
+			continue
+		}
+		if _, ok := existingReverseNames[d.ReverseName]; ok {
+			continue
+		}
+		linkType := d
+		linkType.ID = uuid.NewV4()
+		linkType.SpaceID = spaceID
+		linkType.Version = 0
+		linkType.Lifecycle = gormsupport.Lifecycle{}
+		created, err := r.Create(ctx, &linkType)
+		if err != nil {
+			return nil, errs.Wrapf(err, "failed to create default work item link type %s in space %s", d.Name, spaceID)
+		}
+		existingNames[created.Name] = struct{}{}
+		existingForwardNames[created.ForwardName] = struct{}{}
+		existingReverseNames[created.ReverseName] = struct{}{}
+		added = append(added, *created)
+	}
+	log.Info(ctx, map[string]interface{}{
+		"space_id": spaceID,
+		"added":    len(added),
+	}, "created missing default work item link types for space")
+	return added, nil
+}
+
 // Delete deletes the work item link type with the given id
 // returns NotFoundError or InternalError
 func (r *GormWorkItemLinkTypeRepository) Delete(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) error {
@@ -145,7 +572,88 @@ func (r *GormWorkItemLinkTypeRepository) Delete(ctx context.Context, spaceID uui
 	if db.RowsAffected == 0 {
 		return errors.NewNotFoundError("work item link type", ID.String())
 	}
-	return nil
+	return r.bumpRevision(ctx, spaceID)
+}
+
+// DeleteAll deletes all work item link types of the given space, e.g. when the
+// space is torn down. Links of those types are left alone.
+// returns BadParameterError or InternalError
+func (r *GormWorkItemLinkTypeRepository) DeleteAll(ctx context.Context, spaceID uuid.UUID) (int, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlinktype", "deleteAll"}, time.Now())
+	if uuid.Equal(spaceID, space.SystemSpace) {
+		return 0, errors.NewBadParameterError("spaceID", spaceID).Expected("not the system space")
+	}
+	db := r.db.Where("space_id = ?", spaceID).Delete(&WorkItemLinkType{})
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"space_id": spaceID,
+			"err":      db.Error,
+		}, "unable to delete the work item link types of the space")
+		return 0, errors.NewInternalError(ctx, db.Error)
+	}
+	if db.RowsAffected == 0 {
+		return 0, nil
+	}
+	if err := r.bumpRevision(ctx, spaceID); err != nil {
+		return 0, err
+	}
+	return int(db.RowsAffected), nil
+}
+
+// Restore restores the deleted work item link type with the given ID in the
+// given space. Links of that type that were deleted are not restored.
+// returns NotFoundError or InternalError
+func (r *GormWorkItemLinkTypeRepository) Restore(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) (*WorkItemLinkType, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlinktype", "restore"}, time.Now())
+	log.Info(ctx, map[string]interface{}{
+		"wilt_id":  ID,
+		"space_id": spaceID,
+	}, "Work item link type to restore")
+	deleted := WorkItemLinkType{}
+	db := r.db.Unscoped().Where("id = ? AND space_id = ? AND deleted_at IS NOT NULL", ID, spaceID).First(&deleted)
+	if db.RecordNotFound() {
+		return nil, errors.NewNotFoundError("deleted work item link type", ID.String())
+	}
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wilt_id":  ID,
+			"space_id": spaceID,
+			"err":      db.Error,
+		}, "unable to find deleted work item link type")
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	// The names of the deleted link type may have been reused in the meantime
+	if err := r.CheckUniqueForwardAndReverseName(ctx, deleted); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	var count int64
+	db = r.db.Model(&WorkItemLinkType{}).
+		Where("name = ? AND space_id = ? AND link_category_id = ? AND id != ?", deleted.Name, spaceID, deleted.LinkCategoryID, ID).
+		Count(&count)
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to check the name of the work item link type"))
+	}
+	if count > 0 {
+		return nil, errors.NewDataConflictError(fmt.Sprintf("work item link type already exists in space %s with the same link_category_id: %s; name: %s", spaceID, deleted.LinkCategoryID, deleted.Name))
+	}
+	db = r.db.Unscoped().Model(&WorkItemLinkType{}).
+		Where("id = ? AND space_id = ? AND deleted_at IS NOT NULL", ID, spaceID).
+		Update("deleted_at", gorm.Expr("NULL"))
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wilt_id":  ID,
+			"space_id": spaceID,
+			"err":      db.Error,
+		}, "unable to restore work item link type")
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	if db.RowsAffected == 0 {
+		return nil, errors.NewNotFoundError("deleted work item link type", ID.String())
+	}
+	if err := r.bumpRevision(ctx, spaceID); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	return r.Load(ctx, ID)
 }
 
 // Save updates the given work item link type in storage. Version must be the same as the one int the stored version.
@@ -170,6 +678,12 @@ func (r *GormWorkItemLinkTypeRepository) Save(ctx context.Context, modelToSave W
 	if existingModel.Version != modelToSave.Version {
 		return nil, errors.NewVersionConflictError("version conflict")
 	}
+	if err := r.CheckUniqueForwardAndReverseName(ctx, modelToSave); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	if modelToSave.DescriptionMarkup == "" {
+		modelToSave.DescriptionMarkup = rendering.SystemMarkupDefault
+	}
 	modelToSave.Version = modelToSave.Version + 1
 	db = db.Save(&modelToSave)
 	if db.Error != nil {
@@ -180,6 +694,9 @@ func (r *GormWorkItemLinkTypeRepository) Save(ctx context.Context, modelToSave W
 		}, "unable to save work item link type repository")
 		return nil, errors.NewInternalError(ctx, db.Error)
 	}
+	if err := r.bumpRevision(ctx, modelToSave.SpaceID); err != nil {
+		return nil, errs.WithStack(err)
+	}
 	log.Info(ctx, map[string]interface{}{
 		"wilt_id": existingModel.ID,
 		"wilt":    existingModel,
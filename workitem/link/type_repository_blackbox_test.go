@@ -0,0 +1,668 @@
+package link_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type typeRepoBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	typeRepo *link.GormWorkItemLinkTypeRepository
+}
+
+func TestRunTypeRepoBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &typeRepoBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite("../../config.yaml")})
+}
+
+func (s *typeRepoBlackBoxTest) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.typeRepo = link.NewWorkItemLinkTypeRepository(s.DB)
+}
+
+func (s *typeRepoBlackBoxTest) TestCreateDefaultsForSpace() {
+	s.T().Run("only missing defaults are created", func(t *testing.T) {
+		// given a space that already has a link type named "existing"
+		fxt := tf.NewTestFixture(t, s.DB,
+			tf.WorkItemLinkTypes(1, tf.SetWorkItemLinkTypeNames("existing")),
+		)
+		spaceID := fxt.Spaces[0].ID
+		catID := fxt.WorkItemLinkCategories[0].ID
+		defaults := []link.WorkItemLinkType{
+			{Name: "existing", Topology: link.TopologyNetwork, ForwardName: "a", ReverseName: "b", LinkCategoryID: catID},
+			{Name: "new", Topology: link.TopologyNetwork, ForwardName: "c", ReverseName: "d", LinkCategoryID: catID},
+		}
+		// when
+		added, err := s.typeRepo.CreateDefaultsForSpace(s.Ctx, spaceID, defaults)
+		// then
+		require.NoError(t, err)
+		require.Len(t, added, 1)
+		require.Equal(t, "new", added[0].Name)
+		require.Equal(t, spaceID, added[0].SpaceID)
+		t.Run("re-running is a no-op", func(t *testing.T) {
+			added, err := s.typeRepo.CreateDefaultsForSpace(s.Ctx, spaceID, defaults)
+			require.NoError(t, err)
+			require.Empty(t, added)
+		})
+	})
+	s.T().Run("defaults with taken forward or reverse names are skipped", func(t *testing.T) {
+		// given a space with a link type using the forward name "a" and the
+		// reverse name "b"
+		fxt := tf.NewTestFixture(t, s.DB,
+			tf.WorkItemLinkTypes(1, func(fxt *tf.TestFixture, idx int) error {
+				fxt.WorkItemLinkTypes[idx].ForwardName = "a"
+				fxt.WorkItemLinkTypes[idx].ReverseName = "b"
+				return nil
+			}),
+		)
+		spaceID := fxt.Spaces[0].ID
+		catID := fxt.WorkItemLinkCategories[0].ID
+		defaults := []link.WorkItemLinkType{
+			{Name: "same forward name", Topology: link.TopologyNetwork, ForwardName: "a", ReverseName: "x", LinkCategoryID: catID},
+			{Name: "same reverse name", Topology: link.TopologyNetwork, ForwardName: "y", ReverseName: "b", LinkCategoryID: catID},
+			{Name: "new", Topology: link.TopologyNetwork, ForwardName: "c", ReverseName: "d", LinkCategoryID: catID},
+			{Name: "same forward name as new", Topology: link.TopologyNetwork, ForwardName: "c", ReverseName: "e", LinkCategoryID: catID},
+		}
+		// when
+		added, err := s.typeRepo.CreateDefaultsForSpace(s.Ctx, spaceID, defaults)
+		// then
+		require.NoError(t, err)
+		require.Len(t, added, 1)
+		require.Equal(t, "new", added[0].Name)
+	})
+}
+
+func (s *typeRepoBlackBoxTest) TestListByCategory() {
+	// given two link types in different spaces sharing one category and a
+	// third category without any link type
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Spaces(2),
+		tf.WorkItemLinkCategories(2),
+		tf.WorkItemLinkTypes(2, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].SpaceID = fxt.Spaces[idx].ID
+			return nil
+		}),
+	)
+	s.T().Run("referenced category", func(t *testing.T) {
+		types, err := s.typeRepo.ListByCategory(s.Ctx, fxt.WorkItemLinkCategories[0].ID)
+		require.NoError(t, err)
+		require.Len(t, types, 2)
+	})
+	s.T().Run("unreferenced category", func(t *testing.T) {
+		types, err := s.typeRepo.ListByCategory(s.Ctx, fxt.WorkItemLinkCategories[1].ID)
+		require.NoError(t, err)
+		require.Empty(t, types)
+	})
+}
+
+func (s *typeRepoBlackBoxTest) TestFindByForwardName() {
+	// given three link types with the same forward name in different spaces
+	// and one with another forward name
+	forwardName := "blocks " + uuid.NewV4().String()
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Spaces(3),
+		tf.WorkItemLinkTypes(4, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].SpaceID = fxt.Spaces[idx%3].ID
+			if idx < 3 {
+				fxt.WorkItemLinkTypes[idx].ForwardName = forwardName
+			}
+			return nil
+		}),
+	)
+	spaceIDs := func(types []link.WorkItemLinkType) map[uuid.UUID]struct{} {
+		res := map[uuid.UUID]struct{}{}
+		for _, t := range types {
+			res[t.SpaceID] = struct{}{}
+		}
+		return res
+	}
+	s.T().Run("all matches", func(t *testing.T) {
+		// when
+		types, count, err := s.typeRepo.FindByForwardName(s.Ctx, forwardName, nil, nil)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+		require.Len(t, types, 3)
+		require.Equal(t, map[uuid.UUID]struct{}{
+			fxt.Spaces[0].ID: {},
+			fxt.Spaces[1].ID: {},
+			fxt.Spaces[2].ID: {},
+		}, spaceIDs(types))
+	})
+	s.T().Run("paged", func(t *testing.T) {
+		// when
+		first, count, err := s.typeRepo.FindByForwardName(s.Ctx, forwardName, ptr.Int(0), ptr.Int(2))
+		require.NoError(t, err)
+		second, _, err := s.typeRepo.FindByForwardName(s.Ctx, forwardName, ptr.Int(2), ptr.Int(2))
+		require.NoError(t, err)
+		// then
+		require.Equal(t, 3, count)
+		require.Len(t, first, 2)
+		require.Len(t, second, 1)
+		require.Len(t, spaceIDs(append(first, second...)), 3)
+	})
+	s.T().Run("no match", func(t *testing.T) {
+		types, count, err := s.typeRepo.FindByForwardName(s.Ctx, uuid.NewV4().String(), nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+		require.Empty(t, types)
+	})
+	s.T().Run("empty name", func(t *testing.T) {
+		_, _, err := s.typeRepo.FindByForwardName(s.Ctx, "", nil, nil)
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+}
+
+func (s *typeRepoBlackBoxTest) TestList() {
+	s.T().Run("filter by creator", func(t *testing.T) {
+		// given two link types of which only the first has a creator
+		fxt := tf.NewTestFixture(t, s.DB,
+			tf.Identities(1),
+			tf.WorkItemLinkTypes(2, func(fxt *tf.TestFixture, idx int) error {
+				if idx == 0 {
+					fxt.WorkItemLinkTypes[idx].CreatedBy = &fxt.Identities[0].ID
+				}
+				return nil
+			}),
+		)
+		t.Run("known identity", func(t *testing.T) {
+			// when
+			types, _, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, &fxt.Identities[0].ID, nil, nil, false, nil, nil, nil)
+			// then
+			require.NoError(t, err)
+			require.Len(t, types, 1)
+			require.Equal(t, fxt.WorkItemLinkTypes[0].ID, types[0].ID)
+		})
+		t.Run("unknown identity", func(t *testing.T) {
+			// when
+			unknownID := uuid.NewV4()
+			types, _, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, &unknownID, nil, nil, false, nil, nil, nil)
+			// then
+			require.NoError(t, err)
+			require.Empty(t, types)
+		})
+	})
+	s.T().Run("filter by topology", func(t *testing.T) {
+		// given a tree and a network link type
+		fxt := tf.NewTestFixture(t, s.DB,
+			tf.Identities(1),
+			tf.WorkItemLinkTypes(2, tf.SetTopologies(link.TopologyTree, link.TopologyNetwork), func(fxt *tf.TestFixture, idx int) error {
+				fxt.WorkItemLinkTypes[idx].CreatedBy = &fxt.Identities[0].ID
+				return nil
+			}),
+		)
+		t.Run("known topology", func(t *testing.T) {
+			// when
+			topology := link.TopologyNetwork
+			types, count, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, &fxt.Identities[0].ID, &topology, nil, false, nil, nil, nil)
+			// then
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+			require.Len(t, types, 1)
+			require.Equal(t, fxt.WorkItemLinkTypes[1].ID, types[0].ID)
+		})
+		t.Run("unknown topology", func(t *testing.T) {
+			// when
+			topology := link.Topology("foo")
+			_, _, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, nil, &topology, nil, false, nil, nil, nil)
+			// then
+			require.Error(t, err)
+			require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		})
+	})
+	s.T().Run("filter by link category", func(t *testing.T) {
+		// given two link types in different link categories
+		fxt := tf.NewTestFixture(t, s.DB,
+			tf.Identities(1),
+			tf.WorkItemLinkCategories(2),
+			tf.WorkItemLinkTypes(2, func(fxt *tf.TestFixture, idx int) error {
+				fxt.WorkItemLinkTypes[idx].CreatedBy = &fxt.Identities[0].ID
+				fxt.WorkItemLinkTypes[idx].LinkCategoryID = fxt.WorkItemLinkCategories[idx].ID
+				return nil
+			}),
+		)
+		t.Run("known category", func(t *testing.T) {
+			// when
+			types, count, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, &fxt.Identities[0].ID, nil, &fxt.WorkItemLinkCategories[1].ID, false, nil, nil, nil)
+			// then
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+			require.Len(t, types, 1)
+			require.Equal(t, fxt.WorkItemLinkTypes[1].ID, types[0].ID)
+		})
+		t.Run("unknown category", func(t *testing.T) {
+			// when
+			unknownID := uuid.NewV4()
+			types, count, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, nil, nil, &unknownID, false, nil, nil, nil)
+			// then
+			require.NoError(t, err)
+			require.Equal(t, 0, count)
+			require.Empty(t, types)
+		})
+	})
+	s.T().Run("include deleted", func(t *testing.T) {
+		// given two link types of which the first one is deleted
+		fxt := tf.NewTestFixture(t, s.DB,
+			tf.Identities(1),
+			tf.WorkItemLinkTypes(2, func(fxt *tf.TestFixture, idx int) error {
+				fxt.WorkItemLinkTypes[idx].CreatedBy = &fxt.Identities[0].ID
+				return nil
+			}),
+		)
+		require.NoError(t, s.typeRepo.Delete(s.Ctx, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID))
+		t.Run("excluded by default", func(t *testing.T) {
+			// when
+			types, count, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, &fxt.Identities[0].ID, nil, nil, false, nil, nil, nil)
+			// then
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+			require.Len(t, types, 1)
+			require.Equal(t, fxt.WorkItemLinkTypes[1].ID, types[0].ID)
+		})
+		t.Run("included on request", func(t *testing.T) {
+			// when
+			types, count, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, &fxt.Identities[0].ID, nil, nil, true, nil, nil, nil)
+			// then
+			require.NoError(t, err)
+			require.Equal(t, 2, count)
+			require.Len(t, types, 2)
+			require.NotNil(t, types[0].DeletedAt)
+			require.Nil(t, types[1].DeletedAt)
+		})
+	})
+	s.T().Run("paging", func(t *testing.T) {
+		// given five link types in a space
+		fxt := tf.NewTestFixture(t, s.DB,
+			tf.Identities(1),
+			tf.WorkItemLinkTypes(5, func(fxt *tf.TestFixture, idx int) error {
+				fxt.WorkItemLinkTypes[idx].CreatedBy = &fxt.Identities[0].ID
+				return nil
+			}),
+		)
+		t.Run("page in the middle", func(t *testing.T) {
+			// when
+			start, limit := 1, 2
+			types, count, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, &fxt.Identities[0].ID, nil, nil, false, nil, &start, &limit)
+			// then
+			require.NoError(t, err)
+			require.Equal(t, 5, count)
+			require.Len(t, types, 2)
+			require.Equal(t, fxt.WorkItemLinkTypes[1].ID, types[0].ID)
+			require.Equal(t, fxt.WorkItemLinkTypes[2].ID, types[1].ID)
+		})
+		t.Run("page beyond the end", func(t *testing.T) {
+			// when
+			start, limit := 10, 2
+			types, count, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, &fxt.Identities[0].ID, nil, nil, false, nil, &start, &limit)
+			// then
+			require.NoError(t, err)
+			require.Equal(t, 5, count)
+			require.Empty(t, types)
+		})
+		t.Run("negative start", func(t *testing.T) {
+			// when
+			start := -1
+			_, _, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, nil, nil, nil, false, nil, &start, nil)
+			// then
+			require.Error(t, err)
+			require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		})
+	})
+}
+
+func (s *typeRepoBlackBoxTest) TestCount() {
+	// given two link types in a space
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.WorkItemLinkTypes(2))
+	_, expected, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, nil, nil, nil, false, nil, nil, nil)
+	require.NoError(s.T(), err)
+	s.T().Run("same as list", func(t *testing.T) {
+		// when
+		count, err := s.typeRepo.Count(s.Ctx, fxt.Spaces[0].ID)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, expected, count)
+	})
+	s.T().Run("deleted link types are not counted", func(t *testing.T) {
+		// given
+		require.NoError(t, s.typeRepo.Delete(s.Ctx, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID))
+		// when
+		count, err := s.typeRepo.Count(s.Ctx, fxt.Spaces[0].ID)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, expected-1, count)
+	})
+}
+
+func (s *typeRepoBlackBoxTest) TestRevisions() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.WorkItemLinkTypes(1))
+	revision := func(t *testing.T) int {
+		revisions, err := s.typeRepo.Revisions(s.Ctx, fxt.Spaces[0].ID)
+		require.NoError(t, err)
+		for _, r := range revisions {
+			if r.SpaceID == fxt.Spaces[0].ID {
+				return r.Revision
+			}
+		}
+		return 0
+	}
+	created := revision(s.T())
+	require.True(s.T(), created > 0)
+	s.T().Run("bumped by save", func(t *testing.T) {
+		_, err := s.typeRepo.Save(s.Ctx, *fxt.WorkItemLinkTypes[0])
+		require.NoError(t, err)
+		require.Equal(t, created+1, revision(t))
+	})
+	s.T().Run("bumped by delete and restore", func(t *testing.T) {
+		require.NoError(t, s.typeRepo.Delete(s.Ctx, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID))
+		require.Equal(t, created+2, revision(t))
+		_, err := s.typeRepo.Restore(s.Ctx, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, created+3, revision(t))
+	})
+}
+
+func (s *typeRepoBlackBoxTest) TestListSortedByUsageCount() {
+	// given three link types that are used by a different number of links
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(1),
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinkTypes(3, tf.SetWorkItemLinkTypeNames("most", "some", "none"), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].CreatedBy = &fxt.Identities[0].ID
+			return nil
+		}),
+		tf.WorkItemLinksCustom(3, tf.BuildLinks(tf.L("A", "B", "most"), tf.L("A", "C", "most"), tf.L("B", "C", "some"))),
+	)
+	s.T().Run("ascending", func(t *testing.T) {
+		// when
+		sort := link.SortByUsageCount
+		types, _, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, &fxt.Identities[0].ID, nil, nil, false, &sort, nil, nil)
+		// then
+		require.NoError(t, err)
+		require.Len(t, types, 3)
+		require.Equal(t, "none", types[0].Name)
+		require.Equal(t, "some", types[1].Name)
+		require.Equal(t, "most", types[2].Name)
+	})
+	s.T().Run("descending", func(t *testing.T) {
+		// when
+		sort := link.SortByUsageCountDesc
+		types, _, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, &fxt.Identities[0].ID, nil, nil, false, &sort, nil, nil)
+		// then
+		require.NoError(t, err)
+		require.Len(t, types, 3)
+		require.Equal(t, "most", types[0].Name)
+		require.Equal(t, "some", types[1].Name)
+		require.Equal(t, "none", types[2].Name)
+	})
+	s.T().Run("unknown sort key", func(t *testing.T) {
+		// when
+		sort := "foo"
+		_, _, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, nil, nil, nil, false, &sort, nil, nil)
+		// then
+		require.Error(t, err)
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+}
+
+func (s *typeRepoBlackBoxTest) TestListSortedByNameAndCreationTime() {
+	// given three link types whose names are not in the order of creation
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(1),
+		tf.WorkItemLinkTypes(3, tf.SetWorkItemLinkTypeNames("b", "c", "a"), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinkTypes[idx].CreatedBy = &fxt.Identities[0].ID
+			return nil
+		}),
+	)
+	names := func(types []link.WorkItemLinkType) []string {
+		res := make([]string, len(types))
+		for i, t := range types {
+			res[i] = t.Name
+		}
+		return res
+	}
+	testData := []struct {
+		sort     *string
+		expected []string
+	}{
+		{nil, []string{"b", "c", "a"}},
+		{ptr.String(link.SortByName), []string{"a", "b", "c"}},
+		{ptr.String(link.SortByNameDesc), []string{"c", "b", "a"}},
+		{ptr.String(link.SortByCreatedAt), []string{"b", "c", "a"}},
+		{ptr.String(link.SortByCreatedAtDesc), []string{"a", "c", "b"}},
+	}
+	for _, td := range testData {
+		name := "default"
+		if td.sort != nil {
+			name = *td.sort
+		}
+		s.T().Run(name, func(t *testing.T) {
+			// when
+			types, _, err := s.typeRepo.List(s.Ctx, fxt.Spaces[0].ID, &fxt.Identities[0].ID, nil, nil, false, td.sort, nil, nil)
+			// then
+			require.NoError(t, err)
+			require.Equal(t, td.expected, names(types))
+		})
+	}
+}
+
+func (s *typeRepoBlackBoxTest) TestRestore() {
+	s.T().Run("ok", func(t *testing.T) {
+		// given a deleted link type
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinkTypes(1))
+		require.NoError(t, s.typeRepo.Delete(s.Ctx, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID))
+		_, err := s.typeRepo.Load(s.Ctx, fxt.WorkItemLinkTypes[0].ID)
+		require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+		// when
+		restored, err := s.typeRepo.Restore(s.Ctx, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, fxt.WorkItemLinkTypes[0].ID, restored.ID)
+		require.Nil(t, restored.DeletedAt)
+		_, err = s.typeRepo.Load(s.Ctx, fxt.WorkItemLinkTypes[0].ID)
+		require.NoError(t, err)
+	})
+	s.T().Run("not deleted", func(t *testing.T) {
+		// given a link type that is not deleted
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinkTypes(1))
+		// when
+		_, err := s.typeRepo.Restore(s.Ctx, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID)
+		// then
+		require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+	s.T().Run("other space", func(t *testing.T) {
+		// given a deleted link type in the first of two spaces
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(2), tf.WorkItemLinkTypes(1))
+		require.NoError(t, s.typeRepo.Delete(s.Ctx, fxt.Spaces[0].ID, fxt.WorkItemLinkTypes[0].ID))
+		// when
+		_, err := s.typeRepo.Restore(s.Ctx, fxt.Spaces[1].ID, fxt.WorkItemLinkTypes[0].ID)
+		// then
+		require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+	s.T().Run("names reused in the meantime", func(t *testing.T) {
+		tests := map[string]func(deleted, reused *link.WorkItemLinkType){
+			"name": func(deleted, reused *link.WorkItemLinkType) {
+				reused.Name = deleted.Name
+			},
+			"forward name": func(deleted, reused *link.WorkItemLinkType) {
+				reused.ForwardName = deleted.ForwardName
+			},
+			"reverse name": func(deleted, reused *link.WorkItemLinkType) {
+				reused.ReverseName = deleted.ReverseName
+			},
+		}
+		for name, reuse := range tests {
+			t.Run(name, func(t *testing.T) {
+				// given a deleted link type and a new one reusing one of its
+				// names
+				fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinkTypes(1))
+				deleted := *fxt.WorkItemLinkTypes[0]
+				require.NoError(t, s.typeRepo.Delete(s.Ctx, deleted.SpaceID, deleted.ID))
+				reused := link.WorkItemLinkType{
+					Name:           "new " + deleted.Name,
+					Topology:       deleted.Topology,
+					ForwardName:    "new " + deleted.ForwardName,
+					ReverseName:    "new " + deleted.ReverseName,
+					LinkCategoryID: deleted.LinkCategoryID,
+					SpaceID:        deleted.SpaceID,
+				}
+				reuse(&deleted, &reused)
+				_, err := s.typeRepo.Create(s.Ctx, &reused)
+				require.NoError(t, err)
+				// when
+				_, err = s.typeRepo.Restore(s.Ctx, deleted.SpaceID, deleted.ID)
+				// then
+				require.IsType(t, errors.DataConflictError{}, errs.Cause(err))
+				_, err = s.typeRepo.Load(s.Ctx, deleted.ID)
+				require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+			})
+		}
+	})
+}
+
+func (s *typeRepoBlackBoxTest) TestDeleteAll() {
+	s.T().Run("ok", func(t *testing.T) {
+		// given two link types in the first of two spaces and one in the second
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(2), tf.WorkItemLinkTypes(3, func(fxt *tf.TestFixture, idx int) error {
+			if idx == 2 {
+				fxt.WorkItemLinkTypes[idx].SpaceID = fxt.Spaces[1].ID
+			}
+			return nil
+		}))
+		// when
+		count, err := s.typeRepo.DeleteAll(s.Ctx, fxt.Spaces[0].ID)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		for _, lt := range fxt.WorkItemLinkTypes[:2] {
+			_, err := s.typeRepo.Load(s.Ctx, lt.ID)
+			require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+		}
+		_, err = s.typeRepo.Load(s.Ctx, fxt.WorkItemLinkTypes[2].ID)
+		require.NoError(t, err)
+	})
+	s.T().Run("no link types", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1))
+		// when
+		count, err := s.typeRepo.DeleteAll(s.Ctx, fxt.Spaces[0].ID)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+	s.T().Run("system space", func(t *testing.T) {
+		// when
+		_, err := s.typeRepo.DeleteAll(s.Ctx, space.SystemSpace)
+		// then
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		_, err = s.typeRepo.Load(s.Ctx, link.SystemWorkItemLinkTypeBugBlockerID)
+		require.NoError(t, err)
+	})
+}
+
+func (s *typeRepoBlackBoxTest) TestUniqueForwardAndReverseName() {
+	// given two link types in the first of two spaces
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Spaces(2),
+		tf.WorkItemLinkTypes(2),
+	)
+	existing := *fxt.WorkItemLinkTypes[0]
+	candidate := func(spaceID uuid.UUID, forwardName, reverseName string) *link.WorkItemLinkType {
+		return &link.WorkItemLinkType{
+			Name:           "candidate " + uuid.NewV4().String(),
+			Topology:       link.TopologyNetwork,
+			SpaceID:        spaceID,
+			LinkCategoryID: existing.LinkCategoryID,
+			ForwardName:    forwardName,
+			ReverseName:    reverseName,
+		}
+	}
+	s.T().Run("create", func(t *testing.T) {
+		t.Run("conflict on same forward name", func(t *testing.T) {
+			_, err := s.typeRepo.Create(s.Ctx, candidate(existing.SpaceID, existing.ForwardName, "something else"))
+			require.Error(t, err)
+			require.IsType(t, errors.DataConflictError{}, errs.Cause(err))
+		})
+		t.Run("conflict on same reverse name", func(t *testing.T) {
+			_, err := s.typeRepo.Create(s.Ctx, candidate(existing.SpaceID, "something else", existing.ReverseName))
+			require.Error(t, err)
+			require.IsType(t, errors.DataConflictError{}, errs.Cause(err))
+		})
+		t.Run("ok in another space", func(t *testing.T) {
+			_, err := s.typeRepo.Create(s.Ctx, candidate(fxt.Spaces[1].ID, existing.ForwardName, existing.ReverseName))
+			require.NoError(t, err)
+		})
+	})
+	s.T().Run("save", func(t *testing.T) {
+		t.Run("conflict on forward name of another link type", func(t *testing.T) {
+			toSave := *fxt.WorkItemLinkTypes[1]
+			toSave.ForwardName = existing.ForwardName
+			_, err := s.typeRepo.Save(s.Ctx, toSave)
+			require.Error(t, err)
+			require.IsType(t, errors.DataConflictError{}, errs.Cause(err))
+		})
+		t.Run("conflict on reverse name of another link type", func(t *testing.T) {
+			toSave := *fxt.WorkItemLinkTypes[1]
+			toSave.ReverseName = existing.ReverseName
+			_, err := s.typeRepo.Save(s.Ctx, toSave)
+			require.Error(t, err)
+			require.IsType(t, errors.DataConflictError{}, errs.Cause(err))
+		})
+		t.Run("ok with unchanged names", func(t *testing.T) {
+			toSave := *fxt.WorkItemLinkTypes[1]
+			desc := "new description"
+			toSave.Description = &desc
+			_, err := s.typeRepo.Save(s.Ctx, toSave)
+			require.NoError(t, err)
+		})
+	})
+}
+
+func (s *typeRepoBlackBoxTest) TestSuggest() {
+	// given link types that are used a different number of times between work
+	// items of the same type and a tree link type for which A already has a
+	// parent
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinkTypes(3,
+			tf.SetWorkItemLinkTypeNames("often", "rare", "tree"),
+			tf.SetTopologies(link.TopologyNetwork, link.TopologyNetwork, link.TopologyTree),
+		),
+		tf.WorkItemLinksCustom(4, tf.BuildLinks(
+			tf.L("A", "B", "often"),
+			tf.L("A", "C", "often"),
+			tf.L("B", "C", "rare"),
+			tf.L("C", "A", "tree"),
+		)),
+	)
+	s.T().Run("ranked by usage", func(t *testing.T) {
+		// when
+		types, err := s.typeRepo.Suggest(s.Ctx, fxt.WorkItemByTitle("B").ID, fxt.WorkItemByTitle("A").ID)
+		// then
+		require.NoError(t, err)
+		require.True(t, len(types) >= 2)
+		require.Equal(t, "often", types[0].Name)
+		require.Equal(t, "rare", types[1].Name)
+		for _, lt := range types {
+			require.NotEqual(t, "tree", lt.Name, "target already has a parent")
+		}
+	})
+	s.T().Run("unknown target", func(t *testing.T) {
+		// when
+		_, err := s.typeRepo.Suggest(s.Ctx, fxt.WorkItemByTitle("B").ID, uuid.NewV4())
+		// then
+		require.Error(t, err)
+		require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+}
@@ -0,0 +1,67 @@
+package link
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+)
+
+// UsageGranularity determines the size of the time buckets in which link
+// creations are counted.
+type UsageGranularity string
+
+const (
+	UsageGranularityDay   UsageGranularity = "day"
+	UsageGranularityWeek  UsageGranularity = "week"
+	UsageGranularityMonth UsageGranularity = "month"
+)
+
+// CheckValid returns nil if the given granularity is valid; otherwise a
+// BadParameterError is returned.
+func (g UsageGranularity) CheckValid() error {
+	switch g {
+	case UsageGranularityDay, UsageGranularityWeek, UsageGranularityMonth:
+		return nil
+	default:
+		return errors.NewBadParameterError("granularity", g).Expected(UsageGranularityDay + "|" + UsageGranularityWeek + "|" + UsageGranularityMonth)
+	}
+}
+
+// MaxUsageBuckets is the maximum number of time buckets that the usage of a
+// link type can be requested for at once.
+const MaxUsageBuckets = 1000
+
+// bucketCount returns the number of buckets of this granularity between the
+// given times. Both bounds are truncated to the start of their bucket as in
+// the query, weeks start on Monday.
+func (g UsageGranularity) bucketCount(from, to time.Time) int {
+	switch g {
+	case UsageGranularityMonth:
+		return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month()) + 1
+	case UsageGranularityWeek:
+		return int(to.Sub(from).Hours()/(24*7)) + 2
+	default:
+		return int(to.Sub(from).Hours()/24) + 2
+	}
+}
+
+// CheckRange returns a BadParameterError if the range between from and to is
+// negative or would span more than MaxUsageBuckets buckets of this
+// granularity.
+func (g UsageGranularity) CheckRange(from, to time.Time) error {
+	if to.Before(from) {
+		return errors.NewBadParameterError("to", to).Expected(fmt.Sprintf("a time after %s", from))
+	}
+	if g.bucketCount(from, to) > MaxUsageBuckets {
+		return errors.NewBadParameterError("from", from).Expected(fmt.Sprintf("a time range of at most %d %ss", MaxUsageBuckets, g))
+	}
+	return nil
+}
+
+// UsageBucket holds the number of links of a single link type that were
+// created in the time bucket beginning at Start.
+type UsageBucket struct {
+	Start time.Time
+	Count int
+}